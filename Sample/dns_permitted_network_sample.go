@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/IBM/dns-svcs-go-sdk/common"
 	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
 )
 
@@ -43,9 +44,13 @@ func listDnsPermittedNetworks() {
 // createDnsPermittedNetwork -- Create a permitted network in DNS zone
 func createDnsPermittedNetwork() {
 	zoneID = os.Getenv("ZONE_ID")
-	vpcCrn := "vpc crn"
+	vpcCrn, err := common.ParseVpcCrn(os.Getenv("VPC_CRN"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	createPermittedNetworkOptions := dnsSvc.NewCreatePermittedNetworkOptions(instanceID, zoneID)
-	permittedNetworkCrn, err := dnsSvc.NewPermittedNetworkVpc(vpcCrn)
+	permittedNetworkCrn, err := dnsSvc.NewPermittedNetworkVpcFromCrn(vpcCrn)
 	if err == nil {
 		createPermittedNetworkOptions.SetPermittedNetwork(permittedNetworkCrn)
 		createPermittedNetworkOptions.SetType(dnssvcsv1.CreatePermittedNetworkOptions_Type_Vpc)
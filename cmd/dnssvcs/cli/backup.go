@@ -0,0 +1,114 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+)
+
+// BackupCommand returns the backup command, which writes everything in an instance to a JSON archive and restores
+// an archive into the same or another instance.
+func BackupCommand() *Command {
+	var file string
+	fileFlags := func(flags *flag.FlagSet) {
+		flags.StringVar(&file, "file", "-", "backup archive, - for stdin or stdout")
+	}
+	return &Command{
+		Name:        "backup",
+		Description: "backups of whole instances",
+		Verbs: map[string]*Verb{
+			"create": {
+				Flags: fileFlags,
+				Run: func(ctx *Context, args []string) (int, error) {
+					if len(args) != 0 {
+						return ExitUsage, fmt.Errorf("backup create takes no arguments")
+					}
+					dnsSvcs, err := ctx.DnsSvcs()
+					if err != nil {
+						return ExitError, err
+					}
+					backup, err := dnsSvcs.BackupInstance(ctx.InstanceID)
+					if err != nil {
+						return ExitError, err
+					}
+					data, err := backup.JSON()
+					if err != nil {
+						return ExitError, err
+					}
+					data = append(data, '\n')
+					if file == "-" {
+						_, err = ctx.Stdout.Write(data)
+					} else {
+						err = ioutil.WriteFile(file, data, 0600)
+					}
+					return ExitOK, err
+				},
+			},
+			"restore": {
+				Flags: fileFlags,
+				Run: func(ctx *Context, args []string) (int, error) {
+					if len(args) != 0 {
+						return ExitUsage, fmt.Errorf("backup restore takes no arguments")
+					}
+					var data []byte
+					var err error
+					if file == "-" {
+						data, err = ioutil.ReadAll(ctx.Stdin)
+					} else {
+						data, err = ioutil.ReadFile(file)
+					}
+					if err != nil {
+						return ExitError, err
+					}
+					backup, err := dnssvcsv1.ParseInstanceBackup(data)
+					if err != nil {
+						return ExitError, err
+					}
+					dnsSvcs, err := ctx.DnsSvcs()
+					if err != nil {
+						return ExitError, err
+					}
+					options := dnsSvcs.NewRestoreInstanceOptions(ctx.InstanceID, backup)
+					if ctx.Output == OutputTable {
+						options.SetProgress(func(step dnssvcsv1.RestoreInstanceStep) {
+							fmt.Fprintln(ctx.Stdout, step.String())
+						})
+					}
+					result, err := dnsSvcs.RestoreInstance(options)
+					if result == nil {
+						return ExitError, err
+					}
+					if ctx.Output != OutputTable {
+						if printErr := ctx.Print(result, nil); printErr != nil && err == nil {
+							err = printErr
+						}
+					} else {
+						fmt.Fprintf(ctx.Stdout, "Restored %d (%d partially), skipped %d, failed %d objects\n", result.Restored, len(result.Partial), result.Skipped, len(result.Failures))
+					}
+					if err != nil {
+						return ExitError, err
+					}
+					return ExitOK, nil
+				},
+			},
+		},
+	}
+}
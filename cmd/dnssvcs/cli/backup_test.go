@@ -0,0 +1,89 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/dns-svcs-go-sdk/cmd/dnssvcs/cli"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`dnssvcs backup`, func() {
+	var standIn *fakednssvcs.Server
+	var stdin, stdout, stderr *bytes.Buffer
+	var dir string
+	BeforeEach(func() {
+		standIn = fakednssvcs.NewServer()
+		standIn.Add("/instances/source/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		standIn.Add("/instances/source/dnszones/zone/resource_records", map[string]interface{}{"id": "www", "type": "A", "name": "www.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		standIn.Add("/instances/source/pools", map[string]interface{}{"id": "pool", "name": "web", "origins": []interface{}{map[string]interface{}{"name": "a", "address": "10.1.0.1"}}})
+		standIn.Add("/instances/source/dnszones/zone/load_balancers", map[string]interface{}{"id": "glb", "name": "glb.example.com", "fallback_pool": "pool", "default_pools": []interface{}{"pool"}})
+		os.Setenv("DNS_SVCS_AUTH_TYPE", "noauth")
+		stdin, stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+		var err error
+		dir, err = ioutil.TempDir("", "backup")
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		os.Unsetenv("DNS_SVCS_AUTH_TYPE")
+		os.RemoveAll(dir)
+		standIn.Close()
+	})
+	run := func(args ...string) int {
+		app := cli.NewApp(stdin, stdout, stderr)
+		return app.Run(append([]string{"-url", standIn.URL}, args...))
+	}
+	It(`Invoke backup create and restore`, func() {
+		file := filepath.Join(dir, "backup.json")
+		Expect(run("backup", "create", "-instance-id", "source", "-file", file)).To(Equal(cli.ExitOK))
+		data, err := ioutil.ReadFile(file)
+		Expect(err).To(BeNil())
+		var backup map[string]interface{}
+		Expect(json.Unmarshal(data, &backup)).To(Succeed())
+		Expect(backup["version"]).To(Equal(float64(1)))
+		Expect(backup["dnszones"]).To(HaveLen(1))
+
+		Expect(run("backup", "restore", "-instance-id", "target", "-file", file)).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(ContainSubstring("pool web (id pool): restored\n"))
+		Expect(stdout.String()).To(ContainSubstring("Restored 4 (0 partially), skipped 0, failed 0 objects\n"))
+		zones := standIn.List("/instances/target/dnszones")
+		Expect(zones).To(HaveLen(1))
+		loadBalancers := standIn.List("/instances/target/dnszones/" + zones[0]["id"].(string) + "/load_balancers")
+		Expect(loadBalancers[0]["fallback_pool"]).To(Equal(standIn.List("/instances/target/pools")[0]["id"]))
+
+		stdout.Reset()
+		stdin.Write(data)
+		Expect(run("-output", "json", "backup", "restore", "-instance-id", "target")).To(Equal(cli.ExitOK))
+		var result map[string]interface{}
+		Expect(json.Unmarshal(stdout.Bytes(), &result)).To(Succeed())
+		Expect(result["skipped"]).To(Equal(float64(4)))
+	})
+	It(`Invoke backup with errors`, func() {
+		Expect(run("backup", "create")).To(Equal(cli.ExitUsage))
+		Expect(run("backup", "create", "-instance-id", "source", "extra")).To(Equal(cli.ExitUsage))
+		stdin.WriteString(`{"version": 7}`)
+		Expect(run("backup", "restore", "-instance-id", "target")).To(Equal(cli.ExitError))
+		Expect(stderr.String()).To(ContainSubstring("unsupported instance backup version 7"))
+	})
+})
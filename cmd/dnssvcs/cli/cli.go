@@ -0,0 +1,444 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cli implements the dnssvcs command line: one command per kind of resource, each with list, get, create,
+// update and delete verbs, printing results as a table, JSON or YAML.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+)
+
+// Exit codes of Run.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// Verbs of the resource commands.
+const (
+	VerbList   = "list"
+	VerbGet    = "get"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+)
+
+// Environment variables read for the defaults of the -instance-id and -zone-id flags, in order of precedence. The
+// service URL and credentials are read by the SDK from the DNS_SVCS_* variables or credentials file.
+var (
+	InstanceIDEnvironment = []string{"DNS_SVCS_INSTANCE_ID", "INSTANCE_ID"}
+	DnszoneIDEnvironment  = []string{"DNS_SVCS_ZONE_ID", "ZONE_ID"}
+)
+
+// Resource : A kind of object managed by a command. Verbs left nil are not supported by the resource.
+type Resource struct {
+	// The name of the command, for example "zones".
+	Name string
+
+	// One-line description shown in the usage.
+	Description string
+
+	// Whether the objects belong to a DNS zone, so that the commands need a zone ID.
+	Zoned bool
+
+	// Whether the objects are outside DNS Services instances, so that the commands need no instance ID.
+	Unscoped bool
+
+	// The columns of the table output.
+	Columns []Column
+
+	List   func(ctx *Context) (interface{}, error)
+	Get    func(ctx *Context, id string) (interface{}, error)
+	Create func(ctx *Context, data map[string]interface{}) (interface{}, error)
+	Update func(ctx *Context, id string, data map[string]interface{}) (interface{}, error)
+	Delete func(ctx *Context, id string) error
+}
+
+// Command : A command whose verbs are operations other than list, get, create, update and delete, such as zone
+// export.
+type Command struct {
+	// The name of the command, for example "zone".
+	Name string
+
+	// One-line description shown in the usage.
+	Description string
+
+	// The verbs of the command by name.
+	Verbs map[string]*Verb
+}
+
+// Verb : A verb of a Command.
+type Verb struct {
+	// Whether the verb applies to a DNS zone, so that it needs a zone ID.
+	Zoned bool
+
+	// Defines the flags of the verb, besides -instance-id and -zone-id. It is called for every run, before parsing.
+	Flags func(flags *flag.FlagSet)
+
+	// Runs the verb with its positional arguments and returns the exit code. An error is printed, and the exit code
+	// is then ExitError unless another one is returned.
+	Run func(ctx *Context, args []string) (int, error)
+}
+
+// Column : A column of the table output.
+type Column struct {
+	// The heading of the column.
+	Header string
+
+	// The dot-separated path of the JSON property shown, for example "permitted_network.vpc_crn".
+	Path string
+}
+
+// Context : The settings a command runs with.
+type Context struct {
+	// The instance the command applies to.
+	InstanceID string
+
+	// The DNS zone the command applies to, for resources in a zone.
+	DnszoneID string
+
+	// The service URL, overriding the configured one when set.
+	URL string
+
+	// The output format, one of OutputTable, OutputJSON and OutputYAML.
+	Output string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+
+	// Looks up environment variables, the Getenv of the App.
+	Getenv func(string) string
+
+	dnsSvcs *dnssvcsv1.DnsSvcsV1
+}
+
+// DnsSvcs returns the DNS Services client, configured from the environment as NewDnsSvcsV1UsingExternalConfig does.
+func (ctx *Context) DnsSvcs() (*dnssvcsv1.DnsSvcsV1, error) {
+	if ctx.dnsSvcs == nil {
+		dnsSvcs, err := dnssvcsv1.NewDnsSvcsV1UsingExternalConfig(&dnssvcsv1.DnsSvcsV1Options{URL: ctx.URL})
+		if err != nil {
+			return nil, err
+		}
+		ctx.dnsSvcs = dnsSvcs
+	}
+	return ctx.dnsSvcs, nil
+}
+
+// Print writes a result, an object or a slice of objects, in the output format of the command line. Tables show
+// the given columns.
+func (ctx *Context) Print(result interface{}, columns []Column) error {
+	return writeOutput(ctx.Stdout, ctx.Output, columns, result)
+}
+
+// App : The dnssvcs command line.
+type App struct {
+	// The name of the program in messages.
+	Name string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Looks up environment variables, os.Getenv by default.
+	Getenv func(string) string
+
+	resources map[string]*Resource
+	commands  map[string]*Command
+}
+
+// NewApp : Instantiate App with the DNS Services resources.
+func NewApp(stdin io.Reader, stdout io.Writer, stderr io.Writer) *App {
+	app := &App{
+		Name:      "dnssvcs",
+		Stdin:     stdin,
+		Stdout:    stdout,
+		Stderr:    stderr,
+		Getenv:    os.Getenv,
+		resources: make(map[string]*Resource),
+		commands:  make(map[string]*Command),
+	}
+	for _, resource := range DnsSvcsResources() {
+		app.Register(resource)
+	}
+	app.RegisterCommand(ZoneCommand())
+	app.RegisterCommand(BackupCommand())
+	return app
+}
+
+// Register adds the command of a resource, replacing any of the same name.
+func (app *App) Register(resource *Resource) {
+	app.resources[resource.Name] = resource
+}
+
+// RegisterCommand adds a command, replacing any of the same name.
+func (app *App) RegisterCommand(command *Command) {
+	app.commands[command.Name] = command
+}
+
+// Run runs the command line given by args, without the program name, and returns the exit code.
+//
+//	dnssvcs [-output table|json|yaml] [-url URL] <resource> <verb> [-instance-id ID] [-zone-id ID] [-data DATA] [id]
+func (app *App) Run(args []string) int {
+	global := flag.NewFlagSet(app.Name, flag.ContinueOnError)
+	global.SetOutput(ioutil.Discard)
+	output := global.String("output", OutputTable, "output format: table, json or yaml")
+	url := global.String("url", "", "service URL, overriding the configured one")
+	err := global.Parse(args)
+	if err == flag.ErrHelp || (err == nil && global.NArg() > 0 && global.Arg(0) == "help") {
+		app.usage()
+		return ExitOK
+	}
+	if err != nil {
+		return app.usageError(err)
+	}
+	if !isOutputFormat(*output) {
+		return app.usageError(fmt.Errorf("unknown output format %q", *output))
+	}
+	if global.NArg() < 2 {
+		return app.usageError(errors.New("a resource and a verb are required"))
+	}
+	ctx := &Context{URL: *url, Output: *output, Stdin: app.Stdin, Stdout: app.Stdout, Getenv: app.Getenv}
+	if command, found := app.commands[global.Arg(0)]; found {
+		return app.runCommand(ctx, command, global.Arg(1), global.Args()[2:])
+	}
+	resource, found := app.resources[global.Arg(0)]
+	if !found {
+		return app.usageError(fmt.Errorf("unknown resource %q", global.Arg(0)))
+	}
+	verb := global.Arg(1)
+	if !resource.supports(verb) {
+		return app.usageError(fmt.Errorf("%s does not support %q", resource.Name, verb))
+	}
+
+	command := app.newFlagSet(ctx, resource.Name+" "+verb, !resource.Unscoped, resource.Zoned)
+	var data string
+	if verb == VerbCreate || verb == VerbUpdate {
+		command.StringVar(&data, "data", "", "the object as JSON or YAML, @file to read it from a file or - from stdin")
+	}
+	positional, err := parseInterspersed(command, global.Args()[2:])
+	if err != nil {
+		return app.usageError(err)
+	}
+	wantsID := verb == VerbGet || verb == VerbUpdate || verb == VerbDelete
+	if wantsID && len(positional) != 1 {
+		return app.usageError(fmt.Errorf("%s %s takes one ID", resource.Name, verb))
+	}
+	if !wantsID && len(positional) != 0 {
+		return app.usageError(fmt.Errorf("%s %s takes no arguments", resource.Name, verb))
+	}
+	if err = checkScope(ctx, !resource.Unscoped, resource.Zoned); err != nil {
+		return app.usageError(err)
+	}
+	var object map[string]interface{}
+	if verb == VerbCreate || verb == VerbUpdate {
+		object, err = app.readData(data)
+		if err != nil {
+			return app.usageError(err)
+		}
+	}
+
+	var result interface{}
+	switch verb {
+	case VerbList:
+		result, err = resource.List(ctx)
+	case VerbGet:
+		result, err = resource.Get(ctx, positional[0])
+	case VerbCreate:
+		result, err = resource.Create(ctx, object)
+	case VerbUpdate:
+		result, err = resource.Update(ctx, positional[0], object)
+	case VerbDelete:
+		err = resource.Delete(ctx, positional[0])
+		if err == nil && *output == OutputTable {
+			fmt.Fprintf(app.Stdout, "Deleted %s %s\n", resource.Name, positional[0])
+		}
+	}
+	if err == nil && verb != VerbDelete {
+		err = ctx.Print(result, resource.Columns)
+	}
+	if err != nil {
+		fmt.Fprintf(app.Stderr, "%s: %s\n", app.Name, err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+func (app *App) runCommand(ctx *Context, command *Command, name string, args []string) int {
+	verb, found := command.Verbs[name]
+	if !found {
+		return app.usageError(fmt.Errorf("%s does not support %q", command.Name, name))
+	}
+	flags := app.newFlagSet(ctx, command.Name+" "+name, true, verb.Zoned)
+	if verb.Flags != nil {
+		verb.Flags(flags)
+	}
+	positional, err := parseInterspersed(flags, args)
+	if err == nil {
+		err = checkScope(ctx, true, verb.Zoned)
+	}
+	if err != nil {
+		return app.usageError(err)
+	}
+	code, err := verb.Run(ctx, positional)
+	if err != nil {
+		fmt.Fprintf(app.Stderr, "%s: %s\n", app.Name, err)
+		if code == ExitOK {
+			code = ExitError
+		}
+	}
+	return code
+}
+
+// newFlagSet returns the flags of a verb, with -instance-id and -zone-id when it needs them.
+func (app *App) newFlagSet(ctx *Context, name string, scoped bool, zoned bool) *flag.FlagSet {
+	flags := flag.NewFlagSet(app.Name+" "+name, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	if scoped {
+		flags.StringVar(&ctx.InstanceID, "instance-id", app.lookupEnv(InstanceIDEnvironment), "instance ID")
+	}
+	if zoned {
+		flags.StringVar(&ctx.DnszoneID, "zone-id", app.lookupEnv(DnszoneIDEnvironment), "DNS zone ID")
+	}
+	return flags
+}
+
+// checkScope returns an error when the instance or zone ID a verb needs is missing.
+func checkScope(ctx *Context, scoped bool, zoned bool) error {
+	if scoped && ctx.InstanceID == "" {
+		return errors.New("an instance ID is required: set -instance-id or " + InstanceIDEnvironment[0])
+	}
+	if zoned && ctx.DnszoneID == "" {
+		return errors.New("a zone ID is required: set -zone-id or " + DnszoneIDEnvironment[0])
+	}
+	return nil
+}
+
+// supports reports whether a resource implements a verb.
+func (resource *Resource) supports(verb string) bool {
+	switch verb {
+	case VerbList:
+		return resource.List != nil
+	case VerbGet:
+		return resource.Get != nil
+	case VerbCreate:
+		return resource.Create != nil
+	case VerbUpdate:
+		return resource.Update != nil
+	case VerbDelete:
+		return resource.Delete != nil
+	}
+	return false
+}
+
+// verbs returns the names of the verbs a resource implements.
+func (resource *Resource) verbs() (verbs []string) {
+	for _, verb := range []string{VerbList, VerbGet, VerbCreate, VerbUpdate, VerbDelete} {
+		if resource.supports(verb) {
+			verbs = append(verbs, verb)
+		}
+	}
+	return
+}
+
+// parseInterspersed parses flags appearing before or after the positional arguments.
+func parseInterspersed(flags *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		err = flags.Parse(args)
+		if err != nil || flags.NArg() == 0 {
+			return
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// lookupEnv returns the value of the first of the variables that is set.
+func (app *App) lookupEnv(names []string) string {
+	for _, name := range names {
+		if value := app.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// readData reads the object given to -data: inline, from a file with @file or from stdin with -.
+func (app *App) readData(data string) (object map[string]interface{}, err error) {
+	content := []byte(data)
+	switch {
+	case data == "":
+		return nil, errors.New("-data is required")
+	case data == "-" || data == "@-":
+		content, err = ioutil.ReadAll(app.Stdin)
+	case strings.HasPrefix(data, "@"):
+		content, err = ioutil.ReadFile(data[1:])
+	}
+	if err != nil {
+		return
+	}
+	return parseObject(content)
+}
+
+func (app *App) usageError(err error) int {
+	fmt.Fprintf(app.Stderr, "%s: %s\nRun '%s help' for usage.\n", app.Name, err, app.Name)
+	return ExitUsage
+}
+
+func (app *App) usage() {
+	fmt.Fprintf(app.Stdout, "Usage: %s [-output table|json|yaml] [-url URL] <resource> <verb> [flags] [id]\n\n", app.Name)
+	fmt.Fprintf(app.Stdout, "Resources:\n")
+	names := make([]string, 0, len(app.resources))
+	for name := range app.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resource := app.resources[name]
+		fmt.Fprintf(app.Stdout, "  %-20s %s (%s)\n", name, resource.Description, strings.Join(resource.verbs(), ", "))
+	}
+	fmt.Fprintf(app.Stdout, "\nCommands:\n")
+	names = names[:0]
+	for name := range app.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		command := app.commands[name]
+		verbs := make([]string, 0, len(command.Verbs))
+		for verb := range command.Verbs {
+			verbs = append(verbs, verb)
+		}
+		sort.Strings(verbs)
+		fmt.Fprintf(app.Stdout, "  %-20s %s (%s)\n", name, command.Description, strings.Join(verbs, ", "))
+	}
+	fmt.Fprintf(app.Stdout, "\nFlags:\n")
+	fmt.Fprintf(app.Stdout, "  -instance-id ID  instance ID, from %s by default\n", strings.Join(InstanceIDEnvironment, " or "))
+	fmt.Fprintf(app.Stdout, "  -zone-id ID      DNS zone ID of zoned resources, from %s by default\n", strings.Join(DnszoneIDEnvironment, " or "))
+	fmt.Fprintf(app.Stdout, "  -data DATA       object to create or update as JSON or YAML, @file or - for stdin\n")
+	fmt.Fprintf(app.Stdout, "\nThe service URL and credentials are configured as for NewDnsSvcsV1UsingExternalConfig,\n")
+	fmt.Fprintf(app.Stdout, "for example with DNS_SVCS_URL, DNS_SVCS_AUTH_TYPE and DNS_SVCS_APIKEY.\n")
+}
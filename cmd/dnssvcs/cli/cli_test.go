@@ -0,0 +1,129 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/cmd/dnssvcs/cli"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe(`dnssvcs`, func() {
+	var standIn *fakednssvcs.Server
+	var stdin, stdout, stderr *bytes.Buffer
+	var env map[string]string
+	const zonesPath = "/instances/instance/dnszones"
+	const recordsPath = "/instances/instance/dnszones/zone/resource_records"
+	BeforeEach(func() {
+		standIn = fakednssvcs.NewServer()
+		standIn.Add(zonesPath, map[string]interface{}{"id": "zone", "name": "example.com", "state": "ACTIVE", "label": "us-south"})
+		standIn.Add(recordsPath, map[string]interface{}{"id": "www", "type": "A", "name": "www.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		os.Setenv("DNS_SVCS_AUTH_TYPE", "noauth")
+		stdin, stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+		env = map[string]string{"INSTANCE_ID": "instance"}
+	})
+	AfterEach(func() {
+		os.Unsetenv("DNS_SVCS_AUTH_TYPE")
+		standIn.Close()
+	})
+	run := func(args ...string) int {
+		app := cli.NewApp(stdin, stdout, stderr)
+		app.Getenv = func(name string) string {
+			return env[name]
+		}
+		return app.Run(append([]string{"-url", standIn.URL}, args...))
+	}
+	It(`Invoke list with table output`, func() {
+		Expect(run("zones", "list")).To(Equal(cli.ExitOK))
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(strings.Fields(lines[0])).To(Equal([]string{"ID", "NAME", "STATE", "LABEL", "DESCRIPTION"}))
+		Expect(strings.Fields(lines[1])).To(Equal([]string{"zone", "example.com", "ACTIVE", "us-south"}))
+
+		stdout.Reset()
+		Expect(run("records", "list", "-zone-id", "zone")).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(ContainSubstring(`{"ip":"10.0.0.1"}`))
+	})
+	It(`Invoke get with JSON and YAML output`, func() {
+		env["DNS_SVCS_ZONE_ID"] = "zone"
+		Expect(run("-output", "json", "records", "get", "www")).To(Equal(cli.ExitOK))
+		var record map[string]interface{}
+		Expect(json.Unmarshal(stdout.Bytes(), &record)).To(Succeed())
+		Expect(record["name"]).To(Equal("www.example.com"))
+		Expect(record["rdata"]).To(Equal(map[string]interface{}{"ip": "10.0.0.1"}))
+
+		stdout.Reset()
+		Expect(run("-output", "yaml", "zones", "get", "zone", "-instance-id", "instance")).To(Equal(cli.ExitOK))
+		var zone map[string]interface{}
+		Expect(yaml.Unmarshal(stdout.Bytes(), &zone)).To(Succeed())
+		Expect(zone["label"]).To(Equal("us-south"))
+	})
+	It(`Invoke create, update and delete`, func() {
+		Expect(run("records", "create", "-zone-id", "zone", "-data", "type: A\nname: api\nrdata:\n  ip: 10.0.0.2\n")).To(Equal(cli.ExitOK))
+		Expect(standIn.Bodies["POST "+recordsPath]).To(Equal(map[string]interface{}{"type": "A", "name": "api", "rdata": map[string]interface{}{"ip": "10.0.0.2"}}))
+		Expect(standIn.List(recordsPath)).To(HaveLen(2))
+
+		file, err := ioutil.TempFile("", "update-*.json")
+		Expect(err).To(BeNil())
+		defer os.Remove(file.Name())
+		_, err = file.WriteString(`{"ttl": 60, "rdata": {"ip": "10.0.0.3"}}`)
+		Expect(err).To(BeNil())
+		file.Close()
+		Expect(run("records", "update", "-zone-id", "zone", "www", "-data", "@"+file.Name())).To(Equal(cli.ExitOK))
+		Expect(standIn.Bodies["PUT "+recordsPath+"/www"]).To(Equal(map[string]interface{}{"ttl": float64(60), "rdata": map[string]interface{}{"ip": "10.0.0.3"}}))
+
+		stdin.WriteString(`{"description": "updated"}`)
+		Expect(run("zones", "update", "zone", "-data", "-")).To(Equal(cli.ExitOK))
+		Expect(standIn.List(zonesPath)[0]["description"]).To(Equal("updated"))
+
+		stdout.Reset()
+		Expect(run("records", "delete", "-zone-id", "zone", "www")).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(Equal("Deleted records www\n"))
+		Expect(standIn.List(recordsPath)).To(HaveLen(1))
+	})
+	It(`Invoke with usage errors`, func() {
+		Expect(run("help")).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(ContainSubstring("permitted-networks"))
+
+		Expect(run("records", "list")).To(Equal(cli.ExitUsage))
+		Expect(stderr.String()).To(ContainSubstring("a zone ID is required"))
+		Expect(run("domains", "list")).To(Equal(cli.ExitUsage))
+		Expect(run("permitted-networks", "update", "-zone-id", "zone", "pn")).To(Equal(cli.ExitUsage))
+		Expect(run("zones", "get")).To(Equal(cli.ExitUsage))
+		Expect(run("-output", "xml", "zones", "list")).To(Equal(cli.ExitUsage))
+		Expect(run("zones", "create", "-data", `{"nmae": "example.org"}`)).To(Equal(cli.ExitError))
+		Expect(stderr.String()).To(ContainSubstring(`unknown field "nmae"`))
+		Expect(run("zones", "update", "zone", "-data", `{"instance_id": "other"}`)).To(Equal(cli.ExitError))
+
+		delete(env, "INSTANCE_ID")
+		Expect(run("pools", "list")).To(Equal(cli.ExitUsage))
+		Expect(standIn.Requests).To(BeEmpty())
+	})
+	It(`Invoke with a service error`, func() {
+		Expect(run("monitors", "get", "missing")).To(Equal(cli.ExitError))
+		Expect(stderr.String()).To(ContainSubstring("missing not found"))
+		Expect(stdout.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,174 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+func isOutputFormat(format string) bool {
+	return format == OutputTable || format == OutputJSON || format == OutputYAML
+}
+
+// writeOutput prints a result, an object or a slice of objects, in an output format.
+func writeOutput(w io.Writer, format string, columns []Column, result interface{}) error {
+	var generic interface{}
+	err := Decode(result, &generic)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case OutputJSON:
+		data, err := json.MarshalIndent(generic, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case OutputYAML:
+		data, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	rows, isList := generic.([]interface{})
+	if !isList {
+		rows = []interface{}{generic}
+	}
+	table := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	fmt.Fprintln(table, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = cell(lookup(row, column.Path))
+		}
+		fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	return table.Flush()
+}
+
+// lookup returns the property at a dot-separated path of a JSON object, nil when it is missing.
+func lookup(object interface{}, path string) interface{} {
+	for _, name := range strings.Split(path, ".") {
+		properties, isObject := object.(map[string]interface{})
+		if !isObject {
+			return nil
+		}
+		object = properties[name]
+	}
+	return object
+}
+
+// cell formats a JSON value for a table: scalars as text, arrays of scalars comma-separated and anything else as
+// compact JSON.
+func cell(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				data, _ := json.Marshal(value)
+				return string(data)
+			}
+			items[i] = cell(item)
+		}
+		return strings.Join(items, ",")
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// Decode converts a value to another type through JSON, for example a map of properties to SDK options. Properties
+// the target type does not have are an error, so that misspelled ones are not silently ignored.
+func Decode(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, isMap := from.(map[string]interface{}); isMap {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(to)
+}
+
+// parseObject parses a JSON or YAML object.
+func parseObject(data []byte) (object map[string]interface{}, err error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		err = json.Unmarshal(trimmed, &object)
+		return
+	}
+	var parsed interface{}
+	err = yaml.Unmarshal(trimmed, &parsed)
+	if err != nil {
+		return
+	}
+	object, isObject := jsonValue(parsed).(map[string]interface{})
+	if !isObject {
+		return nil, fmt.Errorf("the data is not an object")
+	}
+	return
+}
+
+// jsonValue converts the maps decoded from YAML to the maps decoded from JSON.
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			object[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return object
+	case []interface{}:
+		for i, item := range value {
+			value[i] = jsonValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,422 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"errors"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+)
+
+// DnsSvcsResources returns the resources of the DNS Services API: zones, resource records, permitted networks, load
+// balancers, pools and monitors.
+func DnsSvcsResources() []*Resource {
+	return []*Resource{
+		zonesResource(),
+		recordsResource(),
+		permittedNetworksResource(),
+		loadBalancersResource(),
+		poolsResource(),
+		monitorsResource(),
+	}
+}
+
+func zonesResource() *Resource {
+	return &Resource{
+		Name:        "zones",
+		Description: "DNS zones",
+		Columns:     []Column{{"ID", "id"}, {"NAME", "name"}, {"STATE", "state"}, {"LABEL", "label"}, {"DESCRIPTION", "description"}},
+		List: func(ctx *Context) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			return dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(ctx.InstanceID))
+		},
+		Get: func(ctx *Context, id string) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.GetDnszone(dnsSvcs.NewGetDnszoneOptions(ctx.InstanceID, id))
+			return result, err
+		},
+		Create: func(ctx *Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewCreateDnszoneOptions(ctx.InstanceID, "")
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.CreateDnszone(options)
+			return result, err
+		},
+		Update: func(ctx *Context, id string, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewUpdateDnszoneOptions(ctx.InstanceID, id)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.UpdateDnszone(options)
+			return result, err
+		},
+		Delete: func(ctx *Context, id string) error {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return err
+			}
+			_, err = dnsSvcs.DeleteDnszone(dnsSvcs.NewDeleteDnszoneOptions(ctx.InstanceID, id))
+			return err
+		},
+	}
+}
+
+func recordsResource() *Resource {
+	return &Resource{
+		Name:        "records",
+		Description: "resource records of a DNS zone",
+		Zoned:       true,
+		Columns:     []Column{{"ID", "id"}, {"TYPE", "type"}, {"NAME", "name"}, {"TTL", "ttl"}, {"RDATA", "rdata"}},
+		List: func(ctx *Context) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			return dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(ctx.InstanceID, ctx.DnszoneID))
+		},
+		Get: func(ctx *Context, id string) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.GetResourceRecord(dnsSvcs.NewGetResourceRecordOptions(ctx.InstanceID, ctx.DnszoneID, id))
+			return result, err
+		},
+		Create: func(ctx *Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewCreateResourceRecordOptions(ctx.InstanceID, ctx.DnszoneID)
+			rdata := &dnssvcsv1.ResourceRecordInputRdata{}
+			found, err := decodeRdata(data, rdata)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				options.SetRdata(rdata)
+			}
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.CreateResourceRecord(options)
+			return result, err
+		},
+		Update: func(ctx *Context, id string, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewUpdateResourceRecordOptions(ctx.InstanceID, ctx.DnszoneID, id)
+			rdata := &dnssvcsv1.ResourceRecordUpdateInputRdata{}
+			found, err := decodeRdata(data, rdata)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				options.SetRdata(rdata)
+			}
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.UpdateResourceRecord(options)
+			return result, err
+		},
+		Delete: func(ctx *Context, id string) error {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return err
+			}
+			_, err = dnsSvcs.DeleteResourceRecord(dnsSvcs.NewDeleteResourceRecordOptions(ctx.InstanceID, ctx.DnszoneID, id))
+			return err
+		},
+	}
+}
+
+func permittedNetworksResource() *Resource {
+	return &Resource{
+		Name:        "permitted-networks",
+		Description: "VPCs permitted to resolve a DNS zone",
+		Zoned:       true,
+		Columns:     []Column{{"ID", "id"}, {"TYPE", "type"}, {"VPC_CRN", "permitted_network.vpc_crn"}, {"STATE", "state"}},
+		List: func(ctx *Context) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			return dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(ctx.InstanceID, ctx.DnszoneID))
+		},
+		Get: func(ctx *Context, id string) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.GetPermittedNetwork(dnsSvcs.NewGetPermittedNetworkOptions(ctx.InstanceID, ctx.DnszoneID, id))
+			return result, err
+		},
+		Create: func(ctx *Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewCreatePermittedNetworkOptions(ctx.InstanceID, ctx.DnszoneID)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.CreatePermittedNetwork(options)
+			return result, err
+		},
+		Delete: func(ctx *Context, id string) error {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return err
+			}
+			_, _, err = dnsSvcs.DeletePermittedNetwork(dnsSvcs.NewDeletePermittedNetworkOptions(ctx.InstanceID, ctx.DnszoneID, id))
+			return err
+		},
+	}
+}
+
+func loadBalancersResource() *Resource {
+	return &Resource{
+		Name:        "load-balancers",
+		Description: "global load balancers of a DNS zone",
+		Zoned:       true,
+		Columns:     []Column{{"ID", "id"}, {"NAME", "name"}, {"ENABLED", "enabled"}, {"HEALTH", "health"}, {"TTL", "ttl"}, {"FALLBACK_POOL", "fallback_pool"}, {"DEFAULT_POOLS", "default_pools"}},
+		List: func(ctx *Context) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.ListLoadBalancers(dnsSvcs.NewListLoadBalancersOptions(ctx.InstanceID, ctx.DnszoneID))
+			if err != nil {
+				return nil, err
+			}
+			return result.LoadBalancers, nil
+		},
+		Get: func(ctx *Context, id string) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.GetLoadBalancer(dnsSvcs.NewGetLoadBalancerOptions(ctx.InstanceID, ctx.DnszoneID, id))
+			return result, err
+		},
+		Create: func(ctx *Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewCreateLoadBalancerOptions(ctx.InstanceID, ctx.DnszoneID)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.CreateLoadBalancer(options)
+			return result, err
+		},
+		Update: func(ctx *Context, id string, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewUpdateLoadBalancerOptions(ctx.InstanceID, ctx.DnszoneID, id)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.UpdateLoadBalancer(options)
+			return result, err
+		},
+		Delete: func(ctx *Context, id string) error {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return err
+			}
+			_, err = dnsSvcs.DeleteLoadBalancer(dnsSvcs.NewDeleteLoadBalancerOptions(ctx.InstanceID, ctx.DnszoneID, id))
+			return err
+		},
+	}
+}
+
+func poolsResource() *Resource {
+	return &Resource{
+		Name:        "pools",
+		Description: "load balancer pools",
+		Columns:     []Column{{"ID", "id"}, {"NAME", "name"}, {"ENABLED", "enabled"}, {"HEALTH", "health"}, {"MONITOR", "monitor"}, {"ORIGINS", "origins"}},
+		List: func(ctx *Context) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.ListPools(dnsSvcs.NewListPoolsOptions(ctx.InstanceID))
+			if err != nil {
+				return nil, err
+			}
+			return result.Pools, nil
+		},
+		Get: func(ctx *Context, id string) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.GetPool(dnsSvcs.NewGetPoolOptions(ctx.InstanceID, id))
+			return result, err
+		},
+		Create: func(ctx *Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewCreatePoolOptions(ctx.InstanceID)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.CreatePool(options)
+			return result, err
+		},
+		Update: func(ctx *Context, id string, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewUpdatePoolOptions(ctx.InstanceID, id)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.UpdatePool(options)
+			return result, err
+		},
+		Delete: func(ctx *Context, id string) error {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return err
+			}
+			_, err = dnsSvcs.DeletePool(dnsSvcs.NewDeletePoolOptions(ctx.InstanceID, id))
+			return err
+		},
+	}
+}
+
+func monitorsResource() *Resource {
+	return &Resource{
+		Name:        "monitors",
+		Description: "load balancer health check monitors",
+		Columns:     []Column{{"ID", "id"}, {"NAME", "name"}, {"TYPE", "type"}, {"PORT", "port"}, {"PATH", "path"}, {"INTERVAL", "interval"}, {"EXPECTED_CODES", "expected_codes"}},
+		List: func(ctx *Context) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.ListMonitors(dnsSvcs.NewListMonitorsOptions(ctx.InstanceID))
+			if err != nil {
+				return nil, err
+			}
+			return result.Monitors, nil
+		},
+		Get: func(ctx *Context, id string) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.GetMonitor(dnsSvcs.NewGetMonitorOptions(ctx.InstanceID, id))
+			return result, err
+		},
+		Create: func(ctx *Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewCreateMonitorOptions(ctx.InstanceID)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.CreateMonitor(options)
+			return result, err
+		},
+		Update: func(ctx *Context, id string, data map[string]interface{}) (interface{}, error) {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcs.NewUpdateMonitorOptions(ctx.InstanceID, id)
+			err = decodeOptions(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcs.UpdateMonitor(options)
+			return result, err
+		},
+		Delete: func(ctx *Context, id string) error {
+			dnsSvcs, err := ctx.DnsSvcs()
+			if err != nil {
+				return err
+			}
+			_, err = dnsSvcs.DeleteMonitor(dnsSvcs.NewDeleteMonitorOptions(ctx.InstanceID, id))
+			return err
+		},
+	}
+}
+
+// pathProperties are the properties of the options that identify the object, taken from the flags and arguments
+// rather than the data.
+var pathProperties = []string{"instance_id", "dnszone_id", "record_id", "permitted_network_id", "lb_id", "pool_id", "monitor_id"}
+
+// decodeOptions sets the options of an operation from the data of the -data flag.
+func decodeOptions(data map[string]interface{}, options interface{}) error {
+	for _, name := range pathProperties {
+		if _, found := data[name]; found {
+			return errors.New(name + " cannot be set in the data")
+		}
+	}
+	return Decode(data, options)
+}
+
+// decodeRdata moves the rdata property of the data of a resource record to rdata, which the record options hold as
+// an interface.
+func decodeRdata(data map[string]interface{}, rdata interface{}) (found bool, err error) {
+	value, found := data["rdata"]
+	if !found {
+		return
+	}
+	err = Decode(value, rdata)
+	delete(data, "rdata")
+	return
+}
@@ -0,0 +1,251 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// ExitDrift is the exit code of zone diff and zone drift when the DNS zones differ from the zone files.
+const ExitDrift = 3
+
+// ZoneCommand returns the zone command, which exports a DNS zone to a zone file, imports a zone file into a DNS zone
+// and compares them, one DNS zone or all of an instance. Zone files are in BIND, JSON or YAML form, chosen with
+// -format or by the file extension.
+func ZoneCommand() *Command {
+	var file, format, dir, junit string
+	var prune, dryRun, ignoreUnmanaged bool
+	fileFlags := func(flags *flag.FlagSet) {
+		flags.StringVar(&file, "file", "-", "zone file, - for stdin or stdout")
+		flags.StringVar(&format, "format", "", "zone file format: bind, json or yaml; by default from the file extension, or bind")
+	}
+	return &Command{
+		Name:        "zone",
+		Description: "zone files of DNS zones",
+		Verbs: map[string]*Verb{
+			"export": {
+				Zoned: true,
+				Flags: fileFlags,
+				Run: func(ctx *Context, args []string) (int, error) {
+					if len(args) != 0 {
+						return ExitUsage, fmt.Errorf("zone export takes no arguments")
+					}
+					dnsSvcs, err := ctx.DnsSvcs()
+					if err != nil {
+						return ExitError, err
+					}
+					zoneFile, err := dnsSvcs.ExportZoneFile(ctx.InstanceID, ctx.DnszoneID)
+					if err != nil {
+						return ExitError, err
+					}
+					data, err := zoneFile.Format(zoneFileFormat(format, file))
+					if err != nil {
+						return ExitError, err
+					}
+					if file == "-" {
+						_, err = ctx.Stdout.Write(data)
+					} else {
+						err = ioutil.WriteFile(file, data, 0644)
+					}
+					return ExitOK, err
+				},
+			},
+			"import": {
+				Zoned: true,
+				Flags: func(flags *flag.FlagSet) {
+					fileFlags(flags)
+					flags.BoolVar(&prune, "prune", false, "delete the resource records the zone file does not list")
+					flags.BoolVar(&dryRun, "dry-run", false, "show the changes without making them")
+				},
+				Run: func(ctx *Context, args []string) (int, error) {
+					if len(args) != 0 {
+						return ExitUsage, fmt.Errorf("zone import takes no arguments")
+					}
+					dnsSvcs, zoneFile, err := readZoneFile(ctx, file, format)
+					if err != nil {
+						return ExitError, err
+					}
+					options := dnsSvcs.NewImportZoneFileOptions(ctx.InstanceID, ctx.DnszoneID, zoneFile).SetPrune(prune).SetDryRun(dryRun)
+					result, err := dnsSvcs.ImportZoneFile(options)
+					if err != nil {
+						return ExitError, err
+					}
+					if ctx.Output != OutputTable {
+						return ExitOK, ctx.Print(result, nil)
+					}
+					if !prune {
+						result.Diff.Extra = nil
+					}
+					fmt.Fprint(ctx.Stdout, result.Diff.String())
+					if dryRun {
+						return ExitOK, nil
+					}
+					_, err = fmt.Fprintf(ctx.Stdout, "Created %d, updated %d, deleted %d resource records\n", len(result.Created), len(result.Updated), len(result.Deleted))
+					return ExitOK, err
+				},
+			},
+			"diff": {
+				Zoned: true,
+				Flags: fileFlags,
+				Run: func(ctx *Context, args []string) (int, error) {
+					if len(args) != 0 {
+						return ExitUsage, fmt.Errorf("zone diff takes no arguments")
+					}
+					dnsSvcs, desired, err := readZoneFile(ctx, file, format)
+					if err != nil {
+						return ExitError, err
+					}
+					live, err := dnsSvcs.ExportZoneFile(ctx.InstanceID, ctx.DnszoneID)
+					if err != nil {
+						return ExitError, err
+					}
+					diff := dnssvcsv1.DiffZoneFiles(desired, live)
+					if ctx.Output == OutputTable {
+						_, err = fmt.Fprint(ctx.Stdout, diff.String())
+					} else {
+						err = ctx.Print(diff, nil)
+					}
+					if err == nil && diff.HasDrift() {
+						return ExitDrift, nil
+					}
+					return ExitOK, err
+				},
+			},
+			"drift": {
+				Flags: func(flags *flag.FlagSet) {
+					flags.StringVar(&dir, "dir", ".", "directory of zone files, named after their DNS zone")
+					flags.StringVar(&junit, "junit", "", "also write the report as JUnit XML to this file")
+					flags.BoolVar(&ignoreUnmanaged, "ignore-unmanaged", false, "leave out the DNS zones without a zone file")
+				},
+				Run: func(ctx *Context, args []string) (int, error) {
+					if len(args) != 0 {
+						return ExitUsage, fmt.Errorf("zone drift takes no arguments")
+					}
+					zoneFiles, err := dnssvcsv1.LoadZoneFiles(dir)
+					if err != nil {
+						return ExitError, err
+					}
+					dnsSvcs, err := ctx.DnsSvcs()
+					if err != nil {
+						return ExitError, err
+					}
+					report, err := dnsSvcs.DriftReport(dnsSvcs.NewDriftReportOptions(ctx.InstanceID, zoneFiles).SetIgnoreUnmanaged(ignoreUnmanaged))
+					if err != nil {
+						return ExitError, err
+					}
+					if junit != "" {
+						var out *os.File
+						out, err = os.Create(junit)
+						if err != nil {
+							return ExitError, err
+						}
+						err = report.WriteJUnit(out)
+						if closeErr := out.Close(); err == nil {
+							err = closeErr
+						}
+						if err != nil {
+							return ExitError, err
+						}
+					}
+					if ctx.Output == OutputTable {
+						err = ctx.Print(driftRows(report), driftColumns)
+					} else {
+						err = ctx.Print(report, nil)
+					}
+					if err == nil && report.HasDrift() {
+						return ExitDrift, nil
+					}
+					return ExitOK, err
+				},
+			},
+		},
+	}
+}
+
+// driftColumns are the table columns of zone drift.
+var driftColumns = []Column{
+	{Header: "NAME", Path: "name"},
+	{Header: "STATUS", Path: "status"},
+	{Header: "IN SYNC", Path: "in_sync"},
+	{Header: "MISSING", Path: "missing"},
+	{Header: "EXTRA", Path: "extra"},
+	{Header: "CHANGED", Path: "changed"},
+	{Header: "ERROR", Path: "error"},
+}
+
+// driftRows summarizes the DNS zones of a drift report as rows of driftColumns, with the number of missing, extra
+// and changed resource records.
+func driftRows(report *dnssvcsv1.DriftReport) []map[string]interface{} {
+	rows := make([]map[string]interface{}, len(report.Zones))
+	for i, zone := range report.Zones {
+		rows[i] = map[string]interface{}{
+			"name":    zone.Name,
+			"status":  zone.Status,
+			"in_sync": zone.InSync,
+			"missing": len(zone.Missing),
+			"extra":   len(zone.Extra),
+			"changed": len(zone.Changed),
+			"error":   zone.Error,
+		}
+	}
+	return rows
+}
+
+// readZoneFile reads the zone file of the -file flag, with the name of the DNS zone as the default origin.
+func readZoneFile(ctx *Context, file string, format string) (dnsSvcs *dnssvcsv1.DnsSvcsV1, zoneFile *dnssvcsv1.ZoneFile, err error) {
+	var data []byte
+	if file == "-" {
+		data, err = ioutil.ReadAll(ctx.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return
+	}
+	dnsSvcs, err = ctx.DnsSvcs()
+	if err != nil {
+		return
+	}
+	dnszone, _, err := dnsSvcs.GetDnszone(dnsSvcs.NewGetDnszoneOptions(ctx.InstanceID, ctx.DnszoneID))
+	if err != nil {
+		return
+	}
+	zoneFile, err = dnssvcsv1.ParseZoneFile(data, zoneFileFormat(format, file), core.StringNilMapper(dnszone.Name))
+	return
+}
+
+// zoneFileFormat returns the format of a zone file: the one given, or the one of its extension.
+func zoneFileFormat(format string, file string) string {
+	if format != "" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return dnssvcsv1.ZoneFileFormat_Json
+	case ".yaml", ".yml":
+		return dnssvcsv1.ZoneFileFormat_Yaml
+	}
+	return dnssvcsv1.ZoneFileFormat_Bind
+}
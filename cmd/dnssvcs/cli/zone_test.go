@@ -0,0 +1,146 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/dns-svcs-go-sdk/cmd/dnssvcs/cli"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`dnssvcs zone`, func() {
+	var standIn *fakednssvcs.Server
+	var stdin, stdout, stderr *bytes.Buffer
+	var dir string
+	const recordsPath = "/instances/instance/dnszones/zone/resource_records"
+	BeforeEach(func() {
+		standIn = fakednssvcs.NewServer()
+		standIn.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		standIn.Add(recordsPath, map[string]interface{}{"id": "ns", "type": "NS", "name": "example.com", "ttl": 3600, "rdata": map[string]interface{}{"nsdname": "ns1.example.com"}})
+		standIn.Add(recordsPath, map[string]interface{}{"id": "www", "type": "A", "name": "www.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		standIn.Add(recordsPath, map[string]interface{}{"id": "mail", "type": "MX", "name": "example.com", "ttl": 900, "rdata": map[string]interface{}{"preference": 10, "exchange": "mail.example.net"}})
+		os.Setenv("DNS_SVCS_AUTH_TYPE", "noauth")
+		stdin, stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+		var err error
+		dir, err = ioutil.TempDir("", "zone")
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		os.Unsetenv("DNS_SVCS_AUTH_TYPE")
+		os.RemoveAll(dir)
+		standIn.Close()
+	})
+	run := func(args ...string) int {
+		app := cli.NewApp(stdin, stdout, stderr)
+		app.Getenv = func(name string) string {
+			return map[string]string{"INSTANCE_ID": "instance", "ZONE_ID": "zone"}[name]
+		}
+		return app.Run(append([]string{"-url", standIn.URL}, args...))
+	}
+	It(`Invoke zone export`, func() {
+		Expect(run("zone", "export")).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(Equal("$ORIGIN example.com.\n@\t900\tIN\tMX\t10 mail.example.net.\nwww\t900\tIN\tA\t10.0.0.1\n"))
+
+		file := filepath.Join(dir, "example.com.json")
+		Expect(run("zone", "export", "-file", file)).To(Equal(cli.ExitOK))
+		data, err := ioutil.ReadFile(file)
+		Expect(err).To(BeNil())
+		var zoneFile map[string]interface{}
+		Expect(json.Unmarshal(data, &zoneFile)).To(Succeed())
+		Expect(zoneFile["origin"]).To(Equal("example.com"))
+		Expect(zoneFile["records"]).To(HaveLen(2))
+	})
+	It(`Invoke zone diff`, func() {
+		file := filepath.Join(dir, "example.com.zone")
+		Expect(run("zone", "export", "-file", file)).To(Equal(cli.ExitOK))
+		Expect(run("zone", "diff", "-file", file)).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(BeEmpty())
+
+		Expect(ioutil.WriteFile(file, []byte("www 900 A 10.0.0.2\napi CNAME www\n"), 0600)).To(Succeed())
+		Expect(run("zone", "diff", "-file", file)).To(Equal(cli.ExitDrift))
+		Expect(stdout.String()).To(Equal(`+ api.example.com. IN CNAME www.example.com.
+- example.com. 900 IN MX 10 mail.example.net.
+~ www.example.com. 900 IN A 10.0.0.2 (was www.example.com. 900 IN A 10.0.0.1)
+`))
+
+		stdout.Reset()
+		stdin.WriteString("records: []\n")
+		Expect(run("-output", "json", "zone", "diff", "-format", "yaml")).To(Equal(cli.ExitDrift))
+		var diff map[string][]interface{}
+		Expect(json.Unmarshal(stdout.Bytes(), &diff)).To(Succeed())
+		Expect(diff["extra"]).To(HaveLen(2))
+		Expect(diff["missing"]).To(BeEmpty())
+	})
+	It(`Invoke zone drift`, func() {
+		Expect(run("zone", "export", "-file", filepath.Join(dir, "example.com.zone"))).To(Equal(cli.ExitOK))
+		Expect(run("zone", "drift", "-dir", dir)).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(ContainSubstring("example.com  in_sync  2"))
+
+		stdout.Reset()
+		junit := filepath.Join(dir, "drift.xml")
+		Expect(ioutil.WriteFile(filepath.Join(dir, "example.org.json"), []byte(`{"records": []}`), 0600)).To(Succeed())
+		Expect(run("-output", "json", "zone", "drift", "-dir", dir, "-junit", junit)).To(Equal(cli.ExitDrift))
+		var report map[string]interface{}
+		Expect(json.Unmarshal(stdout.Bytes(), &report)).To(Succeed())
+		Expect(report["zones"]).To(HaveLen(2))
+		data, err := ioutil.ReadFile(junit)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(`<testsuite name="example.org"`))
+
+		Expect(run("zone", "drift", "-dir", filepath.Join(dir, "none"))).To(Equal(cli.ExitError))
+		Expect(run("zone", "drift", "extra")).To(Equal(cli.ExitUsage))
+	})
+	It(`Invoke zone import`, func() {
+		file := filepath.Join(dir, "example.com.yaml")
+		Expect(ioutil.WriteFile(file, []byte(`records:
+- name: www
+  type: A
+  ttl: 900
+  rdata:
+    ip: 10.0.0.2
+- name: api.example.com
+  type: A
+  rdata:
+    ip: 10.0.0.3
+`), 0600)).To(Succeed())
+		Expect(run("zone", "import", "-file", file, "-dry-run")).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(Equal("+ api.example.com. IN A 10.0.0.3\n~ www.example.com. 900 IN A 10.0.0.2 (was www.example.com. 900 IN A 10.0.0.1)\n"))
+		Expect(standIn.List(recordsPath)).To(HaveLen(3))
+
+		stdout.Reset()
+		Expect(run("zone", "import", "-file", file, "-prune")).To(Equal(cli.ExitOK))
+		Expect(stdout.String()).To(ContainSubstring("Created 1, updated 1, deleted 1 resource records"))
+		Expect(standIn.Bodies["POST "+recordsPath]).To(Equal(map[string]interface{}{"name": "api.example.com", "type": "A", "rdata": map[string]interface{}{"ip": "10.0.0.3"}}))
+		Expect(run("zone", "diff", "-file", file)).To(Equal(cli.ExitOK))
+	})
+	It(`Invoke zone with errors`, func() {
+		Expect(run("zone", "rename")).To(Equal(cli.ExitUsage))
+		Expect(run("zone", "export", "extra")).To(Equal(cli.ExitUsage))
+		Expect(run("zone", "diff", "-file", filepath.Join(dir, "missing.zone"))).To(Equal(cli.ExitError))
+		stdin.WriteString("www A not-an-address\n")
+		Expect(run("zone", "import")).To(Equal(cli.ExitError))
+		Expect(stderr.String()).To(ContainSubstring(`line 1: invalid A address "not-an-address"`))
+		Expect(run("zone", "export", "-zone-id", "missing")).To(Equal(cli.ExitError))
+	})
+})
@@ -0,0 +1,80 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/cmd/dnssvcs/cli"
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsinstancesv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`dnssvcs instances`, func() {
+	var testServer *httptest.Server
+	var resourceIDs []string
+	var stdout, stderr *bytes.Buffer
+	var env map[string]string
+	BeforeEach(func() {
+		resourceIDs = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/resource_instances"))
+			resourceIDs = append(resourceIDs, req.URL.Query().Get("resource_id"))
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			Expect(json.NewEncoder(res).Encode(map[string]interface{}{
+				"rows_count": 1,
+				"resources":  []map[string]interface{}{{"guid": "g1", "name": "dns-a", "state": "active"}},
+			})).To(Succeed())
+		}))
+		os.Setenv("DNS_SVCS_INSTANCES_AUTH_TYPE", "noauth")
+		stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+		env = map[string]string{"DNS_SVCS_INSTANCES_URL": testServer.URL}
+	})
+	AfterEach(func() {
+		os.Unsetenv("DNS_SVCS_INSTANCES_AUTH_TYPE")
+		testServer.Close()
+	})
+	run := func(args ...string) int {
+		app := cli.NewApp(&bytes.Buffer{}, stdout, stderr)
+		app.Register(instancesResource())
+		app.Getenv = func(name string) string {
+			return env[name]
+		}
+		return app.Run(append([]string{"-url", "https://api.dns-svcs.cloud.ibm.com/v1"}, args...))
+	}
+	It(`Invoke instances list against the instances URL`, func() {
+		Expect(run("instances", "list")).To(Equal(cli.ExitOK))
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(strings.Fields(lines[1])).To(Equal([]string{"g1", "dns-a", "active"}))
+		Expect(resourceIDs).To(Equal([]string{dnssvcsinstancesv2.DnsSvcsResourceID}))
+
+		env["RESOURCE_ID"] = "other"
+		Expect(run("instances", "list")).To(Equal(cli.ExitOK))
+		Expect(resourceIDs).To(Equal([]string{dnssvcsinstancesv2.DnsSvcsResourceID, "other"}))
+		Expect(stderr.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/cmd/dnssvcs/cli"
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsinstancesv2"
+)
+
+// instancesResource returns the resource of DNS Services instances, managed through the resource controller.
+func instancesResource() *cli.Resource {
+	return &cli.Resource{
+		Name:        "instances",
+		Description: "DNS Services instances",
+		Unscoped:    true,
+		Columns:     []cli.Column{{Header: "GUID", Path: "guid"}, {Header: "NAME", Path: "name"}, {Header: "STATE", Path: "state"}, {Header: "REGION", Path: "region_id"}, {Header: "RESOURCE_GROUP_ID", Path: "resource_group_id"}},
+		List: func(ctx *cli.Context) (interface{}, error) {
+			dnsSvcsInstances, err := newDnsSvcsInstances(ctx)
+			if err != nil {
+				return nil, err
+			}
+			resourceID := ctx.Getenv("RESOURCE_ID")
+			if resourceID == "" {
+				resourceID = dnssvcsinstancesv2.DnsSvcsResourceID
+			}
+			return dnsSvcsInstances.ListAllResourceInstances(dnsSvcsInstances.NewListResourceInstancesOptions(resourceID, dnssvcsinstancesv2.ListResourceInstancesOptions_Type_ServiceInstance))
+		},
+		Get: func(ctx *cli.Context, id string) (interface{}, error) {
+			dnsSvcsInstances, err := newDnsSvcsInstances(ctx)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcsInstances.GetResourceInstance(dnsSvcsInstances.NewGetResourceInstanceOptions(id))
+			return result, err
+		},
+		Create: func(ctx *cli.Context, data map[string]interface{}) (interface{}, error) {
+			dnsSvcsInstances, err := newDnsSvcsInstances(ctx)
+			if err != nil {
+				return nil, err
+			}
+			options := &dnssvcsinstancesv2.CreateResourceInstanceOptions{}
+			err = cli.Decode(data, options)
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := dnsSvcsInstances.CreateResourceInstance(options)
+			return result, err
+		},
+		Update: func(ctx *cli.Context, id string, data map[string]interface{}) (interface{}, error) {
+			dnsSvcsInstances, err := newDnsSvcsInstances(ctx)
+			if err != nil {
+				return nil, err
+			}
+			options := dnsSvcsInstances.NewUpdateResourceInstanceOptions(id)
+			err = cli.Decode(data, options)
+			if err != nil {
+				return nil, err
+			}
+			options.SetID(id)
+			result, _, err := dnsSvcsInstances.UpdateResourceInstance(options)
+			return result, err
+		},
+		Delete: func(ctx *cli.Context, id string) error {
+			dnsSvcsInstances, err := newDnsSvcsInstances(ctx)
+			if err != nil {
+				return err
+			}
+			_, err = dnsSvcsInstances.DeleteResourceInstance(dnsSvcsInstances.NewDeleteResourceInstanceOptions(id))
+			return err
+		},
+	}
+}
+
+// newDnsSvcsInstances returns the resource controller client, configured from the environment. The -url of the
+// command line is the DNS Services URL, so the resource controller URL is read from DNS_SVCS_INSTANCES_URL instead.
+func newDnsSvcsInstances(ctx *cli.Context) (*dnssvcsinstancesv2.DnsSvcsInstancesV2, error) {
+	return dnssvcsinstancesv2.NewDnsSvcsInstancesV2UsingExternalConfig(&dnssvcsinstancesv2.DnsSvcsInstancesV2Options{URL: ctx.Getenv("DNS_SVCS_INSTANCES_URL")})
+}
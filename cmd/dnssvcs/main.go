@@ -0,0 +1,36 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command dnssvcs manages the zones, resource records, permitted networks, global load balancers and instances of
+// IBM Cloud DNS Services.
+//
+// The service URL and credentials are read from the environment or credentials file as for
+// NewDnsSvcsV1UsingExternalConfig, under the dns_svcs service name for DNS Services and dns_svcs_instances for
+// instances. The -url flag only overrides the DNS Services URL; set DNS_SVCS_INSTANCES_URL for instances. Run
+// "dnssvcs help" for usage.
+package main
+
+import (
+	"os"
+
+	"github.com/IBM/dns-svcs-go-sdk/cmd/dnssvcs/cli"
+)
+
+func main() {
+	app := cli.NewApp(os.Stdin, os.Stdout, os.Stderr)
+	app.Register(instancesResource())
+	os.Exit(app.Run(os.Args[1:]))
+}
@@ -0,0 +1,199 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constants associated with the parts of a Crn.
+const (
+	CrnVersion            = "v1"
+	CrnCnamePublic        = "bluemix"
+	CrnCtypePublic        = "public"
+	CrnServiceNameDnsSvcs = "dns-svcs"
+	CrnServiceNameVpc     = "is"
+	CrnResourceTypeSubnet = "subnet"
+	CrnResourceTypeVpc    = "vpc"
+)
+
+// crnSegments is the number of colon-separated segments of a CRN, including the "crn" prefix.
+const crnSegments = 10
+
+// Crn : A Cloud Resource Name, which uniquely identifies a cloud resource, in the form
+// "crn:version:cname:ctype:service-name:location:scope:service-instance:resource-type:resource".
+type Crn struct {
+	// The version of the CRN format, "v1".
+	Version string
+
+	// The cloud instance the resource belongs to, "bluemix" for the public cloud.
+	Cname string
+
+	// The type of the cloud instance, "public" for the public cloud.
+	Ctype string
+
+	// The service the resource belongs to, such as "is" for VPC resources or "dns-svcs".
+	ServiceName string
+
+	// The region or zone of the resource, such as "us-south" or "us-south-1", or "global".
+	Region string
+
+	// The account, organization or space owning the resource, such as "a/<account ID>"; empty for global resources.
+	Scope string
+
+	// The service instance the resource belongs to; empty for resources that are not part of one.
+	ServiceInstance string
+
+	// The type of the resource within the service, such as "vpc" or "subnet"; empty for service instances.
+	ResourceType string
+
+	// The ID of the resource; empty for service instances.
+	ResourceID string
+}
+
+// NewVpcCrn : Instantiate Crn for a VPC of an account in a region of the public cloud.
+func NewVpcCrn(region string, accountID string, vpcID string) *Crn {
+	return &Crn{
+		Version:      CrnVersion,
+		Cname:        CrnCnamePublic,
+		Ctype:        CrnCtypePublic,
+		ServiceName:  CrnServiceNameVpc,
+		Region:       region,
+		Scope:        "a/" + accountID,
+		ResourceType: CrnResourceTypeVpc,
+		ResourceID:   vpcID,
+	}
+}
+
+// NewSubnetCrn : Instantiate Crn for a VPC subnet of an account in a zone of the public cloud, such as "us-south-1".
+func NewSubnetCrn(zone string, accountID string, subnetID string) *Crn {
+	return &Crn{
+		Version:      CrnVersion,
+		Cname:        CrnCnamePublic,
+		Ctype:        CrnCtypePublic,
+		ServiceName:  CrnServiceNameVpc,
+		Region:       zone,
+		Scope:        "a/" + accountID,
+		ResourceType: CrnResourceTypeSubnet,
+		ResourceID:   subnetID,
+	}
+}
+
+// ParseCrn parses and validates a CRN such as "crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-5ab1a3b0".
+func ParseCrn(crn string) (*Crn, error) {
+	segments := strings.Split(crn, ":")
+	if len(segments) != crnSegments || segments[0] != "crn" {
+		return nil, fmt.Errorf("%q is not a CRN: expected %d colon-separated segments starting with \"crn\"", crn, crnSegments)
+	}
+	parsed := &Crn{
+		Version:         segments[1],
+		Cname:           segments[2],
+		Ctype:           segments[3],
+		ServiceName:     segments[4],
+		Region:          segments[5],
+		Scope:           segments[6],
+		ServiceInstance: segments[7],
+		ResourceType:    segments[8],
+		ResourceID:      segments[9],
+	}
+	if err := parsed.Validate(); err != nil {
+		return nil, fmt.Errorf("%q is not a valid CRN: %s", crn, err.Error())
+	}
+	return parsed, nil
+}
+
+// ParseVpcCrn parses a CRN and checks that it identifies a VPC.
+func ParseVpcCrn(crn string) (*Crn, error) {
+	return parseVpcResourceCrn(crn, CrnResourceTypeVpc, "VPC")
+}
+
+// ParseSubnetCrn parses a CRN and checks that it identifies a VPC subnet.
+func ParseSubnetCrn(crn string) (*Crn, error) {
+	return parseVpcResourceCrn(crn, CrnResourceTypeSubnet, "VPC subnet")
+}
+
+func parseVpcResourceCrn(crn string, resourceType string, description string) (*Crn, error) {
+	parsed, err := ParseCrn(crn)
+	if err != nil {
+		return nil, err
+	}
+	if !parsed.IsResourceType(CrnServiceNameVpc, resourceType) || parsed.ResourceID == "" {
+		return nil, fmt.Errorf("%q is not the CRN of a %s: expected service name %q and resource type %q", crn, description, CrnServiceNameVpc, resourceType)
+	}
+	return parsed, nil
+}
+
+// Validate checks that the version, cname, ctype, service name and region of the CRN are set, that the scope, if
+// set, is of the form "<a|o|s|p>/<ID>", that a resource ID comes with a resource type, and that no part contains a
+// colon.
+func (crn *Crn) Validate() error {
+	parts := []struct {
+		name  string
+		value string
+	}{
+		{"version", crn.Version},
+		{"cname", crn.Cname},
+		{"ctype", crn.Ctype},
+		{"service name", crn.ServiceName},
+		{"region", crn.Region},
+		{"scope", crn.Scope},
+		{"service instance", crn.ServiceInstance},
+		{"resource type", crn.ResourceType},
+		{"resource ID", crn.ResourceID},
+	}
+	for i, part := range parts {
+		if strings.Contains(part.value, ":") {
+			return fmt.Errorf("the %s %q contains a colon", part.name, part.value)
+		}
+		if i < 5 && part.value == "" {
+			return fmt.Errorf("the %s is missing", part.name)
+		}
+	}
+	if crn.Version != CrnVersion {
+		return fmt.Errorf("the version %q is not supported, expected %q", crn.Version, CrnVersion)
+	}
+	if crn.Scope != "" {
+		scope := strings.SplitN(crn.Scope, "/", 2)
+		if len(scope) != 2 || scope[1] == "" || (scope[0] != "a" && scope[0] != "o" && scope[0] != "s" && scope[0] != "p") {
+			return fmt.Errorf("the scope %q is not of the form \"a/<account ID>\"", crn.Scope)
+		}
+	}
+	if crn.ResourceID != "" && crn.ResourceType == "" {
+		return fmt.Errorf("the resource ID %q has no resource type", crn.ResourceID)
+	}
+	return nil
+}
+
+// AccountID returns the account owning the resource, or "" if the scope of the CRN is not an account.
+func (crn *Crn) AccountID() string {
+	if strings.HasPrefix(crn.Scope, "a/") {
+		return crn.Scope[2:]
+	}
+	return ""
+}
+
+// IsResourceType reports whether the CRN identifies a resource of a type of a service.
+func (crn *Crn) IsResourceType(serviceName string, resourceType string) bool {
+	return crn.ServiceName == serviceName && crn.ResourceType == resourceType
+}
+
+// String returns the CRN in its colon-separated form.
+func (crn *Crn) String() string {
+	return strings.Join([]string{"crn", crn.Version, crn.Cname, crn.Ctype, crn.ServiceName, crn.Region, crn.Scope,
+		crn.ServiceInstance, crn.ResourceType, crn.ResourceID}, ":")
+}
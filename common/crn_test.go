@@ -0,0 +1,72 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseCrn(t *testing.T) {
+	crn, err := ParseCrn("crn:v1:bluemix:public:dns-svcs:global:a/bcf1865e99742d38d2d5fc3fb80a5496:434f6c3e-6014-4124-a61d-2e910bca19b1::")
+	assert.Nil(t, err)
+	assert.Equal(t, CrnServiceNameDnsSvcs, crn.ServiceName)
+	assert.Equal(t, "global", crn.Region)
+	assert.Equal(t, "bcf1865e99742d38d2d5fc3fb80a5496", crn.AccountID())
+	assert.Equal(t, "434f6c3e-6014-4124-a61d-2e910bca19b1", crn.ServiceInstance)
+	assert.Equal(t, "", crn.ResourceType)
+	assert.Equal(t, "crn:v1:bluemix:public:dns-svcs:global:a/bcf1865e99742d38d2d5fc3fb80a5496:434f6c3e-6014-4124-a61d-2e910bca19b1::", crn.String())
+
+	for _, invalid := range []string{
+		"vpc crn",
+		"crn:v1:bluemix:public:is:us-south:a/1234::vpc",
+		"crn:v2:bluemix:public:is:us-south:a/1234::vpc:r006-1234",
+		"crn:v1:bluemix:public::us-south:a/1234::vpc:r006-1234",
+		"crn:v1:bluemix:public:is::a/1234::vpc:r006-1234",
+		"crn:v1:bluemix:public:is:us-south:1234::vpc:r006-1234",
+		"crn:v1:bluemix:public:is:us-south:x/1234::vpc:r006-1234",
+		"crn:v1:bluemix:public:is:us-south:a/1234:::r006-1234",
+	} {
+		_, err = ParseCrn(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestParseVpcCrn(t *testing.T) {
+	crn, err := ParseVpcCrn("crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-5ab1a3b0")
+	assert.Nil(t, err)
+	assert.Equal(t, NewVpcCrn("us-south", "1234", "r006-5ab1a3b0"), crn)
+
+	_, err = ParseVpcCrn("crn:v1:bluemix:public:is:us-south-1:a/1234::subnet:0716-a4c0c123-594c-4ef4-ace3-a08858540b5e")
+	assert.Contains(t, err.Error(), "is not the CRN of a VPC")
+	_, err = ParseVpcCrn("crn:v1:bluemix:public:is:us-south:a/1234::vpc:")
+	assert.NotNil(t, err)
+
+	crn, err = ParseSubnetCrn("crn:v1:bluemix:public:is:us-south-1:a/1234::subnet:0716-a4c0c123-594c-4ef4-ace3-a08858540b5e")
+	assert.Nil(t, err)
+	assert.Equal(t, NewSubnetCrn("us-south-1", "1234", "0716-a4c0c123-594c-4ef4-ace3-a08858540b5e").String(), crn.String())
+	assert.True(t, crn.IsResourceType(CrnServiceNameVpc, CrnResourceTypeSubnet))
+}
+
+func TestCrnValidate(t *testing.T) {
+	crn := NewVpcCrn("us-south", "1234", "r006-5ab1a3b0")
+	assert.Nil(t, crn.Validate())
+	crn.ResourceID = "r006:5ab1a3b0"
+	assert.Contains(t, crn.Validate().Error(), "contains a colon")
+	crn = NewVpcCrn("", "1234", "r006-5ab1a3b0")
+	assert.Equal(t, "the region is missing", crn.Validate().Error())
+}
@@ -0,0 +1,46 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the formats of the timestamps returned by the services, tried in order. Timestamps without a
+// time zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a timestamp in any of the formats the services return, such as "2019-01-01T12:00:00Z",
+// "2019-01-01T12:00:00" or "2019-01-01 12:00:00.123 +0000 UTC".
+func ParseTimestamp(timestamp string) (time.Time, error) {
+	value := strings.TrimSpace(timestamp)
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a timestamp", timestamp)
+}
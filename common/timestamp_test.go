@@ -0,0 +1,40 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	for _, timestamp := range []string{
+		"2019-01-01T12:00:00Z",
+		"2019-01-01T12:00:00",
+		"2019-01-01T14:00:00+02:00",
+		"2019-01-01 12:00:00 +0000 UTC",
+		"2019-01-01 12:00:00.000 +0000 UTC",
+		" 2019-01-01 12:00:00 ",
+	} {
+		parsed, err := ParseTimestamp(timestamp)
+		assert.Nil(t, err, timestamp)
+		assert.True(t, parsed.Equal(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)), timestamp)
+	}
+	_, err := ParseTimestamp("yesterday")
+	assert.Equal(t, `"yesterday" is not a timestamp`, err.Error())
+}
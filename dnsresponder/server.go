@@ -0,0 +1,288 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnsresponder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// maxUDPSize is the size of UDP answers to queries without EDNS; larger answers are truncated.
+	maxUDPSize = 512
+
+	// ednsUDPSize is the UDP payload size advertised to, and accepted from, EDNS clients.
+	ednsUDPSize = 4096
+
+	// maxTCPSize is the size limit of DNS messages over TCP.
+	maxTCPSize = 65535
+
+	// tcpIdleTimeout is how long a TCP connection is kept open without a query.
+	tcpIdleTimeout = 10 * time.Second
+)
+
+// Server : An authoritative DNS server answering queries for the DNS zones of a snapshot over UDP and TCP.
+//
+// It answers A, AAAA, CNAME, MX, NS, PTR, SOA, SRV and TXT queries, and ANY queries, with the TTLs of the resource
+// records. CNAME records are followed within the DNS zone. A name that does not exist is answered with NXDOMAIN and
+// a name without resource records of the type asked for with NODATA, both with the SOA record of the DNS zone. Load
+// balancers are answered with the addresses of the origins of their first healthy pool. Queries for names outside
+// the DNS zones of the snapshot are refused.
+type Server struct {
+	mutex       sync.RWMutex
+	zones       []*zone
+	packetConn  net.PacketConn
+	listener    net.Listener
+	connections map[net.Conn]bool
+	waitGroup   sync.WaitGroup
+}
+
+// NewServer : Instantiate Server for a snapshot. Start serves it on a network address.
+func NewServer(snapshot *Snapshot) (server *Server, err error) {
+	server = &Server{connections: make(map[net.Conn]bool)}
+	err = server.Load(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Load replaces the snapshot the server answers from, while it serves.
+func (server *Server) Load(snapshot *Snapshot) error {
+	zones, err := newZones(snapshot)
+	if err != nil {
+		return err
+	}
+	server.mutex.Lock()
+	server.zones = zones
+	server.mutex.Unlock()
+	return nil
+}
+
+// Start listens on a UDP and a TCP address with the same host and port, such as "127.0.0.1:5353", and serves
+// queries in the background until Close. With port 0 a free port is chosen; Addr returns it.
+func (server *Server) Start(address string) (err error) {
+	for attempt := 0; ; attempt++ {
+		server.packetConn, err = net.ListenPacket("udp", address)
+		if err != nil {
+			return
+		}
+		server.listener, err = net.Listen("tcp", server.packetConn.LocalAddr().String())
+		if err == nil {
+			break
+		}
+		server.packetConn.Close()
+		_, port, _ := net.SplitHostPort(address)
+		if port != "0" || attempt == 10 {
+			return
+		}
+	}
+	server.waitGroup.Add(2)
+	go server.serveUDP()
+	go server.serveTCP()
+	return
+}
+
+// Addr returns the address the server listens on, for example to configure a resolver.
+func (server *Server) Addr() string {
+	if server.packetConn == nil {
+		return ""
+	}
+	return server.packetConn.LocalAddr().String()
+}
+
+// Close stops serving and waits until the queries being answered are done.
+func (server *Server) Close() error {
+	if server.packetConn == nil {
+		return nil
+	}
+	err := server.packetConn.Close()
+	if listenerErr := server.listener.Close(); err == nil {
+		err = listenerErr
+	}
+	server.mutex.Lock()
+	for connection := range server.connections {
+		connection.Close()
+	}
+	server.mutex.Unlock()
+	server.waitGroup.Wait()
+	return err
+}
+
+func (server *Server) serveUDP() {
+	defer server.waitGroup.Done()
+	buffer := make([]byte, ednsUDPSize)
+	for {
+		n, address, err := server.packetConn.ReadFrom(buffer)
+		if err != nil {
+			if netErr, isNetErr := err.(net.Error); isNetErr && netErr.Temporary() {
+				continue
+			}
+			return
+		}
+		response, err := server.answer(buffer[:n], true)
+		if err == nil {
+			server.packetConn.WriteTo(response, address)
+		}
+	}
+}
+
+func (server *Server) serveTCP() {
+	defer server.waitGroup.Done()
+	for {
+		connection, err := server.listener.Accept()
+		if err != nil {
+			if netErr, isNetErr := err.(net.Error); isNetErr && netErr.Temporary() {
+				continue
+			}
+			return
+		}
+		server.mutex.Lock()
+		server.connections[connection] = true
+		server.mutex.Unlock()
+		server.waitGroup.Add(1)
+		go server.serveConnection(connection)
+	}
+}
+
+// serveConnection answers the queries of a TCP connection, each preceded by its length, until the client closes it
+// or stays idle.
+func (server *Server) serveConnection(connection net.Conn) {
+	defer server.waitGroup.Done()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.connections, connection)
+		server.mutex.Unlock()
+		connection.Close()
+	}()
+	for {
+		connection.SetDeadline(time.Now().Add(tcpIdleTimeout))
+		var length uint16
+		if binary.Read(connection, binary.BigEndian, &length) != nil {
+			return
+		}
+		query := make([]byte, length)
+		if _, err := io.ReadFull(connection, query); err != nil {
+			return
+		}
+		response, err := server.answer(query, false)
+		if err != nil {
+			return
+		}
+		framed := make([]byte, 2, 2+len(response))
+		binary.BigEndian.PutUint16(framed, uint16(len(response)))
+		if _, err = connection.Write(append(framed, response...)); err != nil {
+			return
+		}
+	}
+}
+
+// Answer returns the response to a DNS query in wire format, as if it was received over TCP.
+func (server *Server) Answer(query []byte) ([]byte, error) {
+	return server.answer(query, false)
+}
+
+// answer returns the response to a DNS query. Over UDP, responses larger than the client accepts are truncated so
+// that it retries over TCP.
+func (server *Server) answer(query []byte, udp bool) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	response := dnsmessage.Message{Header: dnsmessage.Header{
+		ID:               header.ID,
+		Response:         true,
+		OpCode:           header.OpCode,
+		RecursionDesired: header.RecursionDesired,
+	}}
+	questions, err := parser.AllQuestions()
+	switch {
+	case err != nil || len(questions) != 1:
+		response.RCode = dnsmessage.RCodeFormatError
+	case header.OpCode != 0:
+		response.RCode = dnsmessage.RCodeNotImplemented
+	default:
+		response.Questions = questions
+		server.resolve(&response, questions[0])
+	}
+
+	size := maxUDPSize
+	if err == nil && parser.SkipAllAnswers() == nil && parser.SkipAllAuthorities() == nil {
+		for {
+			additional, additionalErr := parser.AdditionalHeader()
+			if additionalErr != nil {
+				break
+			}
+			if additional.Type == dnsmessage.TypeOPT {
+				if int(additional.Class) > size {
+					size = int(additional.Class)
+				}
+				var opt dnsmessage.Resource
+				opt.Header.SetEDNS0(ednsUDPSize, dnsmessage.RCodeSuccess, false)
+				opt.Body = &dnsmessage.OPTResource{}
+				response.Additionals = append(response.Additionals, opt)
+				break
+			}
+			if parser.SkipAdditional() != nil {
+				break
+			}
+		}
+	}
+	if size > ednsUDPSize {
+		size = ednsUDPSize
+	}
+	if !udp {
+		size = maxTCPSize
+	}
+
+	packed, err := response.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("cannot pack the response: %s", err.Error())
+	}
+	if len(packed) > size {
+		response.Truncated = true
+		response.Answers, response.Authorities = nil, nil
+		packed, err = response.Pack()
+	}
+	return packed, err
+}
+
+// resolve fills in the answers to a question from the DNS zone containing its name.
+func (server *Server) resolve(response *dnsmessage.Message, question dnsmessage.Question) {
+	if question.Class != dnsmessage.ClassINET && question.Class != dnsmessage.ClassANY {
+		response.RCode = dnsmessage.RCodeRefused
+		return
+	}
+	name := normalizeName(question.Name.String())
+	server.mutex.RLock()
+	defer server.mutex.RUnlock()
+	for _, z := range server.zones {
+		if z.contains(name) {
+			response.Authoritative = true
+			response.Answers, response.Authorities, response.RCode = z.lookup(name, question.Type)
+			return
+		}
+	}
+	response.RCode = dnsmessage.RCodeRefused
+}
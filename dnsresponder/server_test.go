@@ -0,0 +1,238 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnsresponder_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnsresponder"
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const testSnapshot = `{
+  "zones": [
+    {
+      "dnszone": {"id": "com", "name": "example.com"},
+      "resource_records": [
+        {"id": "soa", "type": "SOA", "name": "example.com", "ttl": 3600, "rdata": {"mname": "ns1.example.com", "rname": "admin.example.com", "serial": 42, "minimum": 120}},
+        {"id": "www1", "type": "A", "name": "www.example.com", "ttl": 300, "rdata": {"ip": "10.0.0.1"}},
+        {"id": "www2", "type": "A", "name": "www.example.com", "ttl": 300, "rdata": {"ip": "10.0.0.2"}},
+        {"id": "api", "type": "AAAA", "name": "api.example.com", "rdata": {"ip": "2001:db8::1"}},
+        {"id": "alias", "type": "CNAME", "name": "alias.example.com", "ttl": 60, "rdata": {"cname": "www.example.com"}},
+        {"id": "dangling", "type": "CNAME", "name": "dangling.example.com", "ttl": 60, "rdata": {"cname": "gone.example.com"}},
+        {"id": "ext", "type": "CNAME", "name": "ext.example.com", "ttl": 60, "rdata": {"cname": "www.example.net"}},
+        {"id": "mx", "type": "MX", "name": "example.com", "ttl": 900, "rdata": {"preference": 10, "exchange": "mail.example.com"}},
+        {"id": "srv", "type": "SRV", "name": "_sip._udp.example.com", "service": "_sip", "protocol": "udp", "ttl": 900, "rdata": {"priority": 1, "weight": 5, "port": 5060, "target": "sip.example.com"}},
+        {"id": "txt", "type": "TXT", "name": "example.com", "ttl": 900, "rdata": {"text": "v=spf1 -all"}},
+        {"id": "caa", "type": "CAA", "name": "example.com", "ttl": 900, "rdata": {"flags": 0, "tag": "issue", "value": "ca.example.net"}}
+      ],
+      "load_balancers": [
+        {"id": "glb", "name": "glb", "enabled": true, "ttl": 30, "fallback_pool": "hosts", "default_pools": ["down", "up"]},
+        {"id": "fb", "name": "fb.example.com", "enabled": true, "fallback_pool": "hosts", "default_pools": ["down"]},
+        {"id": "off", "name": "off", "enabled": false, "fallback_pool": "up", "default_pools": ["up"]}
+      ]
+    },
+    {
+      "dnszone": {"id": "rev", "name": "0.0.10.in-addr.arpa"},
+      "resource_records": [
+        {"id": "ptr", "type": "PTR", "name": "10.0.0.1", "ttl": 300, "rdata": {"ptrdname": "www.example.com"}}
+      ]
+    }
+  ],
+  "pools": [
+    {"id": "down", "enabled": true, "health": "CRITICAL", "origins": [{"name": "a", "address": "10.2.0.1", "enabled": true, "health": false}]},
+    {"id": "up", "enabled": true, "health": "DEGRADED", "origins": [
+      {"name": "a", "address": "10.1.0.1", "enabled": true, "health": true},
+      {"name": "b", "address": "10.1.0.2", "enabled": false, "health": true},
+      {"name": "c", "address": "2001:db8::2", "enabled": true, "health": true},
+      {"name": "d", "address": "10.1.0.4", "enabled": true, "health": false}
+    ]},
+    {"id": "hosts", "enabled": true, "origins": [{"name": "a", "address": "origin.example.net", "enabled": true, "health": false}]}
+  ]
+}`
+
+var _ = Describe(`Server`, func() {
+	var server *dnsresponder.Server
+	BeforeEach(func() {
+		snapshot, err := dnsresponder.ReadSnapshot(strings.NewReader(testSnapshot))
+		Expect(err).To(BeNil())
+		server, err = dnsresponder.NewServer(snapshot)
+		Expect(err).To(BeNil())
+		Expect(server.Start("127.0.0.1:0")).To(Succeed())
+	})
+	AfterEach(func() {
+		Expect(server.Close()).To(Succeed())
+	})
+	exchange := func(network string, name string, qtype dnsmessage.Type) (response dnsmessage.Message) {
+		query := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: 7, RecursionDesired: true},
+			Questions: []dnsmessage.Question{{Name: dnsmessage.MustNewName(name), Type: qtype, Class: dnsmessage.ClassINET}},
+		}
+		packed, err := query.Pack()
+		Expect(err).To(BeNil())
+		connection, err := net.Dial(network, server.Addr())
+		Expect(err).To(BeNil())
+		defer connection.Close()
+		connection.SetDeadline(time.Now().Add(5 * time.Second))
+		buffer := make([]byte, 65535)
+		var n int
+		if network == "udp" {
+			_, err = connection.Write(packed)
+			Expect(err).To(BeNil())
+			n, err = connection.Read(buffer)
+			Expect(err).To(BeNil())
+		} else {
+			framed := make([]byte, 2)
+			binary.BigEndian.PutUint16(framed, uint16(len(packed)))
+			_, err = connection.Write(append(framed, packed...))
+			Expect(err).To(BeNil())
+			var length uint16
+			Expect(binary.Read(connection, binary.BigEndian, &length)).To(Succeed())
+			n, err = io.ReadFull(connection, buffer[:length])
+			Expect(err).To(BeNil())
+		}
+		Expect(response.Unpack(buffer[:n])).To(Succeed())
+		Expect(response.ID).To(Equal(uint16(7)))
+		Expect(response.Response).To(BeTrue())
+		return
+	}
+	answers := func(response dnsmessage.Message) (texts []string) {
+		for _, answer := range response.Answers {
+			var text string
+			switch body := answer.Body.(type) {
+			case *dnsmessage.AResource:
+				text = net.IP(body.A[:]).String()
+			case *dnsmessage.AAAAResource:
+				text = net.IP(body.AAAA[:]).String()
+			case *dnsmessage.CNAMEResource:
+				text = body.CNAME.String()
+			case *dnsmessage.MXResource:
+				text = fmt.Sprintf("%d %s", body.Pref, body.MX.String())
+			case *dnsmessage.SRVResource:
+				text = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, body.Target.String())
+			case *dnsmessage.TXTResource:
+				text = strings.Join(body.TXT, "|")
+			case *dnsmessage.PTRResource:
+				text = body.PTR.String()
+			case *dnsmessage.SOAResource:
+				text = fmt.Sprintf("%s %d", body.NS.String(), body.Serial)
+			}
+			texts = append(texts, fmt.Sprintf("%s %d %s %s", answer.Header.Name.String(), answer.Header.TTL, answer.Header.Type.String()[4:], text))
+		}
+		return
+	}
+
+	It(`Answer queries over UDP and TCP`, func() {
+		for _, network := range []string{"udp", "tcp"} {
+			response := exchange(network, "WWW.example.com.", dnsmessage.TypeA)
+			Expect(response.RCode).To(Equal(dnsmessage.RCodeSuccess))
+			Expect(response.Authoritative).To(BeTrue())
+			Expect(response.RecursionDesired).To(BeTrue())
+			Expect(answers(response)).To(Equal([]string{"www.example.com. 300 A 10.0.0.1", "www.example.com. 300 A 10.0.0.2"}), network)
+		}
+		Expect(answers(exchange("udp", "api.example.com.", dnsmessage.TypeAAAA))).To(Equal([]string{"api.example.com. 900 AAAA 2001:db8::1"}))
+		Expect(answers(exchange("udp", "example.com.", dnsmessage.TypeMX))).To(Equal([]string{"example.com. 900 MX 10 mail.example.com."}))
+		Expect(answers(exchange("udp", "example.com.", dnsmessage.TypeTXT))).To(Equal([]string{"example.com. 900 TXT v=spf1 -all"}))
+		Expect(answers(exchange("udp", "example.com.", dnsmessage.TypeSOA))).To(Equal([]string{"example.com. 3600 SOA ns1.example.com. 42"}))
+		Expect(answers(exchange("udp", "_sip._udp.example.com.", dnsmessage.TypeSRV))).To(Equal([]string{"_sip._udp.example.com. 900 SRV 1 5 5060 sip.example.com."}))
+		Expect(answers(exchange("udp", "1.0.0.10.in-addr.arpa.", dnsmessage.TypePTR))).To(Equal([]string{"1.0.0.10.in-addr.arpa. 300 PTR www.example.com."}))
+		Expect(answers(exchange("tcp", "example.com.", dnsmessage.TypeALL))).To(HaveLen(3))
+	})
+	It(`Answer CNAME records`, func() {
+		Expect(answers(exchange("udp", "alias.example.com.", dnsmessage.TypeA))).To(Equal([]string{
+			"alias.example.com. 60 CNAME www.example.com.", "www.example.com. 300 A 10.0.0.1", "www.example.com. 300 A 10.0.0.2",
+		}))
+		Expect(answers(exchange("udp", "alias.example.com.", dnsmessage.TypeCNAME))).To(Equal([]string{"alias.example.com. 60 CNAME www.example.com."}))
+		response := exchange("udp", "ext.example.com.", dnsmessage.TypeA)
+		Expect(response.RCode).To(Equal(dnsmessage.RCodeSuccess))
+		Expect(answers(response)).To(Equal([]string{"ext.example.com. 60 CNAME www.example.net."}))
+		response = exchange("udp", "dangling.example.com.", dnsmessage.TypeA)
+		Expect(response.RCode).To(Equal(dnsmessage.RCodeNameError))
+		Expect(answers(response)).To(Equal([]string{"dangling.example.com. 60 CNAME gone.example.com."}))
+	})
+	It(`Answer NXDOMAIN and NODATA`, func() {
+		response := exchange("udp", "nothere.example.com.", dnsmessage.TypeA)
+		Expect(response.RCode).To(Equal(dnsmessage.RCodeNameError))
+		Expect(response.Answers).To(BeEmpty())
+		Expect(response.Authorities).To(HaveLen(1))
+		Expect(response.Authorities[0].Header.Type).To(Equal(dnsmessage.TypeSOA))
+		Expect(response.Authorities[0].Header.TTL).To(Equal(uint32(120)))
+
+		for _, name := range []string{"www.example.com.", "_udp.example.com.", "example.com."} {
+			response = exchange("udp", name, dnsmessage.TypeAAAA)
+			Expect(response.RCode).To(Equal(dnsmessage.RCodeSuccess), name)
+			Expect(response.Answers).To(BeEmpty(), name)
+			Expect(response.Authorities).To(HaveLen(1), name)
+		}
+
+		response = exchange("udp", "www.example.org.", dnsmessage.TypeA)
+		Expect(response.RCode).To(Equal(dnsmessage.RCodeRefused))
+		Expect(response.Authoritative).To(BeFalse())
+	})
+	It(`Answer load balancers from pool origins`, func() {
+		Expect(answers(exchange("udp", "glb.example.com.", dnsmessage.TypeA))).To(Equal([]string{"glb.example.com. 30 A 10.1.0.1"}))
+		Expect(answers(exchange("udp", "glb.example.com.", dnsmessage.TypeAAAA))).To(Equal([]string{"glb.example.com. 30 AAAA 2001:db8::2"}))
+		Expect(answers(exchange("udp", "fb.example.com.", dnsmessage.TypeA))).To(Equal([]string{"fb.example.com. 60 CNAME origin.example.net."}))
+		Expect(exchange("udp", "off.example.com.", dnsmessage.TypeA).RCode).To(Equal(dnsmessage.RCodeNameError))
+	})
+	It(`Truncate large answers over UDP`, func() {
+		snapshot := &dnsresponder.Snapshot{Zones: []dnsresponder.SnapshotZone{{Dnszone: dnssvcsv1.Dnszone{Name: core.StringPtr("example.com")}}}}
+		for i := 0; i < 40; i++ {
+			snapshot.Zones[0].ResourceRecords = append(snapshot.Zones[0].ResourceRecords, dnssvcsv1.ResourceRecord{
+				Name: core.StringPtr("big"), Type: core.StringPtr("TXT"), Rdata: map[string]interface{}{"text": strings.Repeat("x", 300) + fmt.Sprint(i)},
+			})
+		}
+		Expect(server.Load(snapshot)).To(Succeed())
+		response := exchange("udp", "big.example.com.", dnsmessage.TypeTXT)
+		Expect(response.Truncated).To(BeTrue())
+		Expect(response.Answers).To(BeEmpty())
+		response = exchange("tcp", "big.example.com.", dnsmessage.TypeTXT)
+		Expect(response.Truncated).To(BeFalse())
+		Expect(response.Answers).To(HaveLen(40))
+		Expect(response.Answers[0].Body.(*dnsmessage.TXTResource).TXT).To(HaveLen(2))
+		Expect(exchange("udp", "www.example.com.", dnsmessage.TypeA).RCode).To(Equal(dnsmessage.RCodeNameError))
+	})
+	It(`Answer malformed queries`, func() {
+		_, err := server.Answer([]byte{0, 1})
+		Expect(err).ToNot(BeNil())
+
+		query := dnsmessage.Message{Header: dnsmessage.Header{ID: 9}}
+		packed, err := query.Pack()
+		Expect(err).To(BeNil())
+		packed, err = server.Answer(packed)
+		Expect(err).To(BeNil())
+		var response dnsmessage.Message
+		Expect(response.Unpack(packed)).To(Succeed())
+		Expect(response.RCode).To(Equal(dnsmessage.RCodeFormatError))
+
+		_, err = dnsresponder.NewServer(&dnsresponder.Snapshot{Zones: []dnsresponder.SnapshotZone{{
+			Dnszone:         dnssvcsv1.Dnszone{Name: core.StringPtr("example.com")},
+			ResourceRecords: []dnssvcsv1.ResourceRecord{{Name: core.StringPtr("www"), Type: core.StringPtr("A"), Rdata: map[string]interface{}{"ip": "2001:db8::1"}}},
+		}}})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("www.example.com"))
+	})
+})
@@ -0,0 +1,121 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dnsresponder answers DNS queries for the DNS zones of a DNS Services instance from a snapshot, without the
+// cloud, so that applications can be tested against the names they resolve in production.
+package dnsresponder
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+)
+
+// Snapshot : The DNS zones of an instance with their resource records and load balancers, and the pools the load
+// balancers use.
+type Snapshot struct {
+	// The DNS zones.
+	Zones []SnapshotZone `json:"zones"`
+
+	// The pools of the instance.
+	Pools []dnssvcsv1.Pool `json:"pools,omitempty"`
+}
+
+// SnapshotZone : A DNS zone of a snapshot.
+type SnapshotZone struct {
+	// The DNS zone.
+	Dnszone dnssvcsv1.Dnszone `json:"dnszone"`
+
+	// The resource records of the DNS zone.
+	ResourceRecords []dnssvcsv1.ResourceRecord `json:"resource_records"`
+
+	// The load balancers of the DNS zone.
+	LoadBalancers []dnssvcsv1.LoadBalancer `json:"load_balancers,omitempty"`
+}
+
+// LoadSnapshot reads the DNS zones, resource records, load balancers and pools of an instance.
+func LoadSnapshot(dnsSvcs *dnssvcsv1.DnsSvcsV1, instanceID string) (snapshot *Snapshot, err error) {
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID))
+	if err != nil {
+		return
+	}
+	snapshot = &Snapshot{Zones: []SnapshotZone{}}
+	for _, dnszone := range dnszones {
+		zone := SnapshotZone{Dnszone: dnszone}
+		zone.ResourceRecords, err = dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(instanceID, *dnszone.ID))
+		if err != nil {
+			return nil, err
+		}
+		loadBalancers, _, listErr := dnsSvcs.ListLoadBalancers(dnsSvcs.NewListLoadBalancersOptions(instanceID, *dnszone.ID))
+		if listErr != nil {
+			return nil, listErr
+		}
+		zone.LoadBalancers = loadBalancers.LoadBalancers
+		snapshot.Zones = append(snapshot.Zones, zone)
+	}
+	pools, _, err := dnsSvcs.ListPools(dnsSvcs.NewListPoolsOptions(instanceID))
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Pools = pools.Pools
+	return
+}
+
+// ReadSnapshot decodes a snapshot written by Write.
+func ReadSnapshot(r io.Reader) (snapshot *Snapshot, err error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &snapshot)
+	return
+}
+
+// ReadSnapshotFile decodes the snapshot in a file written by WriteFile.
+func ReadSnapshotFile(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadSnapshot(file)
+}
+
+// Write encodes the snapshot as JSON.
+func (snapshot *Snapshot) Write(w io.Writer) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteFile encodes the snapshot as JSON into a file.
+func (snapshot *Snapshot) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = snapshot.Write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
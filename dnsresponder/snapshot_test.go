@@ -0,0 +1,90 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnsresponder_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnsresponder"
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Snapshot`, func() {
+	var server *httptest.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	BeforeEach(func() {
+		responses := map[string]string{
+			"/instances/instance/dnszones":                       `{"dnszones": [{"id": "zone", "name": "example.com"}], "total_count": 1}`,
+			"/instances/instance/dnszones/zone/resource_records": `{"resource_records": [{"id": "www", "type": "A", "name": "www.example.com", "ttl": 300, "rdata": {"ip": "10.0.0.1"}}], "total_count": 1}`,
+			"/instances/instance/dnszones/zone/load_balancers":   `{"load_balancers": [{"id": "glb", "name": "glb.example.com", "default_pools": ["pool"], "fallback_pool": "pool"}]}`,
+			"/instances/instance/pools":                          `{"pools": [{"id": "pool", "origins": [{"name": "a", "address": "10.1.0.1"}]}]}`,
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			response, found := responses[req.URL.Path]
+			if !found {
+				res.WriteHeader(404)
+				return
+			}
+			res.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(res, response)
+		}))
+		var err error
+		testService, err = dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	It(`Invoke LoadSnapshot, WriteFile and ReadSnapshotFile successfully`, func() {
+		snapshot, err := dnsresponder.LoadSnapshot(testService, "instance")
+		Expect(err).To(BeNil())
+		Expect(snapshot.Zones).To(HaveLen(1))
+		Expect(*snapshot.Zones[0].Dnszone.Name).To(Equal("example.com"))
+		Expect(snapshot.Zones[0].ResourceRecords).To(HaveLen(1))
+		Expect(snapshot.Zones[0].LoadBalancers).To(HaveLen(1))
+		Expect(snapshot.Pools).To(HaveLen(1))
+
+		dir, err := ioutil.TempDir("", "snapshot")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "snapshot.json")
+		Expect(snapshot.WriteFile(file)).To(Succeed())
+		read, err := dnsresponder.ReadSnapshotFile(file)
+		Expect(err).To(BeNil())
+		Expect(read).To(Equal(snapshot))
+
+		_, err = dnsresponder.NewServer(read)
+		Expect(err).To(BeNil())
+	})
+	It(`Invoke LoadSnapshot with error`, func() {
+		_, err := dnsresponder.LoadSnapshot(testService, "other")
+		Expect(err).ToNot(BeNil())
+		_, err = dnsresponder.ReadSnapshotFile(filepath.Join(os.TempDir(), "missing-snapshot.json"))
+		Expect(err).ToNot(BeNil())
+	})
+})
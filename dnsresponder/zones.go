@@ -0,0 +1,432 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnsresponder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// defaultTTL is the TTL of resource records without one, the default of the API.
+	defaultTTL = 900
+
+	// defaultLoadBalancerTTL is the TTL of load balancers without one, the default of the API.
+	defaultLoadBalancerTTL = 60
+
+	// maxCNAMEChain bounds the CNAME records followed for a query, against loops.
+	maxCNAMEChain = 8
+
+	// maxTXTString is the longest character-string of a TXT record; longer text is split.
+	maxTXTString = 255
+)
+
+// zone is a DNS zone of a snapshot indexed for answering queries.
+type zone struct {
+	// name is the lower-case name of the DNS zone without the trailing dot.
+	name string
+
+	// soa is the SOA record of the DNS zone, returned in the authority section of negative answers.
+	soa dnsmessage.Resource
+
+	// names maps the lower-case names of the DNS zone to their resource records. Names without resource records
+	// that have descendants with some, the empty non-terminals, map to no resource records so that they exist.
+	names map[string][]dnsmessage.Resource
+}
+
+// newZones indexes the DNS zones of a snapshot, the most specific first so that the first DNS zone containing a name
+// is the one answering for it.
+func newZones(snapshot *Snapshot) (zones []*zone, err error) {
+	pools := make(map[string]*dnssvcsv1.Pool)
+	for i := range snapshot.Pools {
+		if snapshot.Pools[i].ID != nil {
+			pools[*snapshot.Pools[i].ID] = &snapshot.Pools[i]
+		}
+	}
+	for i := range snapshot.Zones {
+		snapshotZone := &snapshot.Zones[i]
+		if snapshotZone.Dnszone.Name == nil {
+			return nil, fmt.Errorf("a DNS zone has no name")
+		}
+		z := &zone{name: normalizeName(*snapshotZone.Dnszone.Name), names: make(map[string][]dnsmessage.Resource)}
+		z.soa, err = z.defaultSOA()
+		if err != nil {
+			return
+		}
+		for j := range snapshotZone.ResourceRecords {
+			err = z.addResourceRecord(&snapshotZone.ResourceRecords[j])
+			if err != nil {
+				return nil, fmt.Errorf("DNS zone %s: %s", z.name, err.Error())
+			}
+		}
+		for j := range snapshotZone.LoadBalancers {
+			err = z.addLoadBalancer(&snapshotZone.LoadBalancers[j], pools)
+			if err != nil {
+				return nil, fmt.Errorf("DNS zone %s: %s", z.name, err.Error())
+			}
+		}
+		z.add(z.name, z.soa)
+		zones = append(zones, z)
+	}
+	sort.SliceStable(zones, func(i, j int) bool {
+		return strings.Count(zones[i].name, ".") > strings.Count(zones[j].name, ".")
+	})
+	return
+}
+
+// contains reports whether a name is the name of the DNS zone or below it.
+func (z *zone) contains(name string) bool {
+	return name == z.name || strings.HasSuffix(name, "."+z.name)
+}
+
+// add adds a resource record, and the empty non-terminals between its name and the DNS zone.
+func (z *zone) add(name string, resource dnsmessage.Resource) {
+	z.names[name] = append(z.names[name], resource)
+	for name != z.name {
+		name = name[strings.Index(name, ".")+1:]
+		if _, exists := z.names[name]; !exists {
+			z.names[name] = nil
+		}
+	}
+}
+
+// defaultSOA returns the SOA record of a DNS zone without one in the snapshot.
+func (z *zone) defaultSOA() (soa dnsmessage.Resource, err error) {
+	header, err := resourceHeader(z.name, dnsmessage.TypeSOA, defaultTTL)
+	if err != nil {
+		return
+	}
+	ns, err := newName("ns1." + z.name)
+	if err != nil {
+		return
+	}
+	mbox, err := newName("hostmaster." + z.name)
+	if err != nil {
+		return
+	}
+	soa = dnsmessage.Resource{Header: header, Body: &dnsmessage.SOAResource{
+		NS: ns, MBox: mbox, Serial: 1, Refresh: 7200, Retry: 3600, Expire: 1209600, MinTTL: defaultTTL,
+	}}
+	return
+}
+
+// addResourceRecord adds a resource record of the API. Types that cannot be answered, such as CAA, are skipped.
+func (z *zone) addResourceRecord(record *dnssvcsv1.ResourceRecord) error {
+	if record.Name == nil || record.Type == nil {
+		return fmt.Errorf("a resource record has no name or type")
+	}
+	name := z.qualify(*record.Name)
+	recordType := strings.ToUpper(*record.Type)
+	if recordType == dnssvcsv1.ResourceRecord_Type_Ptr && net.ParseIP(*record.Name) != nil {
+		reverseName, err := dnssvcsv1.ReverseName(*record.Name)
+		if err != nil {
+			return err
+		}
+		name = reverseName
+	}
+	ttl := uint32(defaultTTL)
+	if record.TTL != nil && *record.TTL > 0 {
+		ttl = uint32(*record.TTL)
+	}
+	var rdata rdataMap
+	data, err := json.Marshal(record.Rdata)
+	if err == nil {
+		err = json.Unmarshal(data, &rdata)
+	}
+	if err != nil {
+		return fmt.Errorf("%s record %s: %s", recordType, name, err.Error())
+	}
+
+	var body dnsmessage.ResourceBody
+	var rrType dnsmessage.Type
+	switch recordType {
+	case dnssvcsv1.ResourceRecord_Type_A:
+		rrType = dnsmessage.TypeA
+		body, err = addressBody(rdata.string("ip"), false)
+	case dnssvcsv1.ResourceRecord_Type_Aaaa:
+		rrType = dnsmessage.TypeAAAA
+		body, err = addressBody(rdata.string("ip"), true)
+	case dnssvcsv1.ResourceRecord_Type_Cname:
+		rrType = dnsmessage.TypeCNAME
+		var target dnsmessage.Name
+		target, err = newName(rdata.string("cname"))
+		body = &dnsmessage.CNAMEResource{CNAME: target}
+	case dnssvcsv1.ResourceRecord_Type_Ptr:
+		rrType = dnsmessage.TypePTR
+		var target dnsmessage.Name
+		target, err = newName(rdata.string("ptrdname"))
+		body = &dnsmessage.PTRResource{PTR: target}
+	case dnssvcsv1.ResourceRecord_Type_Ns:
+		rrType = dnsmessage.TypeNS
+		var target dnsmessage.Name
+		target, err = newName(rdata.string("nsdname"))
+		body = &dnsmessage.NSResource{NS: target}
+	case dnssvcsv1.ResourceRecord_Type_Mx:
+		rrType = dnsmessage.TypeMX
+		var exchange dnsmessage.Name
+		exchange, err = newName(rdata.string("exchange"))
+		body = &dnsmessage.MXResource{Pref: uint16(rdata.number("preference")), MX: exchange}
+	case dnssvcsv1.ResourceRecord_Type_Srv:
+		rrType = dnsmessage.TypeSRV
+		var target dnsmessage.Name
+		target, err = newName(rdata.string("target"))
+		body = &dnsmessage.SRVResource{
+			Priority: uint16(rdata.number("priority")),
+			Weight:   uint16(rdata.number("weight")),
+			Port:     uint16(rdata.number("port")),
+			Target:   target,
+		}
+	case dnssvcsv1.ResourceRecord_Type_Txt:
+		rrType = dnsmessage.TypeTXT
+		body = &dnsmessage.TXTResource{TXT: splitText(rdata.string("text"))}
+	case dnssvcsv1.ResourceRecord_Type_Soa:
+		rrType = dnsmessage.TypeSOA
+		soa := *z.soa.Body.(*dnsmessage.SOAResource)
+		if mname := rdata.string("mname"); mname != "" {
+			soa.NS, err = newName(mname)
+		}
+		if rname := rdata.string("rname"); rname != "" && err == nil {
+			soa.MBox, err = newName(rname)
+		}
+		soa.Serial = uint32(rdata.numberOr("serial", uint64(soa.Serial)))
+		soa.Refresh = uint32(rdata.numberOr("refresh", uint64(soa.Refresh)))
+		soa.Retry = uint32(rdata.numberOr("retry", uint64(soa.Retry)))
+		soa.Expire = uint32(rdata.numberOr("expire", uint64(soa.Expire)))
+		soa.MinTTL = uint32(rdata.numberOr("minimum", uint64(soa.MinTTL)))
+		body = &soa
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s record %s: %s", recordType, name, err.Error())
+	}
+	header, err := resourceHeader(name, rrType, ttl)
+	if err != nil {
+		return err
+	}
+	if rrType == dnsmessage.TypeSOA {
+		z.soa = dnsmessage.Resource{Header: header, Body: body}
+		return nil
+	}
+	z.add(name, dnsmessage.Resource{Header: header, Body: body})
+	return nil
+}
+
+// addLoadBalancer adds the A and AAAA records of the origins a load balancer answers with: those of the first of
+// its default pools that is enabled and not critical with healthy origins, or else of its fallback pool. Origins
+// with a host name rather than an address are answered with a CNAME record, when there are no addresses.
+func (z *zone) addLoadBalancer(loadBalancer *dnssvcsv1.LoadBalancer, pools map[string]*dnssvcsv1.Pool) error {
+	if loadBalancer.Name == nil || (loadBalancer.Enabled != nil && !*loadBalancer.Enabled) {
+		return nil
+	}
+	name := z.qualify(*loadBalancer.Name)
+	ttl := uint32(defaultLoadBalancerTTL)
+	if loadBalancer.TTL != nil && *loadBalancer.TTL > 0 {
+		ttl = uint32(*loadBalancer.TTL)
+	}
+
+	var origins []dnssvcsv1.Origin
+	for _, poolID := range loadBalancer.DefaultPools {
+		pool := pools[poolID]
+		if pool == nil || (pool.Enabled != nil && !*pool.Enabled) || (pool.Health != nil && *pool.Health == dnssvcsv1.Pool_Health_Critical) {
+			continue
+		}
+		origins = usableOrigins(pool, true)
+		if len(origins) > 0 {
+			break
+		}
+	}
+	if len(origins) == 0 && loadBalancer.FallbackPool != nil && pools[*loadBalancer.FallbackPool] != nil {
+		origins = usableOrigins(pools[*loadBalancer.FallbackPool], false)
+	}
+
+	var hostNames []string
+	added := false
+	for _, origin := range origins {
+		address := strings.TrimSpace(*origin.Address)
+		ip := net.ParseIP(address)
+		if ip == nil {
+			hostNames = append(hostNames, address)
+			continue
+		}
+		rrType := dnsmessage.TypeA
+		if ip.To4() == nil {
+			rrType = dnsmessage.TypeAAAA
+		}
+		body, err := addressBody(address, rrType == dnsmessage.TypeAAAA)
+		if err != nil {
+			return err
+		}
+		header, err := resourceHeader(name, rrType, ttl)
+		if err != nil {
+			return err
+		}
+		z.add(name, dnsmessage.Resource{Header: header, Body: body})
+		added = true
+	}
+	if !added && len(hostNames) > 0 {
+		target, err := newName(hostNames[0])
+		if err != nil {
+			return fmt.Errorf("load balancer %s: %s", name, err.Error())
+		}
+		header, err := resourceHeader(name, dnsmessage.TypeCNAME, ttl)
+		if err != nil {
+			return err
+		}
+		z.add(name, dnsmessage.Resource{Header: header, Body: &dnsmessage.CNAMEResource{CNAME: target}})
+	}
+	return nil
+}
+
+// usableOrigins returns the enabled origins of a pool with an address, leaving out unhealthy ones if asked to.
+func usableOrigins(pool *dnssvcsv1.Pool, healthyOnly bool) (origins []dnssvcsv1.Origin) {
+	for _, origin := range pool.Origins {
+		if origin.Address == nil || (origin.Enabled != nil && !*origin.Enabled) {
+			continue
+		}
+		if healthyOnly && origin.Health != nil && !*origin.Health {
+			continue
+		}
+		origins = append(origins, origin)
+	}
+	return
+}
+
+// lookup answers a question for a name of the DNS zone, following CNAME records within the DNS zone. The answer is
+// NXDOMAIN when the final name does not exist and NODATA, no answers without an error, when it exists without
+// resource records of the type asked for; both come with the SOA record as authority.
+func (z *zone) lookup(name string, qtype dnsmessage.Type) (answers []dnsmessage.Resource, authorities []dnsmessage.Resource, rcode dnsmessage.RCode) {
+	for chain := 0; ; chain++ {
+		resources, exists := z.names[name]
+		matched := filterType(resources, qtype)
+		if len(matched) > 0 {
+			return append(answers, matched...), nil, dnsmessage.RCodeSuccess
+		}
+		cnames := filterType(resources, dnsmessage.TypeCNAME)
+		if len(cnames) > 0 && qtype != dnsmessage.TypeCNAME {
+			answers = append(answers, cnames[0])
+			target := normalizeName(cnames[0].Body.(*dnsmessage.CNAMEResource).CNAME.String())
+			if !z.contains(target) || chain >= maxCNAMEChain {
+				return answers, nil, dnsmessage.RCodeSuccess
+			}
+			name = target
+			continue
+		}
+		rcode = dnsmessage.RCodeSuccess
+		if !exists {
+			rcode = dnsmessage.RCodeNameError
+		}
+		soa := z.soa
+		if minTTL := soa.Body.(*dnsmessage.SOAResource).MinTTL; minTTL < soa.Header.TTL {
+			soa.Header.TTL = minTTL
+		}
+		return answers, []dnsmessage.Resource{soa}, rcode
+	}
+}
+
+// qualify returns the lower-case fully qualified form of a name relative to the DNS zone.
+func (z *zone) qualify(name string) string {
+	name = normalizeName(name)
+	if name == "" || name == "@" {
+		return z.name
+	}
+	if z.contains(name) {
+		return name
+	}
+	return name + "." + z.name
+}
+
+// filterType returns the resource records of a type, all of them for TypeALL.
+func filterType(resources []dnsmessage.Resource, rrType dnsmessage.Type) (matched []dnsmessage.Resource) {
+	for _, resource := range resources {
+		if rrType == dnsmessage.TypeALL || resource.Header.Type == rrType {
+			matched = append(matched, resource)
+		}
+	}
+	return
+}
+
+// rdataMap is the rdata of a resource record decoded from JSON.
+type rdataMap map[string]interface{}
+
+func (rdata rdataMap) string(key string) string {
+	value, _ := rdata[key].(string)
+	return value
+}
+
+func (rdata rdataMap) number(key string) uint64 {
+	return rdata.numberOr(key, 0)
+}
+
+func (rdata rdataMap) numberOr(key string, defaultValue uint64) uint64 {
+	value, isNumber := rdata[key].(float64)
+	if !isNumber || value < 0 {
+		return defaultValue
+	}
+	return uint64(value)
+}
+
+// addressBody returns the A or AAAA record body of an IP address.
+func addressBody(address string, ipv6 bool) (dnsmessage.ResourceBody, error) {
+	ip := net.ParseIP(address)
+	if ip == nil || (ip.To4() == nil) != ipv6 {
+		return nil, fmt.Errorf("'%s' is not a valid IPv%s address", address, map[bool]string{false: "4", true: "6"}[ipv6])
+	}
+	if ipv6 {
+		body := &dnsmessage.AAAAResource{}
+		copy(body.AAAA[:], ip.To16())
+		return body, nil
+	}
+	body := &dnsmessage.AResource{}
+	copy(body.A[:], ip.To4())
+	return body, nil
+}
+
+// splitText splits the text of a TXT record into character-strings.
+func splitText(text string) (texts []string) {
+	for len(text) > maxTXTString {
+		texts = append(texts, text[:maxTXTString])
+		text = text[maxTXTString:]
+	}
+	return append(texts, text)
+}
+
+func resourceHeader(name string, rrType dnsmessage.Type, ttl uint32) (header dnsmessage.ResourceHeader, err error) {
+	header.Name, err = newName(name)
+	header.Type, header.Class, header.TTL = rrType, dnsmessage.ClassINET, ttl
+	return
+}
+
+// newName returns the DNS message name of a domain name.
+func newName(name string) (dnsmessage.Name, error) {
+	name = normalizeName(name)
+	if name == "" {
+		return dnsmessage.Name{}, fmt.Errorf("a domain name is empty")
+	}
+	return dnsmessage.NewName(name + ".")
+}
+
+// normalizeName returns a domain name in lower case without the trailing dot.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
@@ -0,0 +1,313 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsinstancesv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	common "github.com/IBM/dns-svcs-go-sdk/common"
+	"github.com/IBM/go-sdk-core/v3/core"
+)
+
+// DnsSvcsResourceID is the global catalog ID of the DNS Services offering.
+const DnsSvcsResourceID = "b4ed8a30-936f-11e9-b289-1d079699cbe5"
+
+// DefaultInstanceResolverTTL is how long an InstanceResolver caches the instances it resolves.
+const DefaultInstanceResolverTTL = 10 * time.Minute
+
+// InstanceResolver : Finds DNS Services instances by name, resource group, tag or CRN, and caches what it finds.
+// Instances that are removed or pending reclamation are never resolved.
+type InstanceResolver struct {
+	// The resource controller client the instances are listed with.
+	Service *DnsSvcsInstancesV2
+
+	// The global catalog ID of the offering of the instances, DnsSvcsResourceID by default.
+	ResourceID string
+
+	// How long resolved instances are cached; zero disables the cache.
+	TTL time.Duration
+
+	// Returns the CRNs of the resources that have a tag. The resource controller does not return the tags of
+	// instances, so resolving by tag requires a lookup, for example through the global search and tagging API.
+	TagLookup func(tag string) (crns []string, err error)
+
+	mutex sync.Mutex
+	cache map[string]resolvedInstance
+}
+
+type resolvedInstance struct {
+	instance *ResourceInstance
+	expires  time.Time
+}
+
+// NewInstanceResolver : Instantiate InstanceResolver for DNS Services instances, with a cache of
+// DefaultInstanceResolverTTL.
+func (dnsSvcsInstances *DnsSvcsInstancesV2) NewInstanceResolver() *InstanceResolver {
+	return &InstanceResolver{
+		Service:    dnsSvcsInstances,
+		ResourceID: DnsSvcsResourceID,
+		TTL:        DefaultInstanceResolverTTL,
+		cache:      make(map[string]resolvedInstance),
+	}
+}
+
+// SetResourceID : Allow user to set ResourceID
+func (resolver *InstanceResolver) SetResourceID(resourceID string) *InstanceResolver {
+	resolver.ResourceID = resourceID
+	return resolver
+}
+
+// SetTTL : Allow user to set TTL
+func (resolver *InstanceResolver) SetTTL(ttl time.Duration) *InstanceResolver {
+	resolver.TTL = ttl
+	return resolver
+}
+
+// SetTagLookup : Allow user to set TagLookup
+func (resolver *InstanceResolver) SetTagLookup(tagLookup func(tag string) (crns []string, err error)) *InstanceResolver {
+	resolver.TagLookup = tagLookup
+	return resolver
+}
+
+// ResolveInstanceOptions : The ResolveInstance options. At least one criterion is required; an instance must match
+// all of those set.
+type ResolveInstanceOptions struct {
+	// The name of the instance.
+	Name *string `json:"name,omitempty"`
+
+	// The short ID of the resource group of the instance.
+	ResourceGroupID *string `json:"resource_group_id,omitempty"`
+
+	// A tag of the instance. Requires the TagLookup of the resolver.
+	Tag *string `json:"tag,omitempty"`
+
+	// The CRN of the instance.
+	Crn *string `json:"crn,omitempty"`
+}
+
+// NewResolveInstanceOptions : Instantiate ResolveInstanceOptions
+func (*DnsSvcsInstancesV2) NewResolveInstanceOptions() *ResolveInstanceOptions {
+	return &ResolveInstanceOptions{}
+}
+
+// SetName : Allow user to set Name
+func (options *ResolveInstanceOptions) SetName(name string) *ResolveInstanceOptions {
+	options.Name = core.StringPtr(name)
+	return options
+}
+
+// SetResourceGroupID : Allow user to set ResourceGroupID
+func (options *ResolveInstanceOptions) SetResourceGroupID(resourceGroupID string) *ResolveInstanceOptions {
+	options.ResourceGroupID = core.StringPtr(resourceGroupID)
+	return options
+}
+
+// SetTag : Allow user to set Tag
+func (options *ResolveInstanceOptions) SetTag(tag string) *ResolveInstanceOptions {
+	options.Tag = core.StringPtr(tag)
+	return options
+}
+
+// SetCrn : Allow user to set Crn
+func (options *ResolveInstanceOptions) SetCrn(crn string) *ResolveInstanceOptions {
+	options.Crn = core.StringPtr(crn)
+	return options
+}
+
+// criteria describes the criteria set, for example "name=dns, resource_group_id=1234".
+func (options *ResolveInstanceOptions) criteria() string {
+	var criteria []string
+	for _, criterion := range []struct {
+		name  string
+		value *string
+	}{{"name", options.Name}, {"resource_group_id", options.ResourceGroupID}, {"tag", options.Tag}, {"crn", options.Crn}} {
+		if criterion.value != nil {
+			criteria = append(criteria, criterion.name+"="+*criterion.value)
+		}
+	}
+	return strings.Join(criteria, ", ")
+}
+
+// AmbiguousInstanceError is returned by ResolveInstance when more than one instance matches the criteria.
+type AmbiguousInstanceError struct {
+	// The criteria, for example "name=dns".
+	Criteria string
+
+	// The instances matching the criteria.
+	Matches []ResourceInstance
+}
+
+// Error returns the description of the error, with the names and GUIDs of the instances matching.
+func (e *AmbiguousInstanceError) Error() string {
+	matches := make([]string, 0, len(e.Matches))
+	for _, match := range e.Matches {
+		matches = append(matches, fmt.Sprintf("%s (%s)", stringValue(match.Name), stringValue(match.Guid)))
+	}
+	return fmt.Sprintf("%d instances match %s: %s", len(e.Matches), e.Criteria, strings.Join(matches, ", "))
+}
+
+// ResolveInstance : Find the instance matching the criteria of the options
+// Return the only instance matching the criteria, from the cache when it was resolved within the TTL for the same
+// ResourceID. The instance returned is a copy the caller may change. An *AmbiguousInstanceError is returned when
+// several instances match.
+func (resolver *InstanceResolver) ResolveInstance(resolveInstanceOptions *ResolveInstanceOptions) (instance *ResourceInstance, err error) {
+	err = core.ValidateNotNil(resolveInstanceOptions, "resolveInstanceOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	options := resolveInstanceOptions
+	criteria := options.criteria()
+	if criteria == "" {
+		err = fmt.Errorf("a name, resource group ID, tag or CRN is required to resolve an instance")
+		return
+	}
+	// The cache key includes the offering, which can be changed between calls.
+	key := resolver.ResourceID + " " + criteria
+	if resolver.TTL > 0 {
+		resolver.mutex.Lock()
+		cached, found := resolver.cache[key]
+		resolver.mutex.Unlock()
+		if found && time.Now().Before(cached.expires) {
+			return copyResourceInstance(cached.instance), nil
+		}
+	}
+
+	listOptions := resolver.Service.NewListResourceInstancesOptions(resolver.ResourceID, ListResourceInstancesOptions_Type_ServiceInstance)
+	if options.Name != nil {
+		listOptions.SetName(*options.Name)
+	}
+	if options.ResourceGroupID != nil {
+		listOptions.SetResourceGroupID(*options.ResourceGroupID)
+	}
+	crns := make(map[string]bool)
+	if options.Crn != nil {
+		crn, parseErr := common.ParseCrn(*options.Crn)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		if crn.ServiceInstance != "" {
+			listOptions.SetGuid(crn.ServiceInstance)
+		}
+		crns[crn.String()] = true
+	}
+	if options.Tag != nil {
+		if resolver.TagLookup == nil {
+			err = fmt.Errorf("resolving instances by tag requires a TagLookup")
+			return
+		}
+		var tagged []string
+		tagged, err = resolver.TagLookup(*options.Tag)
+		if err != nil {
+			return
+		}
+		tagCrns := make(map[string]bool)
+		for _, crn := range tagged {
+			if options.Crn == nil || crns[crn] {
+				tagCrns[crn] = true
+			}
+		}
+		crns = tagCrns
+	}
+
+	instances, err := resolver.Service.ListAllResourceInstances(listOptions)
+	if err != nil {
+		return
+	}
+	var matches []ResourceInstance
+	for _, candidate := range instances {
+		state := candidate.StateValue()
+		if state == ResourceInstance_State_Removed || state == ResourceInstance_State_PendingReclamation {
+			continue
+		}
+		if (options.Crn != nil || options.Tag != nil) && !crns[stringValue(candidate.Crn)] {
+			continue
+		}
+		if options.Name != nil && stringValue(candidate.Name) != *options.Name {
+			continue
+		}
+		if options.ResourceGroupID != nil && stringValue(candidate.ResourceGroupID) != *options.ResourceGroupID {
+			continue
+		}
+		matches = append(matches, candidate)
+	}
+	switch len(matches) {
+	case 0:
+		err = fmt.Errorf("no instance matches %s", criteria)
+		return
+	case 1:
+		instance = &matches[0]
+	default:
+		err = &AmbiguousInstanceError{Criteria: criteria, Matches: matches}
+		return
+	}
+	if resolver.TTL > 0 {
+		resolver.mutex.Lock()
+		if resolver.cache == nil {
+			resolver.cache = make(map[string]resolvedInstance)
+		}
+		resolver.cache[key] = resolvedInstance{instance: copyResourceInstance(instance), expires: time.Now().Add(resolver.TTL)}
+		resolver.mutex.Unlock()
+	}
+	return
+}
+
+// ResolveInstanceGUID : Find the GUID of the instance matching the criteria of the options
+// Return the GUID of the only instance matching the criteria, the instance ID that DnsSvcsV1 operations take.
+func (resolver *InstanceResolver) ResolveInstanceGUID(resolveInstanceOptions *ResolveInstanceOptions) (guid string, err error) {
+	instance, err := resolver.ResolveInstance(resolveInstanceOptions)
+	if err != nil {
+		return
+	}
+	if instance.Guid == nil || *instance.Guid == "" {
+		err = fmt.Errorf("instance %s has no GUID", stringValue(instance.Name))
+		return
+	}
+	return *instance.Guid, nil
+}
+
+// InvalidateCache forgets every instance resolved, so that the next ResolveInstance lists the instances again.
+func (resolver *InstanceResolver) InvalidateCache() {
+	resolver.mutex.Lock()
+	resolver.cache = make(map[string]resolvedInstance)
+	resolver.mutex.Unlock()
+}
+
+// copyResourceInstance returns a deep copy of an instance, so that changing the instances returned by ResolveInstance
+// does not change those of the cache. The instance itself is copied when it cannot be encoded.
+func copyResourceInstance(instance *ResourceInstance) *ResourceInstance {
+	copied := &ResourceInstance{}
+	data, err := json.Marshal(instance)
+	if err == nil {
+		err = json.Unmarshal(data, copied)
+	}
+	if err != nil {
+		*copied = *instance
+	}
+	return copied
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,175 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsinstancesv2_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsinstancesv2"
+	"github.com/IBM/go-sdk-core/v3/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`InstanceResolver`, func() {
+	crn := func(guid string) string {
+		return "crn:v1:bluemix:public:dns-svcs:global:a/1234:" + guid + "::"
+	}
+	instances := []map[string]interface{}{
+		{"guid": "g1", "crn": crn("g1"), "name": "dns-a", "resource_group_id": "rg1", "state": "active"},
+		{"guid": "g2", "crn": crn("g2"), "name": "dns-a", "resource_group_id": "rg2", "state": "active"},
+		{"guid": "g3", "crn": crn("g3"), "name": "dns-b", "resource_group_id": "rg1", "state": "removed"},
+		{"guid": "g4", "crn": crn("g4"), "name": "dns-b", "resource_group_id": "rg1", "state": "active"},
+	}
+	var requests int
+	var resourceIDs []string
+	var testServer *httptest.Server
+	var resolver *dnssvcsinstancesv2.InstanceResolver
+	BeforeEach(func() {
+		requests = 0
+		resourceIDs = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			requests++
+			query := req.URL.Query()
+			resourceIDs = append(resourceIDs, query.Get("resource_id"))
+			matching := []map[string]interface{}{}
+			for _, instance := range instances {
+				if (query.Get("name") == "" || query.Get("name") == instance["name"]) &&
+					(query.Get("resource_group_id") == "" || query.Get("resource_group_id") == instance["resource_group_id"]) &&
+					(query.Get("guid") == "" || query.Get("guid") == instance["guid"]) {
+					matching = append(matching, instance)
+				}
+			}
+			start, _ := strconv.Atoi(query.Get("start"))
+			page := map[string]interface{}{"rows_count": len(matching)}
+			if start+2 < len(matching) {
+				page["next_url"] = fmt.Sprintf("/v2/resource_instances?start=%d", start+2)
+				matching = matching[start : start+2]
+			} else {
+				matching = matching[start:]
+			}
+			page["resources"] = matching
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			Expect(json.NewEncoder(res).Encode(page)).To(Succeed())
+		}))
+		testService, testServiceErr := dnssvcsinstancesv2.NewDnsSvcsInstancesV2(&dnssvcsinstancesv2.DnsSvcsInstancesV2Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(testServiceErr).To(BeNil())
+		resolver = testService.NewInstanceResolver()
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+	It(`Invoke ResolveInstance successfully`, func() {
+		instance, err := resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions().SetName("dns-a").SetResourceGroupID("rg2"))
+		Expect(err).To(BeNil())
+		Expect(*instance.Guid).To(Equal("g2"))
+
+		guid, err := resolver.ResolveInstanceGUID(resolver.Service.NewResolveInstanceOptions().SetName("dns-b"))
+		Expect(err).To(BeNil())
+		Expect(guid).To(Equal("g4"))
+
+		guid, err = resolver.ResolveInstanceGUID(resolver.Service.NewResolveInstanceOptions().SetCrn(crn("g1")))
+		Expect(err).To(BeNil())
+		Expect(guid).To(Equal("g1"))
+		Expect(requests).To(Equal(3))
+
+		resolver.SetTagLookup(func(tag string) ([]string, error) {
+			Expect(tag).To(Equal("env:prod"))
+			return []string{crn("g2"), crn("g3")}, nil
+		})
+		guid, err = resolver.ResolveInstanceGUID(resolver.Service.NewResolveInstanceOptions().SetTag("env:prod"))
+		Expect(err).To(BeNil())
+		Expect(guid).To(Equal("g2"))
+		Expect(requests).To(Equal(5))
+		for _, resourceID := range resourceIDs {
+			Expect(resourceID).To(Equal(dnssvcsinstancesv2.DnsSvcsResourceID))
+		}
+	})
+	It(`Invoke ResolveInstance from the cache`, func() {
+		options := resolver.Service.NewResolveInstanceOptions().SetName("dns-b")
+		_, err := resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		_, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(1))
+
+		instance, err := resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		*instance.Name = "changed"
+		instance.LastOperation = map[string]interface{}{"type": "changed"}
+		instance, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		Expect(*instance.Name).To(Equal("dns-b"))
+		Expect(instance.LastOperation).To(BeNil())
+		Expect(requests).To(Equal(1))
+
+		resolver.SetResourceID("other")
+		_, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(2))
+		Expect(resourceIDs).To(Equal([]string{dnssvcsinstancesv2.DnsSvcsResourceID, "other"}))
+		resolver.SetResourceID(dnssvcsinstancesv2.DnsSvcsResourceID)
+		_, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(2))
+
+		resolver.InvalidateCache()
+		_, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(3))
+
+		resolver.SetTTL(0)
+		_, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		_, err = resolver.ResolveInstance(options)
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(5))
+	})
+	It(`Invoke ResolveInstance with error`, func() {
+		_, err := resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions().SetName("dns-a"))
+		ambiguousErr, isAmbiguous := err.(*dnssvcsinstancesv2.AmbiguousInstanceError)
+		Expect(isAmbiguous).To(BeTrue())
+		Expect(ambiguousErr.Matches).To(HaveLen(2))
+		Expect(err.Error()).To(Equal("2 instances match name=dns-a: dns-a (g1), dns-a (g2)"))
+
+		_, err = resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions().SetName("dns-c"))
+		Expect(err.Error()).To(Equal("no instance matches name=dns-c"))
+
+		_, err = resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions().SetCrn(crn("g3")))
+		Expect(err.Error()).To(Equal("no instance matches crn=" + crn("g3")))
+
+		_, err = resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions().SetTag("env:prod"))
+		Expect(err.Error()).To(Equal("resolving instances by tag requires a TagLookup"))
+
+		_, err = resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions().SetCrn("dns"))
+		Expect(err).NotTo(BeNil())
+
+		_, err = resolver.ResolveInstance(resolver.Service.NewResolveInstanceOptions())
+		Expect(err).NotTo(BeNil())
+		_, err = resolver.ResolveInstance(nil)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,203 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsinstancesv2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	common "github.com/IBM/dns-svcs-go-sdk/common"
+	"github.com/go-openapi/strfmt"
+)
+
+// ResourceInstanceState : The state of a resource instance.
+type ResourceInstanceState string
+
+// Constants associated with the ResourceInstance.State property.
+// The current state of the instance.
+const (
+	ResourceInstance_State_Active             ResourceInstanceState = "active"
+	ResourceInstance_State_Failed             ResourceInstanceState = "failed"
+	ResourceInstance_State_Inactive           ResourceInstanceState = "inactive"
+	ResourceInstance_State_PendingReclamation ResourceInstanceState = "pending_reclamation"
+	ResourceInstance_State_PreProvisioning    ResourceInstanceState = "pre_provisioning"
+	ResourceInstance_State_Provisioning       ResourceInstanceState = "provisioning"
+	ResourceInstance_State_Removed            ResourceInstanceState = "removed"
+)
+
+// IsKnown reports whether the state is one of the ResourceInstance_State_ constants.
+func (state ResourceInstanceState) IsKnown() bool {
+	switch state {
+	case ResourceInstance_State_Active, ResourceInstance_State_Failed, ResourceInstance_State_Inactive,
+		ResourceInstance_State_PendingReclamation, ResourceInstance_State_PreProvisioning,
+		ResourceInstance_State_Provisioning, ResourceInstance_State_Removed:
+		return true
+	}
+	return false
+}
+
+// ResourceInstanceType : The type of a resource instance.
+type ResourceInstanceType string
+
+// Constants associated with the ResourceInstance.Type property.
+// The type of the instance.
+const (
+	ResourceInstance_Type_ServiceInstance ResourceInstanceType = "service_instance"
+)
+
+// IsKnown reports whether the type is one of the ResourceInstance_Type_ constants.
+func (instanceType ResourceInstanceType) IsKnown() bool {
+	return instanceType == ResourceInstance_Type_ServiceInstance
+}
+
+// StateValue returns the state of the instance, lower-cased; values that are not one of the ResourceInstance_State_
+// constants are kept as they are. It is empty when State is not set.
+func (resourceInstance *ResourceInstance) StateValue() ResourceInstanceState {
+	if resourceInstance.State == nil {
+		return ""
+	}
+	return ResourceInstanceState(strings.ToLower(*resourceInstance.State))
+}
+
+// TypeValue returns the type of the instance, lower-cased. It is empty when Type is not set.
+func (resourceInstance *ResourceInstance) TypeValue() ResourceInstanceType {
+	if resourceInstance.Type == nil {
+		return ""
+	}
+	return ResourceInstanceType(strings.ToLower(*resourceInstance.Type))
+}
+
+// CreatedAtTime returns the time the instance was created, or an error if CreatedAt is missing.
+func (resourceInstance *ResourceInstance) CreatedAtTime() (time.Time, error) {
+	return dateTimeProperty(resourceInstance.CreatedAt, "created_at")
+}
+
+// UpdatedAtTime returns the time the instance was last updated, or an error if UpdatedAt is missing.
+func (resourceInstance *ResourceInstance) UpdatedAtTime() (time.Time, error) {
+	return dateTimeProperty(resourceInstance.UpdatedAt, "updated_at")
+}
+
+// DeletedAtTime returns the time the instance was deleted, or an error if DeletedAt is missing.
+func (resourceInstance *ResourceInstance) DeletedAtTime() (time.Time, error) {
+	return dateTimeProperty(resourceInstance.DeletedAt, "deleted_at")
+}
+
+// StartDateTime returns the time the plan was changed, or an error if StartDate is missing.
+func (planHistoryItem *PlanHistoryItem) StartDateTime() (time.Time, error) {
+	return dateTimeProperty(planHistoryItem.StartDate, "start_date")
+}
+
+func dateTimeProperty(dateTime *strfmt.DateTime, property string) (time.Time, error) {
+	if dateTime == nil {
+		return time.Time{}, fmt.Errorf("%s is missing", property)
+	}
+	return time.Time(*dateTime), nil
+}
+
+// LastOperation : The status of the last operation requested on a resource instance.
+type LastOperation struct {
+	// The operation, for example "create", "update" or "delete".
+	Type *string `json:"type,omitempty"`
+
+	// The state of the operation.
+	State *string `json:"state,omitempty"`
+
+	// Explanation of the state of the operation.
+	Description *string `json:"description,omitempty"`
+
+	// Whether the operation runs asynchronously.
+	Async *bool `json:"async,omitempty"`
+
+	// Whether the operation can be cancelled.
+	Cancelable *bool `json:"cancelable,omitempty"`
+
+	// Whether the resource controller polls the service broker for the state of the operation.
+	Poll *bool `json:"poll,omitempty"`
+
+	// When the operation was last updated.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// The last_operation property as returned by the service. Values of an unexpected format are only found here.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Constants associated with the LastOperation.State property.
+// The state of the operation.
+const (
+	LastOperation_State_Failed     = "failed"
+	LastOperation_State_InProgress = "in progress"
+	LastOperation_State_Succeeded  = "succeeded"
+)
+
+// ParsedLastOperation returns the last operation of the instance, or nil when the service did not return one.
+func (resourceInstance *ResourceInstance) ParsedLastOperation() *LastOperation {
+	if resourceInstance.LastOperation == nil {
+		return nil
+	}
+	return ParseLastOperation(resourceInstance.LastOperation)
+}
+
+// ParseLastOperation reads the last_operation property of a resource instance leniently: booleans may be strings,
+// timestamps may be in any format common.ParseTimestamp accepts or seconds since the epoch, and properties of an
+// unexpected type are left unset. Every property is kept as returned in Raw.
+func ParseLastOperation(lastOperation map[string]interface{}) *LastOperation {
+	parsed := &LastOperation{
+		Type:        stringProperty(lastOperation, "type"),
+		State:       stringProperty(lastOperation, "state"),
+		Description: stringProperty(lastOperation, "description"),
+		Async:       boolProperty(lastOperation, "async"),
+		Cancelable:  boolProperty(lastOperation, "cancelable"),
+		Poll:        boolProperty(lastOperation, "poll"),
+		Raw:         lastOperation,
+	}
+	switch updatedAt := lastOperation["updated_at"].(type) {
+	case string:
+		if timestamp, err := common.ParseTimestamp(updatedAt); err == nil {
+			parsed.UpdatedAt = &timestamp
+		}
+	case float64:
+		timestamp := time.Unix(int64(updatedAt), 0).UTC()
+		parsed.UpdatedAt = &timestamp
+	}
+	return parsed
+}
+
+// InProgress reports whether the operation has not finished yet.
+func (lastOperation *LastOperation) InProgress() bool {
+	return lastOperation.State != nil && strings.EqualFold(*lastOperation.State, LastOperation_State_InProgress)
+}
+
+func stringProperty(m map[string]interface{}, propertyName string) *string {
+	if value, isString := m[propertyName].(string); isString {
+		return &value
+	}
+	return nil
+}
+
+func boolProperty(m map[string]interface{}, propertyName string) *bool {
+	switch value := m[propertyName].(type) {
+	case bool:
+		return &value
+	case string:
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
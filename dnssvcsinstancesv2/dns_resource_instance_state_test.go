@@ -0,0 +1,87 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsinstancesv2_test
+
+import (
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsinstancesv2"
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`ResourceInstance state`, func() {
+	It(`Invoke StateValue, TypeValue and the time accessors successfully`, func() {
+		created := strfmt.DateTime(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+		resourceInstance := &dnssvcsinstancesv2.ResourceInstance{
+			State:     core.StringPtr("Active"),
+			Type:      core.StringPtr("service_instance"),
+			CreatedAt: &created,
+		}
+		Expect(resourceInstance.StateValue()).To(Equal(dnssvcsinstancesv2.ResourceInstance_State_Active))
+		Expect(resourceInstance.StateValue().IsKnown()).To(BeTrue())
+		Expect(resourceInstance.TypeValue()).To(Equal(dnssvcsinstancesv2.ResourceInstance_Type_ServiceInstance))
+		createdAt, err := resourceInstance.CreatedAtTime()
+		Expect(err).To(BeNil())
+		Expect(createdAt.Equal(time.Time(created))).To(BeTrue())
+		deletedAt, err := resourceInstance.DeletedAtTime()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("deleted_at is missing"))
+		Expect(deletedAt.IsZero()).To(BeTrue())
+
+		resourceInstance.State = core.StringPtr("migrating")
+		Expect(string(resourceInstance.StateValue())).To(Equal("migrating"))
+		Expect(resourceInstance.StateValue().IsKnown()).To(BeFalse())
+		Expect((&dnssvcsinstancesv2.ResourceInstance{}).StateValue()).To(BeEmpty())
+	})
+	It(`Invoke ParsedLastOperation successfully`, func() {
+		resourceInstance, err := dnssvcsinstancesv2.UnmarshalResourceInstance(map[string]interface{}{
+			"id": "ID",
+			"last_operation": map[string]interface{}{
+				"type":        "create",
+				"state":       "in progress",
+				"async":       "true",
+				"cancelable":  false,
+				"poll":        "sometimes",
+				"description": "Provisioning",
+				"updated_at":  "2019-01-01T12:00:00.000Z",
+				"reason_code": 42.0,
+			},
+		})
+		Expect(err).To(BeNil())
+		lastOperation := resourceInstance.ParsedLastOperation()
+		Expect(*lastOperation.Type).To(Equal("create"))
+		Expect(lastOperation.InProgress()).To(BeTrue())
+		Expect(*lastOperation.Async).To(BeTrue())
+		Expect(*lastOperation.Cancelable).To(BeFalse())
+		Expect(lastOperation.Poll).To(BeNil())
+		Expect(lastOperation.UpdatedAt.Equal(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))).To(BeTrue())
+		Expect(lastOperation.Raw["poll"]).To(Equal("sometimes"))
+		Expect(lastOperation.Raw["reason_code"]).To(Equal(42.0))
+
+		lastOperation = dnssvcsinstancesv2.ParseLastOperation(map[string]interface{}{"state": 1, "updated_at": 1546344000.0})
+		Expect(lastOperation.State).To(BeNil())
+		Expect(lastOperation.InProgress()).To(BeFalse())
+		Expect(lastOperation.UpdatedAt.Equal(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))).To(BeTrue())
+		lastOperation = dnssvcsinstancesv2.ParseLastOperation(map[string]interface{}{"updated_at": "soon"})
+		Expect(lastOperation.UpdatedAt).To(BeNil())
+
+		Expect((&dnssvcsinstancesv2.ResourceInstance{}).ParsedLastOperation()).To(BeNil())
+	})
+})
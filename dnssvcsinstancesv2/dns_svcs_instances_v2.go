@@ -19,6 +19,7 @@ package dnssvcsinstancesv2
 
 import (
 	"fmt"
+	"net/url"
 
 	common "github.com/IBM/dns-svcs-go-sdk/common"
 	"github.com/IBM/go-sdk-core/v3/core"
@@ -164,6 +165,9 @@ func (dnsSvcsInstances *DnsSvcsInstancesV2) ListResourceInstances(listResourceIn
 	if listResourceInstancesOptions.UpdatedTo != nil {
 		builder.AddQuery("updated_to", fmt.Sprint(*listResourceInstancesOptions.UpdatedTo))
 	}
+	if listResourceInstancesOptions.Start != nil {
+		builder.AddQuery("start", fmt.Sprint(*listResourceInstancesOptions.Start))
+	}
 
 	request, err := builder.Build()
 	if err != nil {
@@ -184,6 +188,34 @@ func (dnsSvcsInstances *DnsSvcsInstancesV2) ListResourceInstances(listResourceIn
 	return
 }
 
+// ListAllResourceInstances : Get a list of all resource instances, across pages
+// Get the resource instances matching the options, following the next page URL of every page from the given Start.
+// The Limit of the options sets the page size.
+func (dnsSvcsInstances *DnsSvcsInstancesV2) ListAllResourceInstances(listResourceInstancesOptions *ListResourceInstancesOptions) (resourceInstances []ResourceInstance, err error) {
+	err = core.ValidateNotNil(listResourceInstancesOptions, "listResourceInstancesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	pageOptions := *listResourceInstancesOptions
+	for {
+		result, _, listErr := dnsSvcsInstances.ListResourceInstances(&pageOptions)
+		if listErr != nil {
+			err = listErr
+			return
+		}
+		resourceInstances = append(resourceInstances, result.Resources...)
+		if result.NextURL == nil || *result.NextURL == "" || len(result.Resources) == 0 {
+			return
+		}
+		nextURL, parseErr := url.Parse(*result.NextURL)
+		if parseErr != nil || nextURL.Query().Get("start") == "" {
+			err = fmt.Errorf("the next page URL %q has no start parameter", *result.NextURL)
+			return
+		}
+		pageOptions.Start = core.StringPtr(nextURL.Query().Get("start"))
+	}
+}
+
 // CreateResourceInstance : Create (provision) a new resource instance
 // Provision a new resource in the specified location for the selected plan.
 func (dnsSvcsInstances *DnsSvcsInstancesV2) CreateResourceInstance(createResourceInstanceOptions *CreateResourceInstanceOptions) (result *ResourceInstance, response *core.DetailedResponse, err error) {
@@ -603,6 +635,9 @@ type ListResourceInstancesOptions struct {
 	// End date inclusive filter.
 	UpdatedTo *string `json:"updated_to,omitempty"`
 
+	// The token of the page to list, from the next_url of the previous page.
+	Start *string `json:"start,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
@@ -681,6 +716,12 @@ func (options *ListResourceInstancesOptions) SetUpdatedTo(updatedTo string) *Lis
 	return options
 }
 
+// SetStart : Allow user to set Start
+func (options *ListResourceInstancesOptions) SetStart(start string) *ListResourceInstancesOptions {
+	options.Start = core.StringPtr(start)
+	return options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *ListResourceInstancesOptions) SetHeaders(param map[string]string) *ListResourceInstancesOptions {
 	options.Headers = param
@@ -896,6 +937,10 @@ func UnmarshalResourceInstance(m map[string]interface{}) (result *ResourceInstan
 	if err != nil {
 		return
 	}
+	obj.LastOperation, err = core.UnmarshalObject(m, "last_operation")
+	if err != nil {
+		return
+	}
 	obj.DashboardURL, err = core.UnmarshalString(m, "dashboard_url")
 	if err != nil {
 		return
@@ -939,6 +984,23 @@ func UnmarshalResourceInstance(m map[string]interface{}) (result *ResourceInstan
 	return
 }
 
+// ParsedCrn returns the parsed CRN of the instance, or an error if it is missing or malformed.
+func (resourceInstance *ResourceInstance) ParsedCrn() (*common.Crn, error) {
+	if resourceInstance.Crn == nil {
+		return nil, fmt.Errorf("the resource instance has no CRN")
+	}
+	return common.ParseCrn(*resourceInstance.Crn)
+}
+
+// ParsedTargetCrn returns the parsed CRN of the deployment location of the instance, or an error if it is missing or
+// malformed.
+func (resourceInstance *ResourceInstance) ParsedTargetCrn() (*common.Crn, error) {
+	if resourceInstance.TargetCrn == nil {
+		return nil, fmt.Errorf("the resource instance has no target CRN")
+	}
+	return common.ParseCrn(*resourceInstance.TargetCrn)
+}
+
 // UnmarshalResourceInstanceSlice unmarshals a slice of ResourceInstance instances from the specified list of maps.
 func UnmarshalResourceInstanceSlice(s []interface{}) (slice []ResourceInstance, err error) {
 	for _, v := range s {
@@ -0,0 +1,320 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// DefaultBulkConcurrency is the number of requests a bulk operation keeps in flight when BulkOptions.Concurrency is
+// not set.
+const DefaultBulkConcurrency = 10
+
+// BulkOptions : Options controlling a bulk resource record operation.
+type BulkOptions struct {
+	// Maximum number of requests in flight, the default value is DefaultBulkConcurrency.
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// Stop starting new items after the first item fails. Items not started are reported as skipped.
+	FailFast bool `json:"fail_fast,omitempty"`
+
+	// Undo the items applied successfully when any item of the batch fails.
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// NewBulkOptions : Instantiate BulkOptions
+func (*DnsSvcsV1) NewBulkOptions() *BulkOptions {
+	return &BulkOptions{
+		Concurrency: DefaultBulkConcurrency,
+	}
+}
+
+// SetConcurrency : Allow user to set Concurrency
+func (options *BulkOptions) SetConcurrency(concurrency int) *BulkOptions {
+	options.Concurrency = concurrency
+	return options
+}
+
+// SetFailFast : Allow user to set FailFast
+func (options *BulkOptions) SetFailFast(failFast bool) *BulkOptions {
+	options.FailFast = failFast
+	return options
+}
+
+// SetRollback : Allow user to set Rollback
+func (options *BulkOptions) SetRollback(rollback bool) *BulkOptions {
+	options.Rollback = rollback
+	return options
+}
+
+// BulkResourceRecordResult : Outcome of one item of a bulk resource record operation.
+type BulkResourceRecordResult struct {
+	// Position of the item in the batch.
+	Index int
+
+	// The resource record created or updated, or the resource record deleted when rollback is enabled.
+	Record *ResourceRecord
+
+	// The response of the request applying the item.
+	Response *core.DetailedResponse
+
+	// The error applying the item.
+	Err error
+
+	// Whether the item was not started because the batch failed fast.
+	Skipped bool
+
+	// Whether the item was undone after the batch failed.
+	RolledBack bool
+
+	// The error undoing the item.
+	RollbackErr error
+}
+
+// BulkError : The error returned by a bulk operation in which some items failed.
+type BulkError struct {
+	// Number of items that failed.
+	Failed int
+
+	// Number of items that were not started.
+	Skipped int
+
+	// Number of items in the batch.
+	Total int
+
+	// The error of the first failed item.
+	First error
+}
+
+func (e *BulkError) Error() string {
+	msg := fmt.Sprintf("%d of %d items failed", e.Failed, e.Total)
+	if e.Skipped > 0 {
+		msg += fmt.Sprintf(", %d skipped", e.Skipped)
+	}
+	if e.First != nil {
+		msg += ": " + e.First.Error()
+	}
+	return msg
+}
+
+// BulkCreateResourceRecords : Create resource records
+// Create a batch of resource records concurrently. The results are in the order of the batch; the returned error is
+// a *BulkError when any item failed.
+func (dnsSvcs *DnsSvcsV1) BulkCreateResourceRecords(createResourceRecordOptions []*CreateResourceRecordOptions, bulkOptions *BulkOptions) (results []BulkResourceRecordResult, err error) {
+	apply := func(i int, result *BulkResourceRecordResult) {
+		result.Record, result.Response, result.Err = dnsSvcs.CreateResourceRecord(createResourceRecordOptions[i])
+	}
+	undo := func(i int, result *BulkResourceRecordResult) error {
+		options := createResourceRecordOptions[i]
+		_, err := dnsSvcs.DeleteResourceRecord(dnsSvcs.NewDeleteResourceRecordOptions(*options.InstanceID, *options.DnszoneID, *result.Record.ID))
+		return err
+	}
+	return runBulk(len(createResourceRecordOptions), bulkOptions, apply, undo)
+}
+
+// BulkUpdateResourceRecords : Update resource records
+// Update a batch of resource records concurrently. With rollback enabled each record is read before it is updated, so
+// that it can be restored. The results are in the order of the batch; the returned error is a *BulkError when any
+// item failed.
+func (dnsSvcs *DnsSvcsV1) BulkUpdateResourceRecords(updateResourceRecordOptions []*UpdateResourceRecordOptions, bulkOptions *BulkOptions) (results []BulkResourceRecordResult, err error) {
+	previous := make([]*ResourceRecord, len(updateResourceRecordOptions))
+	rollback := bulkOptions != nil && bulkOptions.Rollback
+	apply := func(i int, result *BulkResourceRecordResult) {
+		options := updateResourceRecordOptions[i]
+		if rollback && options != nil && options.InstanceID != nil && options.DnszoneID != nil && options.RecordID != nil {
+			previous[i], result.Response, result.Err = dnsSvcs.GetResourceRecord(dnsSvcs.NewGetResourceRecordOptions(*options.InstanceID, *options.DnszoneID, *options.RecordID))
+			if result.Err != nil {
+				return
+			}
+		}
+		result.Record, result.Response, result.Err = dnsSvcs.UpdateResourceRecord(options)
+	}
+	undo := func(i int, result *BulkResourceRecordResult) error {
+		options := updateResourceRecordOptions[i]
+		restoreOptions, err := newUpdateResourceRecordOptionsFromRecord(*options.InstanceID, *options.DnszoneID, previous[i])
+		if err != nil {
+			return err
+		}
+		_, _, err = dnsSvcs.UpdateResourceRecord(restoreOptions)
+		return err
+	}
+	return runBulk(len(updateResourceRecordOptions), bulkOptions, apply, undo)
+}
+
+// BulkDeleteResourceRecords : Delete resource records
+// Delete a batch of resource records concurrently. With rollback enabled each record is read before it is deleted and
+// reported in the result, so that it can be re-created under a new identifier. The results are in the order of the
+// batch; the returned error is a *BulkError when any item failed.
+func (dnsSvcs *DnsSvcsV1) BulkDeleteResourceRecords(deleteResourceRecordOptions []*DeleteResourceRecordOptions, bulkOptions *BulkOptions) (results []BulkResourceRecordResult, err error) {
+	rollback := bulkOptions != nil && bulkOptions.Rollback
+	apply := func(i int, result *BulkResourceRecordResult) {
+		options := deleteResourceRecordOptions[i]
+		if rollback && options != nil && options.InstanceID != nil && options.DnszoneID != nil && options.RecordID != nil {
+			result.Record, result.Response, result.Err = dnsSvcs.GetResourceRecord(dnsSvcs.NewGetResourceRecordOptions(*options.InstanceID, *options.DnszoneID, *options.RecordID))
+			if result.Err != nil {
+				return
+			}
+		}
+		result.Response, result.Err = dnsSvcs.DeleteResourceRecord(options)
+	}
+	undo := func(i int, result *BulkResourceRecordResult) error {
+		options := deleteResourceRecordOptions[i]
+		createOptions, err := newCreateResourceRecordOptionsFromRecord(*options.InstanceID, *options.DnszoneID, result.Record)
+		if err != nil {
+			return err
+		}
+		_, _, err = dnsSvcs.CreateResourceRecord(createOptions)
+		return err
+	}
+	return runBulk(len(deleteResourceRecordOptions), bulkOptions, apply, undo)
+}
+
+// runBulk applies the items of a batch with a bounded pool of workers, then undoes the applied items when the batch
+// failed and rollback is enabled.
+func runBulk(count int, bulkOptions *BulkOptions, apply func(int, *BulkResourceRecordResult), undo func(int, *BulkResourceRecordResult) error) (results []BulkResourceRecordResult, err error) {
+	if bulkOptions == nil {
+		bulkOptions = &BulkOptions{}
+	}
+	concurrency := bulkOptions.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	results = make([]BulkResourceRecordResult, count)
+	var mutex sync.Mutex
+	failed := false
+	indexes := make(chan int)
+	var workers sync.WaitGroup
+	for w := 0; w < concurrency && w < count; w++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for i := range indexes {
+				result := &results[i]
+				result.Index = i
+				mutex.Lock()
+				skip := failed && bulkOptions.FailFast
+				mutex.Unlock()
+				if skip {
+					result.Skipped = true
+					continue
+				}
+				apply(i, result)
+				if result.Err != nil {
+					mutex.Lock()
+					failed = true
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	workers.Wait()
+
+	if !failed {
+		return
+	}
+	bulkErr := &BulkError{Total: count}
+	for i := range results {
+		switch {
+		case results[i].Skipped:
+			bulkErr.Skipped++
+		case results[i].Err != nil:
+			bulkErr.Failed++
+			if bulkErr.First == nil {
+				bulkErr.First = results[i].Err
+			}
+		}
+	}
+	err = bulkErr
+	if !bulkOptions.Rollback {
+		return
+	}
+	for i := range results {
+		if results[i].Skipped || results[i].Err != nil {
+			continue
+		}
+		results[i].RollbackErr = undo(i, &results[i])
+		results[i].RolledBack = results[i].RollbackErr == nil
+	}
+	return
+}
+
+// newCreateResourceRecordOptionsFromRecord returns the options that create a copy of a resource record in a DNS zone.
+func newCreateResourceRecordOptionsFromRecord(instanceID string, dnszoneID string, record *ResourceRecord) (createOptions *CreateResourceRecordOptions, err error) {
+	if record == nil || record.Type == nil {
+		err = fmt.Errorf("resource record has no type")
+		return
+	}
+	var rdata *ResourceRecordInputRdata
+	err = record.GetRdataModel(UnmarshalResourceRecordInputRdata, &rdata)
+	if err != nil {
+		return
+	}
+	createOptions = &CreateResourceRecordOptions{
+		InstanceID: core.StringPtr(instanceID),
+		DnszoneID:  core.StringPtr(dnszoneID),
+		Name:       relativeRecordName(record),
+		Type:       record.Type,
+		Rdata:      rdata,
+		TTL:        record.TTL,
+		Service:    record.Service,
+		Protocol:   record.Protocol,
+	}
+	return
+}
+
+// newUpdateResourceRecordOptionsFromRecord returns the options that restore a resource record to the given state.
+func newUpdateResourceRecordOptionsFromRecord(instanceID string, dnszoneID string, record *ResourceRecord) (updateOptions *UpdateResourceRecordOptions, err error) {
+	if record == nil || record.ID == nil {
+		err = fmt.Errorf("resource record has no identifier")
+		return
+	}
+	var rdata *ResourceRecordUpdateInputRdata
+	err = record.GetRdataModel(UnmarshalResourceRecordUpdateInputRdata, &rdata)
+	if err != nil {
+		return
+	}
+	updateOptions = &UpdateResourceRecordOptions{
+		InstanceID: core.StringPtr(instanceID),
+		DnszoneID:  core.StringPtr(dnszoneID),
+		RecordID:   record.ID,
+		Name:       relativeRecordName(record),
+		Rdata:      rdata,
+		TTL:        record.TTL,
+		Service:    record.Service,
+		Protocol:   record.Protocol,
+	}
+	return
+}
+
+// relativeRecordName returns the name to create a resource record under. The service prefixes the name of an SRV
+// record with its service and protocol, which are passed separately on creation.
+func relativeRecordName(record *ResourceRecord) *string {
+	if record.Name == nil || record.Type == nil || *record.Type != ResourceRecord_Type_Srv || record.Service == nil || record.Protocol == nil {
+		return record.Name
+	}
+	prefix := *record.Service + "._" + strings.TrimPrefix(*record.Protocol, "_") + "."
+	return core.StringPtr(strings.TrimPrefix(*record.Name, prefix))
+}
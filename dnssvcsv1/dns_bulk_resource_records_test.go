@@ -0,0 +1,121 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Bulk resource record operations`, func() {
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	zoneRecords := "/instances/instance/dnszones/zone/resource_records"
+	badZoneRecords := "/instances/instance/dnszones/bad/resource_records"
+	newCreateOptions := func(zoneID string, name string) *dnssvcsv1.CreateResourceRecordOptions {
+		rdata, _ := testService.NewResourceRecordInputRdataRdataARecord("10.0.0.1")
+		return testService.NewCreateResourceRecordOptions("instance", zoneID).SetName(name).SetType(dnssvcsv1.CreateResourceRecordOptions_Type_A).SetRdata(rdata)
+	}
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		fake.Failures["POST "+badZoneRecords] = http.StatusBadRequest
+		testService = fake.Service()
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke BulkCreateResourceRecords successfully`, func() {
+		batch := []*dnssvcsv1.CreateResourceRecordOptions{}
+		for i := 0; i < 25; i++ {
+			batch = append(batch, newCreateOptions("zone", fmt.Sprintf("host%02d", i)))
+		}
+		results, err := testService.BulkCreateResourceRecords(batch, testService.NewBulkOptions().SetConcurrency(4))
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(25))
+		for i, result := range results {
+			Expect(result.Index).To(Equal(i))
+			Expect(result.Err).To(BeNil())
+			Expect(result.Response.StatusCode).To(Equal(http.StatusOK))
+			Expect(*result.Record.Name).To(Equal(fmt.Sprintf("host%02d.example.com", i)))
+		}
+		Expect(fake.List(zoneRecords)).To(HaveLen(25))
+	})
+	It(`Invoke BulkCreateResourceRecords with error: continue on error`, func() {
+		batch := []*dnssvcsv1.CreateResourceRecordOptions{newCreateOptions("zone", "a"), newCreateOptions("bad", "b"), newCreateOptions("zone", "c")}
+		results, err := testService.BulkCreateResourceRecords(batch, nil)
+		Expect(err).ToNot(BeNil())
+		bulkErr, ok := err.(*dnssvcsv1.BulkError)
+		Expect(ok).To(BeTrue())
+		Expect(bulkErr.Failed).To(Equal(1))
+		Expect(bulkErr.Total).To(Equal(3))
+		Expect(results[1].Err).ToNot(BeNil())
+		Expect(fake.SortedNames(zoneRecords)).To(Equal([]string{"a.example.com", "c.example.com"}))
+	})
+	It(`Invoke BulkCreateResourceRecords with error: fail fast and rollback`, func() {
+		batch := []*dnssvcsv1.CreateResourceRecordOptions{newCreateOptions("zone", "a"), newCreateOptions("bad", "b"), newCreateOptions("zone", "c")}
+		results, err := testService.BulkCreateResourceRecords(batch, testService.NewBulkOptions().SetConcurrency(1).SetFailFast(true).SetRollback(true))
+		Expect(err).ToNot(BeNil())
+		Expect(err.(*dnssvcsv1.BulkError).Skipped).To(Equal(1))
+		Expect(results[0].RolledBack).To(BeTrue())
+		Expect(results[2].Skipped).To(BeTrue())
+		Expect(fake.List(zoneRecords)).To(BeEmpty())
+	})
+	It(`Invoke BulkUpdateResourceRecords and BulkDeleteResourceRecords with rollback`, func() {
+		first := fake.Add(zoneRecords, map[string]interface{}{"name": "a.example.com", "type": "A", "ttl": 300, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		second := fake.Add(zoneRecords, map[string]interface{}{"name": "b.example.com", "type": "A", "ttl": 300, "rdata": map[string]interface{}{"ip": "10.0.0.2"}})
+		fake.Failures["PUT "+zoneRecords+"/"+second] = http.StatusConflict
+		fake.Failures["DELETE "+zoneRecords+"/"+second] = http.StatusConflict
+
+		updates := []*dnssvcsv1.UpdateResourceRecordOptions{
+			testService.NewUpdateResourceRecordOptions("instance", "zone", first).SetTTL(60),
+			testService.NewUpdateResourceRecordOptions("instance", "zone", second).SetTTL(60),
+		}
+		results, err := testService.BulkUpdateResourceRecords(updates, testService.NewBulkOptions().SetRollback(true))
+		Expect(err).ToNot(BeNil())
+		Expect(results[0].RolledBack).To(BeTrue())
+		Expect(fake.List(zoneRecords)[0]["ttl"]).To(BeNumerically("==", 300))
+
+		deletes := []*dnssvcsv1.DeleteResourceRecordOptions{
+			testService.NewDeleteResourceRecordOptions("instance", "zone", first),
+			testService.NewDeleteResourceRecordOptions("instance", "zone", second),
+		}
+		results, err = testService.BulkDeleteResourceRecords(deletes, testService.NewBulkOptions().SetRollback(true))
+		Expect(err).ToNot(BeNil())
+		Expect(*results[0].Record.Name).To(Equal("a.example.com"))
+		Expect(results[0].RolledBack).To(BeTrue())
+		Expect(fake.SortedNames(zoneRecords)).To(Equal([]string{"a.example.com", "b.example.com"}))
+	})
+	It(`Invoke BulkDeleteResourceRecords without reading the records`, func() {
+		first := fake.Add(zoneRecords, map[string]interface{}{"name": "a.example.com", "type": "A", "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		second := fake.Add(zoneRecords, map[string]interface{}{"name": "b.example.com", "type": "A", "rdata": map[string]interface{}{"ip": "10.0.0.2"}})
+		deletes := []*dnssvcsv1.DeleteResourceRecordOptions{
+			testService.NewDeleteResourceRecordOptions("instance", "zone", first),
+			testService.NewDeleteResourceRecordOptions("instance", "zone", second),
+		}
+		results, err := testService.BulkDeleteResourceRecords(deletes, nil)
+		Expect(err).To(BeNil())
+		Expect(results[0].Record).To(BeNil())
+		Expect(fake.List(zoneRecords)).To(BeEmpty())
+		Expect(fake.Requests).To(ConsistOf("DELETE "+zoneRecords+"/"+first, "DELETE "+zoneRecords+"/"+second))
+	})
+})
@@ -0,0 +1,325 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// DriftReportOptions : The DriftReport options.
+type DriftReportOptions struct {
+	// The unique identifier of a service instance.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The desired state: one zone file per managed DNS zone, matched to the DNS zones of the instance by origin.
+	ZoneFiles []*ZoneFile `json:"zone_files" validate:"required"`
+
+	// Leave the DNS zones of the instance without a zone file out of the report, instead of reporting them as
+	// unmanaged.
+	IgnoreUnmanaged *bool `json:"ignore_unmanaged,omitempty"`
+}
+
+// NewDriftReportOptions : Instantiate DriftReportOptions
+func (*DnsSvcsV1) NewDriftReportOptions(instanceID string, zoneFiles []*ZoneFile) *DriftReportOptions {
+	return &DriftReportOptions{
+		InstanceID: core.StringPtr(instanceID),
+		ZoneFiles:  zoneFiles,
+	}
+}
+
+// SetIgnoreUnmanaged : Allow user to set IgnoreUnmanaged
+func (options *DriftReportOptions) SetIgnoreUnmanaged(ignoreUnmanaged bool) *DriftReportOptions {
+	options.IgnoreUnmanaged = core.BoolPtr(ignoreUnmanaged)
+	return options
+}
+
+// DriftReport : The differences between the DNS zones of an instance and their desired state.
+type DriftReport struct {
+	// The unique identifier of the service instance.
+	InstanceID string `json:"instance_id"`
+
+	// When the DNS zones were read.
+	CheckedAt time.Time `json:"checked_at"`
+
+	// The DNS zones, sorted by name.
+	Zones []ZoneDriftReport `json:"zones"`
+}
+
+// ZoneDriftReport : The differences between a DNS zone and its zone file.
+type ZoneDriftReport struct {
+	// Name of the DNS zone.
+	Name string `json:"name"`
+
+	// The unique identifier of the DNS zone, empty when it is missing.
+	DnszoneID string `json:"dnszone_id,omitempty"`
+
+	// Status of the DNS zone.
+	Status string `json:"status"`
+
+	// Number of resource records of the zone file that match the DNS zone.
+	InSync int `json:"in_sync"`
+
+	// Resource records of the zone file missing from the DNS zone.
+	Missing []ZoneFileRecord `json:"missing,omitempty"`
+
+	// Resource records of the DNS zone the zone file does not list.
+	Extra []ZoneFileRecord `json:"extra,omitempty"`
+
+	// Resource records whose TTL or rdata differ.
+	Changed []ZoneRecordChange `json:"changed,omitempty"`
+
+	// Why the DNS zone could not be compared, for the error status.
+	Error string `json:"error,omitempty"`
+}
+
+// Constants associated with the ZoneDriftReport.Status property.
+// Status of the DNS zone.
+const (
+	ZoneDriftReport_Status_InSync    = "in_sync"
+	ZoneDriftReport_Status_Drifted   = "drifted"
+	ZoneDriftReport_Status_Missing   = "missing"
+	ZoneDriftReport_Status_Unmanaged = "unmanaged"
+	ZoneDriftReport_Status_Error     = "error"
+)
+
+// DriftReport : Report the drift of the DNS zones of an instance
+// Compare every DNS zone of an instance and its resource records with the zone file of the same name. DNS zones with
+// a zone file are in sync or drifted, zone files without a DNS zone are missing and DNS zones without a zone file are
+// unmanaged. A DNS zone whose resource records cannot be read is reported with the error status rather than failing
+// the whole report; an error is only returned when the DNS zones cannot be listed.
+func (dnsSvcs *DnsSvcsV1) DriftReport(driftReportOptions *DriftReportOptions) (report *DriftReport, err error) {
+	err = core.ValidateNotNil(driftReportOptions, "driftReportOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(driftReportOptions, "driftReportOptions")
+	if err != nil {
+		return
+	}
+	instanceID := *driftReportOptions.InstanceID
+	desired := make(map[string]*ZoneFile)
+	for _, zoneFile := range driftReportOptions.ZoneFiles {
+		origin := normalizeDomainName(zoneFile.Origin)
+		if origin == "" {
+			return nil, fmt.Errorf("a zone file has no origin")
+		}
+		if desired[origin] != nil {
+			return nil, fmt.Errorf("two zone files have the origin %s", origin)
+		}
+		desired[origin] = zoneFile
+	}
+
+	checkedAt := time.Now().UTC()
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID))
+	if err != nil {
+		return
+	}
+	report = &DriftReport{InstanceID: instanceID, CheckedAt: checkedAt, Zones: []ZoneDriftReport{}}
+	for _, dnszone := range dnszones {
+		name := normalizeDomainName(stringValue(dnszone.Name, ""))
+		zone := ZoneDriftReport{Name: name, DnszoneID: stringValue(dnszone.ID, "")}
+		zoneFile := desired[name]
+		delete(desired, name)
+		if zoneFile == nil {
+			if driftReportOptions.IgnoreUnmanaged == nil || !*driftReportOptions.IgnoreUnmanaged {
+				zone.Status = ZoneDriftReport_Status_Unmanaged
+				report.Zones = append(report.Zones, zone)
+			}
+			continue
+		}
+		records, listErr := dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(instanceID, zone.DnszoneID))
+		var live *ZoneFile
+		if listErr == nil {
+			live, listErr = NewZoneFile(name, records)
+		}
+		if listErr != nil {
+			zone.Status = ZoneDriftReport_Status_Error
+			zone.Error = listErr.Error()
+			report.Zones = append(report.Zones, zone)
+			continue
+		}
+		diff := DiffZoneFiles(zoneFile, live)
+		zone.Missing, zone.Extra, zone.Changed = diff.Missing, diff.Extra, diff.Changed
+		zone.InSync = len(zoneFile.Records) - len(diff.Missing) - len(diff.Changed)
+		zone.Status = ZoneDriftReport_Status_InSync
+		if diff.HasDrift() {
+			zone.Status = ZoneDriftReport_Status_Drifted
+		}
+		report.Zones = append(report.Zones, zone)
+	}
+	for name, zoneFile := range desired {
+		report.Zones = append(report.Zones, ZoneDriftReport{
+			Name:    name,
+			Status:  ZoneDriftReport_Status_Missing,
+			Missing: DiffZoneFiles(zoneFile, &ZoneFile{Origin: name}).Missing,
+		})
+	}
+	sort.Slice(report.Zones, func(i, j int) bool {
+		return report.Zones[i].Name < report.Zones[j].Name
+	})
+	return
+}
+
+// HasDrift reports whether any DNS zone is drifted, missing or could not be compared. Unmanaged DNS zones are not
+// drift.
+func (report *DriftReport) HasDrift() bool {
+	for _, zone := range report.Zones {
+		switch zone.Status {
+		case ZoneDriftReport_Status_Drifted, ZoneDriftReport_Status_Missing, ZoneDriftReport_Status_Error:
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the report as indented JSON.
+func (report *DriftReport) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// junitTestSuites and the types below are the subset of the JUnit XML format read by CI servers.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML: a test suite per DNS zone with a failing test case per missing, extra
+// or changed resource record. DNS zones in sync have a single passing test case, unmanaged ones a skipped one and
+// those that could not be compared an error.
+func (report *DriftReport) WriteJUnit(w io.Writer) error {
+	suites := junitTestSuites{Name: "dns drift " + report.InstanceID}
+	for _, zone := range report.Zones {
+		suite := junitTestSuite{Name: zone.Name, Timestamp: report.CheckedAt.Format("2006-01-02T15:04:05")}
+		addCase := func(name string, failure *junitMessage) {
+			suite.Cases = append(suite.Cases, junitTestCase{Name: name, ClassName: zone.Name, Failure: failure})
+			if failure != nil {
+				suite.Failures++
+			}
+		}
+		switch zone.Status {
+		case ZoneDriftReport_Status_InSync:
+			addCase(fmt.Sprintf("%d resource records in sync", zone.InSync), nil)
+		case ZoneDriftReport_Status_Unmanaged:
+			suite.Cases = append(suite.Cases, junitTestCase{Name: "unmanaged", ClassName: zone.Name, Skipped: &junitMessage{Message: "no zone file"}})
+			suite.Skipped++
+		case ZoneDriftReport_Status_Error:
+			suite.Cases = append(suite.Cases, junitTestCase{Name: "resource records", ClassName: zone.Name, Error: &junitMessage{Message: zone.Error}})
+			suite.Errors++
+		}
+		for _, record := range zone.Missing {
+			addCase(record.Type+" "+record.Name, &junitMessage{Message: "missing", Type: "missing", Text: record.String()})
+		}
+		for _, record := range zone.Extra {
+			addCase(record.Type+" "+record.Name, &junitMessage{Message: "extra", Type: "extra", Text: record.String()})
+		}
+		for _, change := range zone.Changed {
+			addCase(change.Desired.Type+" "+change.Desired.Name, &junitMessage{
+				Message: "changed " + strings.Join(change.Properties, ", "),
+				Type:    "changed",
+				Text:    "desired: " + change.Desired.String() + "\nlive: " + change.Live.String(),
+			})
+		}
+		suite.Tests = len(suite.Cases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, suite)
+	}
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header+string(data)+"\n")
+	return err
+}
+
+// LoadZoneFiles reads the zone files of a directory: files ending in .json, .yaml or .yml in those formats and files
+// ending in .zone or .db in BIND form. Zone files without an origin take their name without the extension, for
+// example "example.com.zone".
+func LoadZoneFiles(dir string) (zoneFiles []*ZoneFile, err error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	formats := map[string]string{
+		".json": ZoneFileFormat_Json,
+		".yaml": ZoneFileFormat_Yaml,
+		".yml":  ZoneFileFormat_Yaml,
+		".zone": ZoneFileFormat_Bind,
+		".db":   ZoneFileFormat_Bind,
+	}
+	for _, entry := range entries {
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		format, found := formats[extension]
+		if entry.IsDir() || !found {
+			continue
+		}
+		data, readErr := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if readErr != nil {
+			return nil, readErr
+		}
+		zoneFile, parseErr := ParseZoneFile(data, format, strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s: %s", entry.Name(), parseErr.Error())
+		}
+		zoneFiles = append(zoneFiles, zoneFile)
+	}
+	return
+}
@@ -0,0 +1,137 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Drift report`, func() {
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	var zoneFiles []*dnssvcsv1.ZoneFile
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "com", "name": "example.com"})
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "org", "name": "example.org"})
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "net", "name": "example.net"})
+		fake.Add("/instances/instance/dnszones/com/resource_records", map[string]interface{}{"id": "www", "type": "A", "name": "www.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		fake.Add("/instances/instance/dnszones/org/resource_records", map[string]interface{}{"id": "www", "type": "A", "name": "www.example.org", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		fake.Add("/instances/instance/dnszones/org/resource_records", map[string]interface{}{"id": "old", "type": "TXT", "name": "old.example.org", "ttl": 900, "rdata": map[string]interface{}{"text": "old"}})
+		zoneFiles = nil
+		for origin, data := range map[string]string{
+			"example.com": "$TTL 900\nwww A 10.0.0.1\n",
+			"example.org": "$TTL 900\nwww 60 A 10.0.0.1\napi A 10.0.0.2\n",
+			"example.io":  "www A 10.0.0.1\n",
+		} {
+			zoneFile, err := dnssvcsv1.ParseZoneFile([]byte(data), dnssvcsv1.ZoneFileFormat_Bind, origin)
+			Expect(err).To(BeNil())
+			zoneFiles = append(zoneFiles, zoneFile)
+		}
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke DriftReport successfully`, func() {
+		report, err := testService.DriftReport(testService.NewDriftReportOptions("instance", zoneFiles))
+		Expect(err).To(BeNil())
+		Expect(report.HasDrift()).To(BeTrue())
+		Expect(report.Zones).To(HaveLen(4))
+
+		com, io, net, org := report.Zones[0], report.Zones[1], report.Zones[2], report.Zones[3]
+		Expect(com.Status).To(Equal(dnssvcsv1.ZoneDriftReport_Status_InSync))
+		Expect(com.InSync).To(Equal(1))
+		Expect(io.Status).To(Equal(dnssvcsv1.ZoneDriftReport_Status_Missing))
+		Expect(io.Missing).To(HaveLen(1))
+		Expect(net.Status).To(Equal(dnssvcsv1.ZoneDriftReport_Status_Unmanaged))
+		Expect(org.Status).To(Equal(dnssvcsv1.ZoneDriftReport_Status_Drifted))
+		Expect(org.DnszoneID).To(Equal("org"))
+		Expect(org.Missing[0].Name).To(Equal("api.example.org"))
+		Expect(org.Extra[0].Name).To(Equal("old.example.org"))
+		Expect(org.Changed[0].Properties).To(Equal([]string{"ttl"}))
+
+		var buffer bytes.Buffer
+		Expect(report.WriteJSON(&buffer)).To(BeNil())
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(buffer.Bytes(), &decoded)).To(BeNil())
+		Expect(decoded["instance_id"]).To(Equal("instance"))
+		Expect(decoded["zones"]).To(HaveLen(4))
+
+		buffer.Reset()
+		Expect(report.WriteJUnit(&buffer)).To(BeNil())
+		junit := buffer.String()
+		Expect(junit).To(ContainSubstring(`<testsuites name="dns drift instance" tests="6" failures="4" errors="0">`))
+		Expect(junit).To(ContainSubstring(`<testcase name="1 resource records in sync" classname="example.com"></testcase>`))
+		Expect(junit).To(ContainSubstring(`<skipped message="no zone file"></skipped>`))
+		Expect(junit).To(ContainSubstring(`<failure message="changed ttl" type="changed">`))
+
+		report, err = testService.DriftReport(testService.NewDriftReportOptions("instance", zoneFiles[:0]).SetIgnoreUnmanaged(true))
+		Expect(err).To(BeNil())
+		Expect(report.Zones).To(BeEmpty())
+		Expect(report.HasDrift()).To(BeFalse())
+	})
+	It(`Invoke DriftReport with a failing DNS zone`, func() {
+		fake.Failures["GET /instances/instance/dnszones/org/resource_records"] = 500
+		report, err := testService.DriftReport(testService.NewDriftReportOptions("instance", zoneFiles).SetIgnoreUnmanaged(true))
+		Expect(err).To(BeNil())
+		Expect(report.Zones).To(HaveLen(3))
+		Expect(report.Zones[2].Status).To(Equal(dnssvcsv1.ZoneDriftReport_Status_Error))
+		Expect(report.Zones[2].Error).ToNot(BeEmpty())
+
+		var buffer bytes.Buffer
+		Expect(report.WriteJUnit(&buffer)).To(BeNil())
+		Expect(buffer.String()).To(ContainSubstring(`errors="1"`))
+
+		fake.Failures["GET /instances/instance/dnszones"] = 500
+		_, err = testService.DriftReport(testService.NewDriftReportOptions("instance", zoneFiles))
+		Expect(err).ToNot(BeNil())
+		_, err = testService.DriftReport(testService.NewDriftReportOptions("instance", append(zoneFiles, zoneFiles[0])))
+		Expect(err).ToNot(BeNil())
+		_, err = testService.DriftReport(nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke LoadZoneFiles successfully`, func() {
+		dir, err := ioutil.TempDir("", "zones")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		Expect(ioutil.WriteFile(filepath.Join(dir, "example.com.zone"), []byte("www A 10.0.0.1\n"), 0600)).To(BeNil())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "other.json"), []byte(`{"origin": "example.org", "records": []}`), 0600)).To(BeNil())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# Zones\n"), 0600)).To(BeNil())
+
+		zoneFiles, err := dnssvcsv1.LoadZoneFiles(dir)
+		Expect(err).To(BeNil())
+		Expect(zoneFiles).To(HaveLen(2))
+		Expect(zoneFiles[0].Origin).To(Equal("example.com"))
+		Expect(zoneFiles[0].Records[0].Name).To(Equal("www.example.com"))
+		Expect(zoneFiles[1].Origin).To(Equal("example.org"))
+
+		Expect(ioutil.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("records: [\n"), 0600)).To(BeNil())
+		_, err = dnssvcsv1.LoadZoneFiles(dir)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("broken.yaml"))
+	})
+})
@@ -0,0 +1,213 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// FailoverSimulation : The conditions a load balancer failover is simulated under.
+type FailoverSimulation struct {
+	// Origins to treat as down, by origin name or address.
+	FailedOrigins []string `json:"failed_origins,omitempty"`
+
+	// The availability zone the query comes from. When empty, or when the load balancer maps no pools to it, routing
+	// starts at the default pools.
+	AvailabilityZone string `json:"availability_zone,omitempty"`
+
+	// Also treat origins as down when their reported health is unhealthy.
+	UseReportedHealth bool `json:"use_reported_health,omitempty"`
+}
+
+// FailoverResult : The outcome of a load balancer failover simulation.
+type FailoverResult struct {
+	// The pool serving traffic, nil when no pool can serve it.
+	Pool *Pool
+
+	// The origins of the pool traffic is balanced across.
+	Origins []Origin
+
+	// How the pool was chosen, one step per pool considered.
+	Steps []FailoverStep
+}
+
+// FailoverStep : A pool considered by a load balancer failover simulation.
+type FailoverStep struct {
+	// Which list of the load balancer the pool comes from.
+	Stage string
+
+	// Identifier of the pool.
+	PoolID string
+
+	// Whether the pool serves traffic.
+	Selected bool
+
+	// Explanation of the decision.
+	Message string
+}
+
+// Constants associated with the FailoverStep.Stage property.
+// Which list of the load balancer the pool comes from.
+const (
+	FailoverStep_Stage_AzPools      = "az_pools"
+	FailoverStep_Stage_DefaultPools = "default_pools"
+	FailoverStep_Stage_FallbackPool = "fallback_pool"
+	FailoverStep_Stage_LoadBalancer = "load_balancer"
+)
+
+// String returns the explanation of the steps, one per line.
+func (result *FailoverResult) String() string {
+	lines := make([]string, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		lines = append(lines, fmt.Sprintf("[%s] %s", step.Stage, step.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SimulateFailover computes which pool and origins of a load balancer would serve traffic under simulated origin
+// failures. The pools mapped to the availability zone are tried first, then the default pools in priority order;
+// the first enabled pool with at least HealthyOriginsThreshold healthy origins serves traffic. When none does, the
+// fallback pool serves traffic whatever its health.
+func SimulateFailover(loadBalancer *LoadBalancer, pools []Pool, simulation *FailoverSimulation) (result *FailoverResult, err error) {
+	err = core.ValidateNotNil(loadBalancer, "loadBalancer cannot be nil")
+	if err != nil {
+		return
+	}
+	if simulation == nil {
+		simulation = &FailoverSimulation{}
+	}
+	result = &FailoverResult{Steps: []FailoverStep{}}
+	if loadBalancer.Enabled != nil && !*loadBalancer.Enabled {
+		result.Steps = append(result.Steps, FailoverStep{Stage: FailoverStep_Stage_LoadBalancer, Message: "the load balancer is disabled and serves no traffic"})
+		return
+	}
+	poolsByID := make(map[string]*Pool)
+	for i := range pools {
+		if pools[i].ID != nil {
+			poolsByID[*pools[i].ID] = &pools[i]
+		}
+	}
+	failed := make(map[string]bool)
+	for _, origin := range simulation.FailedOrigins {
+		failed[strings.ToLower(origin)] = true
+	}
+
+	if simulation.AvailabilityZone != "" {
+		var azPools []string
+		for _, item := range loadBalancer.AzPools {
+			if item.AvailabilityZone != nil && strings.EqualFold(*item.AvailabilityZone, simulation.AvailabilityZone) {
+				azPools = item.Pools
+			}
+		}
+		if len(azPools) == 0 {
+			result.Steps = append(result.Steps, FailoverStep{Stage: FailoverStep_Stage_AzPools, Message: fmt.Sprintf("no pools are mapped to availability zone %s", simulation.AvailabilityZone)})
+		}
+		if result.tryPools(FailoverStep_Stage_AzPools, azPools, poolsByID, failed, simulation.UseReportedHealth) {
+			return
+		}
+	}
+	if result.tryPools(FailoverStep_Stage_DefaultPools, loadBalancer.DefaultPools, poolsByID, failed, simulation.UseReportedHealth) {
+		return
+	}
+
+	step := FailoverStep{Stage: FailoverStep_Stage_FallbackPool}
+	if loadBalancer.FallbackPool == nil {
+		step.Message = "no pool is healthy and the load balancer has no fallback pool"
+		result.Steps = append(result.Steps, step)
+		return
+	}
+	step.PoolID = *loadBalancer.FallbackPool
+	pool := poolsByID[step.PoolID]
+	if pool == nil {
+		step.Message = fmt.Sprintf("no pool is healthy and fallback pool %s does not exist", step.PoolID)
+		result.Steps = append(result.Steps, step)
+		return
+	}
+	healthy, enabled, threshold := evaluatePool(pool, failed, simulation.UseReportedHealth)
+	step.Selected = true
+	result.Pool = pool
+	if len(healthy) >= threshold {
+		result.Origins = healthy
+		step.Message = fmt.Sprintf("no other pool is healthy, fallback pool %s serves traffic with %d of %d origins healthy", poolLabel(pool), len(healthy), len(pool.Origins))
+	} else {
+		result.Origins = enabled
+		if len(healthy) > 0 {
+			result.Origins = healthy
+		}
+		step.Message = fmt.Sprintf("no other pool is healthy, fallback pool %s serves traffic although only %d of %d origins are healthy (threshold %d)", poolLabel(pool), len(healthy), len(pool.Origins), threshold)
+	}
+	result.Steps = append(result.Steps, step)
+	return
+}
+
+// tryPools records a step for each pool in turn until one can serve traffic, and reports whether one was found.
+func (result *FailoverResult) tryPools(stage string, poolIDs []string, poolsByID map[string]*Pool, failed map[string]bool, useReportedHealth bool) bool {
+	for _, poolID := range poolIDs {
+		step := FailoverStep{Stage: stage, PoolID: poolID}
+		pool := poolsByID[poolID]
+		switch {
+		case pool == nil:
+			step.Message = fmt.Sprintf("pool %s does not exist, skipped", poolID)
+		case pool.Enabled != nil && !*pool.Enabled:
+			step.Message = fmt.Sprintf("pool %s is disabled, skipped", poolLabel(pool))
+		default:
+			healthy, _, threshold := evaluatePool(pool, failed, useReportedHealth)
+			if len(healthy) < threshold {
+				step.Message = fmt.Sprintf("pool %s is unhealthy: %d of %d origins healthy, threshold %d", poolLabel(pool), len(healthy), len(pool.Origins), threshold)
+				break
+			}
+			step.Selected = true
+			step.Message = fmt.Sprintf("pool %s serves traffic: %d of %d origins healthy, threshold %d", poolLabel(pool), len(healthy), len(pool.Origins), threshold)
+			result.Pool = pool
+			result.Origins = healthy
+		}
+		result.Steps = append(result.Steps, step)
+		if step.Selected {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluatePool returns the healthy and the enabled origins of a pool, and its healthy origins threshold.
+func evaluatePool(pool *Pool, failed map[string]bool, useReportedHealth bool) (healthy []Origin, enabled []Origin, threshold int) {
+	threshold = int(int64Value(pool.HealthyOriginsThreshold, 1))
+	for _, origin := range pool.Origins {
+		if origin.Enabled != nil && !*origin.Enabled {
+			continue
+		}
+		enabled = append(enabled, origin)
+		if (origin.Name != nil && failed[strings.ToLower(*origin.Name)]) || (origin.Address != nil && failed[strings.ToLower(*origin.Address)]) {
+			continue
+		}
+		if useReportedHealth && origin.Health != nil && !*origin.Health {
+			continue
+		}
+		healthy = append(healthy, origin)
+	}
+	return
+}
+
+func poolLabel(pool *Pool) string {
+	if pool.Name != nil && *pool.Name != "" {
+		return *pool.Name
+	}
+	return stringValue(pool.ID, "")
+}
@@ -0,0 +1,88 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SimulateFailover(loadBalancer *LoadBalancer, pools []Pool, simulation *FailoverSimulation)`, func() {
+	newOrigin := func(name string, address string, enabled bool) dnssvcsv1.Origin {
+		return dnssvcsv1.Origin{Name: core.StringPtr(name), Address: core.StringPtr(address), Enabled: core.BoolPtr(enabled), Health: core.BoolPtr(true)}
+	}
+	var loadBalancer *dnssvcsv1.LoadBalancer
+	var pools []dnssvcsv1.Pool
+	BeforeEach(func() {
+		loadBalancer = &dnssvcsv1.LoadBalancer{
+			Enabled:      core.BoolPtr(true),
+			DefaultPools: []string{"east", "west"},
+			FallbackPool: core.StringPtr("backup"),
+			AzPools:      []dnssvcsv1.LoadBalancerAzPoolsItem{{AvailabilityZone: core.StringPtr("us-south-1"), Pools: []string{"south"}}},
+		}
+		pools = []dnssvcsv1.Pool{
+			{ID: core.StringPtr("east"), Name: core.StringPtr("east"), Enabled: core.BoolPtr(true), HealthyOriginsThreshold: core.Int64Ptr(2),
+				Origins: []dnssvcsv1.Origin{newOrigin("e1", "10.0.1.1", true), newOrigin("e2", "10.0.1.2", true), newOrigin("e3", "10.0.1.3", false)}},
+			{ID: core.StringPtr("west"), Name: core.StringPtr("west"), Enabled: core.BoolPtr(true),
+				Origins: []dnssvcsv1.Origin{newOrigin("w1", "10.0.2.1", true)}},
+			{ID: core.StringPtr("south"), Name: core.StringPtr("south"), Enabled: core.BoolPtr(false),
+				Origins: []dnssvcsv1.Origin{newOrigin("s1", "10.0.3.1", true)}},
+			{ID: core.StringPtr("backup"), Name: core.StringPtr("backup"), Enabled: core.BoolPtr(true),
+				Origins: []dnssvcsv1.Origin{newOrigin("b1", "10.0.4.1", true)}},
+		}
+	})
+	It(`Route to the first healthy default pool`, func() {
+		result, err := dnssvcsv1.SimulateFailover(loadBalancer, pools, nil)
+		Expect(err).To(BeNil())
+		Expect(*result.Pool.ID).To(Equal("east"))
+		Expect(result.Origins).To(HaveLen(2))
+		Expect(result.Steps).To(HaveLen(1))
+	})
+	It(`Fail over below the healthy origins threshold and skip a disabled availability zone pool`, func() {
+		result, err := dnssvcsv1.SimulateFailover(loadBalancer, pools, &dnssvcsv1.FailoverSimulation{FailedOrigins: []string{"10.0.1.2"}, AvailabilityZone: "us-south-1"})
+		Expect(err).To(BeNil())
+		Expect(*result.Pool.ID).To(Equal("west"))
+		Expect(result.Steps).To(HaveLen(3))
+		Expect(result.Steps[0].Stage).To(Equal(dnssvcsv1.FailoverStep_Stage_AzPools))
+		Expect(result.Steps[0].Message).To(ContainSubstring("disabled"))
+		Expect(result.Steps[1].Message).To(ContainSubstring("1 of 3 origins healthy, threshold 2"))
+		Expect(result.Steps[2].Selected).To(BeTrue())
+	})
+	It(`Route to the fallback pool when every pool is unhealthy`, func() {
+		pools[3].Origins[0].Health = core.BoolPtr(false)
+		result, err := dnssvcsv1.SimulateFailover(loadBalancer, pools, &dnssvcsv1.FailoverSimulation{FailedOrigins: []string{"e1", "w1"}, UseReportedHealth: true})
+		Expect(err).To(BeNil())
+		Expect(*result.Pool.ID).To(Equal("backup"))
+		Expect(*result.Origins[0].Name).To(Equal("b1"))
+		Expect(result.Steps[len(result.Steps)-1].Stage).To(Equal(dnssvcsv1.FailoverStep_Stage_FallbackPool))
+		Expect(result.String()).To(ContainSubstring("[fallback_pool] no other pool is healthy, fallback pool backup serves traffic although only 0 of 1 origins are healthy"))
+	})
+	It(`Serve no traffic from a disabled load balancer`, func() {
+		loadBalancer.Enabled = core.BoolPtr(false)
+		result, err := dnssvcsv1.SimulateFailover(loadBalancer, pools, nil)
+		Expect(err).To(BeNil())
+		Expect(result.Pool).To(BeNil())
+		Expect(result.Steps[0].Stage).To(Equal(dnssvcsv1.FailoverStep_Stage_LoadBalancer))
+	})
+	It(`Fail without a load balancer`, func() {
+		result, err := dnssvcsv1.SimulateFailover(nil, pools, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(result).To(BeNil())
+	})
+})
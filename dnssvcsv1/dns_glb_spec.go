@@ -0,0 +1,784 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"gopkg.in/yaml.v2"
+)
+
+// GlbSpec : The desired load balancers, pools and monitors of a service instance. Objects reference each other by
+// name rather than by ID.
+type GlbSpec struct {
+	// The load balancer monitors.
+	Monitors []GlbSpecMonitor `json:"monitors,omitempty" yaml:"monitors,omitempty"`
+
+	// The load balancer pools.
+	Pools []GlbSpecPool `json:"pools,omitempty" yaml:"pools,omitempty"`
+
+	// The load balancers.
+	LoadBalancers []GlbSpecLoadBalancer `json:"load_balancers,omitempty" yaml:"load_balancers,omitempty"`
+}
+
+// GlbSpecMonitor : A load balancer monitor of a GlbSpec. Properties left unset are not managed.
+type GlbSpecMonitor struct {
+	// The name of the load balancer monitor, unique within the service instance.
+	Name *string `json:"name" yaml:"name" validate:"required"`
+
+	// Descriptive text of the load balancer monitor.
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// The protocol to use for the health check.
+	Type *string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Port number to connect to for the health check.
+	Port *int64 `json:"port,omitempty" yaml:"port,omitempty"`
+
+	// The interval between each health check.
+	Interval *int64 `json:"interval,omitempty" yaml:"interval,omitempty"`
+
+	// The number of retries to attempt in case of a timeout before marking the origin as unhealthy.
+	Retries *int64 `json:"retries,omitempty" yaml:"retries,omitempty"`
+
+	// The timeout (in seconds) before marking the health check as failed.
+	Timeout *int64 `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// The method to use for the health check.
+	Method *string `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// The endpoint path to health check against.
+	Path *string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// The HTTP request headers to send in the health check, by header name.
+	Headers map[string][]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// Do not validate the certificate when monitor use HTTPS.
+	AllowInsecure *bool `json:"allow_insecure,omitempty" yaml:"allow_insecure,omitempty"`
+
+	// The expected HTTP response code or code range of the health check.
+	ExpectedCodes *string `json:"expected_codes,omitempty" yaml:"expected_codes,omitempty"`
+
+	// A case-insensitive sub-string to look for in the response body.
+	ExpectedBody *string `json:"expected_body,omitempty" yaml:"expected_body,omitempty"`
+
+	// Follow redirects returned by the origin.
+	FollowRedirects *bool `json:"follow_redirects,omitempty" yaml:"follow_redirects,omitempty"`
+}
+
+// GlbSpecPool : A load balancer pool of a GlbSpec. Properties left unset are not managed.
+type GlbSpecPool struct {
+	// Name of the load balancer pool, unique within the service instance.
+	Name *string `json:"name" yaml:"name" validate:"required"`
+
+	// Descriptive text of the load balancer pool.
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Whether the load balancer pool is enabled.
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// The minimum number of origins that must be healthy for this pool to serve traffic.
+	HealthyOriginsThreshold *int64 `json:"healthy_origins_threshold,omitempty" yaml:"healthy_origins_threshold,omitempty"`
+
+	// The list of origins within this pool.
+	Origins []GlbSpecOrigin `json:"origins,omitempty" yaml:"origins,omitempty"`
+
+	// The name of the load balancer monitor associated to this pool.
+	Monitor *string `json:"monitor,omitempty" yaml:"monitor,omitempty"`
+
+	// The notification channel.
+	NotificationChannel *string `json:"notification_channel,omitempty" yaml:"notification_channel,omitempty"`
+
+	// Health check region of VSIs.
+	HealthcheckRegion *string `json:"healthcheck_region,omitempty" yaml:"healthcheck_region,omitempty"`
+
+	// Health check subnet IDs of VSIs.
+	HealthcheckSubnets []string `json:"healthcheck_subnets,omitempty" yaml:"healthcheck_subnets,omitempty"`
+}
+
+// GlbSpecOrigin : An origin of a GlbSpecPool.
+type GlbSpecOrigin struct {
+	// The name of the origin server.
+	Name *string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Description of the origin server.
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// The address of the origin server. It can be a hostname or an IP address.
+	Address *string `json:"address,omitempty" yaml:"address,omitempty"`
+
+	// Whether the origin server is enabled.
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+}
+
+// GlbSpecLoadBalancer : A load balancer of a GlbSpec. Properties left unset are not managed.
+type GlbSpecLoadBalancer struct {
+	// Name of the load balancer, relative to the DNS zone or fully qualified.
+	Name *string `json:"name" yaml:"name" validate:"required"`
+
+	// Name of the DNS zone of the load balancer.
+	Dnszone *string `json:"dnszone" yaml:"dnszone" validate:"required"`
+
+	// Descriptive text of the load balancer.
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Whether the load balancer is enabled.
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// Time to live in second.
+	TTL *int64 `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// The name of the pool to use when all other pools are detected as unhealthy.
+	FallbackPool *string `json:"fallback_pool" yaml:"fallback_pool" validate:"required"`
+
+	// The names of the default pools, ordered by their failover priority.
+	DefaultPools []string `json:"default_pools" yaml:"default_pools" validate:"required"`
+
+	// Map availability zones to pool names.
+	AzPools []GlbSpecAzPools `json:"az_pools,omitempty" yaml:"az_pools,omitempty"`
+}
+
+// GlbSpecAzPools : The pools of a GlbSpecLoadBalancer for an availability zone.
+type GlbSpecAzPools struct {
+	// Availability zone.
+	AvailabilityZone *string `json:"availability_zone" yaml:"availability_zone"`
+
+	// The names of the pools.
+	Pools []string `json:"pools" yaml:"pools"`
+}
+
+// ParseGlbSpec parses a GlbSpec from JSON or YAML. Unknown properties are rejected.
+func ParseGlbSpec(data []byte) (spec *GlbSpec, err error) {
+	spec = new(GlbSpec)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(spec)
+	} else {
+		err = yaml.UnmarshalStrict(data, spec)
+	}
+	if err != nil {
+		spec = nil
+		err = fmt.Errorf("invalid load balancer spec: %s", err.Error())
+	}
+	return
+}
+
+// PlanGlbOptions : The PlanGlb options.
+type PlanGlbOptions struct {
+	// The unique identifier of a service instance.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The desired load balancers, pools and monitors.
+	Spec *GlbSpec `json:"spec" validate:"required"`
+
+	// Delete the monitors and pools of the service instance, and the load balancers of the DNS zones of the spec, that
+	// the spec does not list.
+	Prune *bool `json:"prune,omitempty"`
+}
+
+// NewPlanGlbOptions : Instantiate PlanGlbOptions
+func (*DnsSvcsV1) NewPlanGlbOptions(instanceID string, spec *GlbSpec) *PlanGlbOptions {
+	return &PlanGlbOptions{
+		InstanceID: core.StringPtr(instanceID),
+		Spec:       spec,
+	}
+}
+
+// SetPrune : Allow user to set Prune
+func (options *PlanGlbOptions) SetPrune(prune bool) *PlanGlbOptions {
+	options.Prune = core.BoolPtr(prune)
+	return options
+}
+
+// GlbPlan : The changes bringing the load balancers, pools and monitors of a service instance to a GlbSpec.
+type GlbPlan struct {
+	// The unique identifier of the service instance.
+	InstanceID string `json:"instance_id"`
+
+	// The changes in the order they are applied: creates and updates of monitors, pools and load balancers, then
+	// deletes of load balancers, pools and monitors.
+	Changes []GlbChange `json:"changes"`
+
+	// IDs of existing objects by name, completed with created objects while the plan is applied.
+	monitorIDs map[string]string
+	poolIDs    map[string]string
+}
+
+// GlbChange : A change of a GlbPlan.
+type GlbChange struct {
+	// The change.
+	Action string `json:"action"`
+
+	// The kind of object changed.
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Identifier of the object, empty for a create.
+	ID string `json:"id,omitempty"`
+
+	// The unique identifier of the DNS zone of a load balancer.
+	DnszoneID string `json:"dnszone_id,omitempty"`
+
+	// The properties an update changes.
+	Fields []string `json:"fields,omitempty"`
+
+	monitor      *GlbSpecMonitor
+	pool         *GlbSpecPool
+	loadBalancer *GlbSpecLoadBalancer
+}
+
+// Constants associated with the GlbChange.Action property.
+// The change.
+const (
+	GlbChange_Action_Create = "create"
+	GlbChange_Action_Delete = "delete"
+	GlbChange_Action_Update = "update"
+)
+
+// String returns the changes, one per line.
+func (plan *GlbPlan) String() string {
+	symbols := map[string]string{GlbChange_Action_Create: "+", GlbChange_Action_Update: "~", GlbChange_Action_Delete: "-"}
+	lines := make([]string, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		line := fmt.Sprintf("%s %s %s", symbols[change.Action], change.Kind, change.Name)
+		if len(change.Fields) > 0 {
+			line += " (" + strings.Join(change.Fields, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PlanGlb : Plan the changes to bring load balancers to a spec
+// Resolve the names of a GlbSpec to IDs and compare the spec with the load balancers, pools and monitors of a service
+// instance. Objects are matched by name; properties the spec leaves unset are not compared. Nothing is changed until
+// the plan is applied with ApplyGlbPlan.
+func (dnsSvcs *DnsSvcsV1) PlanGlb(planGlbOptions *PlanGlbOptions) (plan *GlbPlan, err error) {
+	err = core.ValidateNotNil(planGlbOptions, "planGlbOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(planGlbOptions, "planGlbOptions")
+	if err != nil {
+		return
+	}
+	instanceID, spec := *planGlbOptions.InstanceID, planGlbOptions.Spec
+	prune := boolValue(planGlbOptions.Prune, false)
+	err = spec.validate()
+	if err != nil {
+		return
+	}
+
+	monitors, _, err := dnsSvcs.ListMonitors(dnsSvcs.NewListMonitorsOptions(instanceID))
+	if err != nil {
+		return
+	}
+	pools, _, err := dnsSvcs.ListPools(dnsSvcs.NewListPoolsOptions(instanceID))
+	if err != nil {
+		return
+	}
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID))
+	if err != nil {
+		return
+	}
+	dnszoneIDs := make(map[string]string)
+	for _, dnszone := range dnszones {
+		dnszoneIDs[normalizeDomainName(stringValue(dnszone.Name, ""))] = stringValue(dnszone.ID, "")
+	}
+
+	plan = &GlbPlan{InstanceID: instanceID, Changes: []GlbChange{}, monitorIDs: make(map[string]string), poolIDs: make(map[string]string)}
+	var deletes []GlbChange
+
+	existingMonitors := make(map[string]*Monitor)
+	for i, monitor := range monitors.Monitors {
+		existingMonitors[strings.ToLower(stringValue(monitor.Name, ""))] = &monitors.Monitors[i]
+		plan.monitorIDs[strings.ToLower(stringValue(monitor.Name, ""))] = stringValue(monitor.ID, "")
+	}
+	wanted := make(map[string]bool)
+	for i := range spec.Monitors {
+		monitor := &spec.Monitors[i]
+		key := strings.ToLower(*monitor.Name)
+		wanted[key] = true
+		change := GlbChange{Kind: GlbTopologyNode_Kind_Monitor, Name: *monitor.Name, monitor: monitor}
+		if existing := existingMonitors[key]; existing != nil {
+			change.ID = stringValue(existing.ID, "")
+			plan.addUpdate(change, existing, monitor.createOptions(instanceID))
+		} else {
+			plan.addCreate(change)
+		}
+	}
+	for _, monitor := range monitors.Monitors {
+		if !wanted[strings.ToLower(stringValue(monitor.Name, ""))] {
+			deletes = append(deletes, GlbChange{Action: GlbChange_Action_Delete, Kind: GlbTopologyNode_Kind_Monitor, Name: stringValue(monitor.Name, ""), ID: stringValue(monitor.ID, "")})
+		}
+	}
+
+	existingPools := make(map[string]*Pool)
+	for i, pool := range pools.Pools {
+		existingPools[strings.ToLower(stringValue(pool.Name, ""))] = &pools.Pools[i]
+		plan.poolIDs[strings.ToLower(stringValue(pool.Name, ""))] = stringValue(pool.ID, "")
+	}
+	wanted = make(map[string]bool)
+	for i := range spec.Pools {
+		pool := &spec.Pools[i]
+		key := strings.ToLower(*pool.Name)
+		wanted[key] = true
+		change := GlbChange{Kind: GlbTopologyNode_Kind_Pool, Name: *pool.Name, pool: pool}
+		if existing := existingPools[key]; existing != nil {
+			change.ID = stringValue(existing.ID, "")
+			plan.addUpdate(change, existing, pool.createOptions(instanceID, plan.planned(plan.monitorIDs, pool.Monitor)))
+		} else {
+			plan.addCreate(change)
+		}
+	}
+	for _, pool := range pools.Pools {
+		if !wanted[strings.ToLower(stringValue(pool.Name, ""))] {
+			deletes = append(deletes, GlbChange{Action: GlbChange_Action_Delete, Kind: GlbTopologyNode_Kind_Pool, Name: stringValue(pool.Name, ""), ID: stringValue(pool.ID, "")})
+		}
+	}
+
+	// Load balancers are listed for the DNS zones of the spec only, and keyed by DNS zone and fully qualified name.
+	existingLoadBalancers := make(map[string]*LoadBalancer)
+	var existingKeys []string
+	listed := make(map[string]bool)
+	for _, loadBalancer := range spec.LoadBalancers {
+		zoneName := normalizeDomainName(*loadBalancer.Dnszone)
+		dnszoneID, found := dnszoneIDs[zoneName]
+		if !found {
+			plan, err = nil, fmt.Errorf("DNS zone %s of load balancer %s does not exist", *loadBalancer.Dnszone, *loadBalancer.Name)
+			return
+		}
+		if listed[dnszoneID] {
+			continue
+		}
+		listed[dnszoneID] = true
+		result, _, listErr := dnsSvcs.ListLoadBalancers(dnsSvcs.NewListLoadBalancersOptions(instanceID, dnszoneID))
+		if listErr != nil {
+			plan, err = nil, listErr
+			return
+		}
+		for i, existing := range result.LoadBalancers {
+			key := dnszoneID + " " + qualifyDomainName(stringValue(existing.Name, ""), zoneName)
+			existingLoadBalancers[key] = &result.LoadBalancers[i]
+			existingKeys = append(existingKeys, key)
+		}
+	}
+	wanted = make(map[string]bool)
+	for i := range spec.LoadBalancers {
+		loadBalancer := &spec.LoadBalancers[i]
+		zoneName := normalizeDomainName(*loadBalancer.Dnszone)
+		dnszoneID := dnszoneIDs[zoneName]
+		key := dnszoneID + " " + qualifyDomainName(*loadBalancer.Name, zoneName)
+		wanted[key] = true
+		change := GlbChange{Kind: GlbTopologyNode_Kind_LoadBalancer, Name: *loadBalancer.Name, DnszoneID: dnszoneID, loadBalancer: loadBalancer}
+		if existing := existingLoadBalancers[key]; existing != nil {
+			change.ID = stringValue(existing.ID, "")
+			plan.addUpdate(change, existing, loadBalancer.createOptions(instanceID, dnszoneID, func(name string) string {
+				return plan.planned(plan.poolIDs, &name)
+			}))
+		} else {
+			plan.addCreate(change)
+		}
+	}
+	for _, key := range existingKeys {
+		if !wanted[key] {
+			existing := existingLoadBalancers[key]
+			deletes = append(deletes, GlbChange{Action: GlbChange_Action_Delete, Kind: GlbTopologyNode_Kind_LoadBalancer, Name: stringValue(existing.Name, ""), ID: stringValue(existing.ID, ""), DnszoneID: key[:strings.Index(key, " ")]})
+		}
+	}
+
+	if prune {
+		sort.SliceStable(deletes, func(i, j int) bool {
+			return deleteOrder(deletes[i].Kind) < deleteOrder(deletes[j].Kind)
+		})
+		plan.Changes = append(plan.Changes, deletes...)
+	}
+	return
+}
+
+// ApplyGlbPlan : Apply a load balancer plan
+// Apply the changes of a plan in order, resolving the names of monitors and pools created by earlier changes. The
+// first failing change stops the plan; the changes applied until then are returned with the error. Planning again
+// afterwards resumes the work.
+func (dnsSvcs *DnsSvcsV1) ApplyGlbPlan(plan *GlbPlan) (applied []GlbChange, err error) {
+	err = core.ValidateNotNil(plan, "plan cannot be nil")
+	if err != nil {
+		return
+	}
+	applied = []GlbChange{}
+	for _, change := range plan.Changes {
+		err = dnsSvcs.applyGlbChange(plan, change)
+		if err != nil {
+			err = fmt.Errorf("failed to %s %s %s: %s", change.Action, change.Kind, change.Name, err.Error())
+			return
+		}
+		applied = append(applied, change)
+	}
+	return
+}
+
+// ApplyGlbSpec : Bring load balancers to a spec
+// Plan the changes bringing the load balancers, pools and monitors of a service instance to a spec and apply them.
+// Applying the same spec again changes nothing.
+func (dnsSvcs *DnsSvcsV1) ApplyGlbSpec(planGlbOptions *PlanGlbOptions) (plan *GlbPlan, err error) {
+	plan, err = dnsSvcs.PlanGlb(planGlbOptions)
+	if err != nil {
+		return
+	}
+	_, err = dnsSvcs.ApplyGlbPlan(plan)
+	return
+}
+
+func (dnsSvcs *DnsSvcsV1) applyGlbChange(plan *GlbPlan, change GlbChange) (err error) {
+	instanceID := plan.InstanceID
+	switch change.Kind {
+	case GlbTopologyNode_Kind_Monitor:
+		switch change.Action {
+		case GlbChange_Action_Create:
+			var monitor *Monitor
+			monitor, _, err = dnsSvcs.CreateMonitor(change.monitor.createOptions(instanceID))
+			if err == nil {
+				plan.monitorIDs[strings.ToLower(change.Name)] = stringValue(monitor.ID, "")
+			}
+		case GlbChange_Action_Update:
+			updateOptions := dnsSvcs.NewUpdateMonitorOptions(instanceID, change.ID)
+			err = convertOptions(change.monitor.createOptions(instanceID), updateOptions)
+			if err == nil {
+				_, _, err = dnsSvcs.UpdateMonitor(updateOptions)
+			}
+		case GlbChange_Action_Delete:
+			_, err = dnsSvcs.DeleteMonitor(dnsSvcs.NewDeleteMonitorOptions(instanceID, change.ID))
+		}
+	case GlbTopologyNode_Kind_Pool:
+		if change.Action == GlbChange_Action_Delete {
+			_, err = dnsSvcs.DeletePool(dnsSvcs.NewDeletePoolOptions(instanceID, change.ID))
+			return
+		}
+		var monitorID string
+		monitorID, err = plan.resolve(GlbTopologyNode_Kind_Monitor, plan.monitorIDs, change.pool.Monitor)
+		if err != nil {
+			return
+		}
+		createOptions := change.pool.createOptions(instanceID, monitorID)
+		if change.Action == GlbChange_Action_Create {
+			var pool *Pool
+			pool, _, err = dnsSvcs.CreatePool(createOptions)
+			if err == nil {
+				plan.poolIDs[strings.ToLower(change.Name)] = stringValue(pool.ID, "")
+			}
+			return
+		}
+		updateOptions := dnsSvcs.NewUpdatePoolOptions(instanceID, change.ID)
+		err = convertOptions(createOptions, updateOptions)
+		if err == nil {
+			_, _, err = dnsSvcs.UpdatePool(updateOptions)
+		}
+	case GlbTopologyNode_Kind_LoadBalancer:
+		if change.Action == GlbChange_Action_Delete {
+			_, err = dnsSvcs.DeleteLoadBalancer(dnsSvcs.NewDeleteLoadBalancerOptions(instanceID, change.DnszoneID, change.ID))
+			return
+		}
+		var resolveErr error
+		createOptions := change.loadBalancer.createOptions(instanceID, change.DnszoneID, func(name string) string {
+			id, err := plan.resolve(GlbTopologyNode_Kind_Pool, plan.poolIDs, &name)
+			if err != nil && resolveErr == nil {
+				resolveErr = err
+			}
+			return id
+		})
+		if resolveErr != nil {
+			err = resolveErr
+			return
+		}
+		if change.Action == GlbChange_Action_Create {
+			_, _, err = dnsSvcs.CreateLoadBalancer(createOptions)
+			return
+		}
+		updateOptions := dnsSvcs.NewUpdateLoadBalancerOptions(instanceID, change.DnszoneID, change.ID)
+		err = convertOptions(createOptions, updateOptions)
+		if err == nil {
+			_, _, err = dnsSvcs.UpdateLoadBalancer(updateOptions)
+		}
+	}
+	return
+}
+
+func (plan *GlbPlan) addCreate(change GlbChange) {
+	change.Action = GlbChange_Action_Create
+	plan.Changes = append(plan.Changes, change)
+}
+
+// addUpdate adds an update when the properties of createOptions differ from the existing object.
+func (plan *GlbPlan) addUpdate(change GlbChange, existing interface{}, createOptions interface{}) {
+	var desired, current map[string]interface{}
+	_ = convertOptions(createOptions, &desired)
+	_ = convertOptions(existing, &current)
+	for _, key := range []string{"instance_id", "dnszone_id", "name", "Headers"} {
+		delete(desired, key)
+	}
+	for key, value := range desired {
+		if !subsetEqual(key, value, current[key]) {
+			change.Fields = append(change.Fields, key)
+		}
+	}
+	if len(change.Fields) == 0 {
+		return
+	}
+	sort.Strings(change.Fields)
+	change.Action = GlbChange_Action_Update
+	plan.Changes = append(plan.Changes, change)
+}
+
+// planned returns the ID of an object by name, or a placeholder that matches no ID when the object is created by the
+// plan.
+func (plan *GlbPlan) planned(ids map[string]string, name *string) string {
+	if name == nil {
+		return ""
+	}
+	if id, found := ids[strings.ToLower(*name)]; found {
+		return id
+	}
+	return "<" + *name + ">"
+}
+
+func (plan *GlbPlan) resolve(kind string, ids map[string]string, name *string) (string, error) {
+	if name == nil {
+		return "", nil
+	}
+	if id, found := ids[strings.ToLower(*name)]; found {
+		return id, nil
+	}
+	return "", fmt.Errorf("%s %s does not exist", kind, *name)
+}
+
+// validate checks the required properties of a spec, that names are unique and that every reference resolves to an
+// object of the spec.
+func (spec *GlbSpec) validate() (err error) {
+	monitors, pools, loadBalancers := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	for i := range spec.Monitors {
+		err = core.ValidateStruct(&spec.Monitors[i], "monitor")
+		if err != nil {
+			return
+		}
+		key := strings.ToLower(*spec.Monitors[i].Name)
+		if monitors[key] {
+			return fmt.Errorf("monitor %s is listed twice", *spec.Monitors[i].Name)
+		}
+		monitors[key] = true
+	}
+	for i := range spec.Pools {
+		pool := &spec.Pools[i]
+		err = core.ValidateStruct(pool, "pool")
+		if err != nil {
+			return
+		}
+		key := strings.ToLower(*pool.Name)
+		if pools[key] {
+			return fmt.Errorf("pool %s is listed twice", *pool.Name)
+		}
+		pools[key] = true
+		err = ValidateHealthcheckSettings(pool.HealthcheckRegion, pool.HealthcheckSubnets)
+		if err != nil {
+			return fmt.Errorf("pool %s: %s", *pool.Name, err.Error())
+		}
+		if pool.Monitor != nil && !monitors[strings.ToLower(*pool.Monitor)] {
+			return fmt.Errorf("pool %s references monitor %s, which the spec does not list", *pool.Name, *pool.Monitor)
+		}
+	}
+	for i := range spec.LoadBalancers {
+		loadBalancer := &spec.LoadBalancers[i]
+		err = core.ValidateStruct(loadBalancer, "loadBalancer")
+		if err != nil {
+			return
+		}
+		key := normalizeDomainName(*loadBalancer.Dnszone) + " " + qualifyDomainName(*loadBalancer.Name, *loadBalancer.Dnszone)
+		if loadBalancers[key] {
+			return fmt.Errorf("load balancer %s is listed twice", *loadBalancer.Name)
+		}
+		loadBalancers[key] = true
+		references := append([]string{*loadBalancer.FallbackPool}, loadBalancer.DefaultPools...)
+		for _, azPools := range loadBalancer.AzPools {
+			references = append(references, azPools.Pools...)
+		}
+		for _, name := range references {
+			if !pools[strings.ToLower(name)] {
+				return fmt.Errorf("load balancer %s references pool %s, which the spec does not list", *loadBalancer.Name, name)
+			}
+		}
+	}
+	return
+}
+
+func (monitor *GlbSpecMonitor) createOptions(instanceID string) *CreateMonitorOptions {
+	options := &CreateMonitorOptions{
+		InstanceID:      core.StringPtr(instanceID),
+		Name:            monitor.Name,
+		Description:     monitor.Description,
+		Type:            monitor.Type,
+		Port:            monitor.Port,
+		Interval:        monitor.Interval,
+		Retries:         monitor.Retries,
+		Timeout:         monitor.Timeout,
+		Method:          monitor.Method,
+		Path:            monitor.Path,
+		AllowInsecure:   monitor.AllowInsecure,
+		ExpectedCodes:   monitor.ExpectedCodes,
+		ExpectedBody:    monitor.ExpectedBody,
+		FollowRedirects: monitor.FollowRedirects,
+	}
+	if len(monitor.Headers) > 0 {
+		names := make([]string, 0, len(monitor.Headers))
+		for name := range monitor.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		headers := newHealthcheckHeaderBuilder()
+		for _, name := range names {
+			headers.Set(name, monitor.Headers[name]...)
+		}
+		options.HeadersVar = headers.HealthcheckHeaders()
+	}
+	return options
+}
+
+func (pool *GlbSpecPool) createOptions(instanceID string, monitorID string) *CreatePoolOptions {
+	options := &CreatePoolOptions{
+		InstanceID:              core.StringPtr(instanceID),
+		Name:                    pool.Name,
+		Description:             pool.Description,
+		Enabled:                 pool.Enabled,
+		HealthyOriginsThreshold: pool.HealthyOriginsThreshold,
+		NotificationChannel:     pool.NotificationChannel,
+		HealthcheckRegion:       pool.HealthcheckRegion,
+		HealthcheckSubnets:      pool.HealthcheckSubnets,
+	}
+	if pool.Origins != nil {
+		options.Origins = make([]OriginInput, 0, len(pool.Origins))
+		for _, origin := range pool.Origins {
+			options.Origins = append(options.Origins, OriginInput{Name: origin.Name, Description: origin.Description, Address: origin.Address, Enabled: origin.Enabled})
+		}
+	}
+	if pool.Monitor != nil {
+		options.Monitor = core.StringPtr(monitorID)
+	}
+	return options
+}
+
+func (loadBalancer *GlbSpecLoadBalancer) createOptions(instanceID string, dnszoneID string, poolID func(string) string) *CreateLoadBalancerOptions {
+	options := &CreateLoadBalancerOptions{
+		InstanceID:  core.StringPtr(instanceID),
+		DnszoneID:   core.StringPtr(dnszoneID),
+		Name:        loadBalancer.Name,
+		Description: loadBalancer.Description,
+		Enabled:     loadBalancer.Enabled,
+		TTL:         loadBalancer.TTL,
+	}
+	if loadBalancer.FallbackPool != nil {
+		options.FallbackPool = core.StringPtr(poolID(*loadBalancer.FallbackPool))
+	}
+	for _, name := range loadBalancer.DefaultPools {
+		options.DefaultPools = append(options.DefaultPools, poolID(name))
+	}
+	for _, azPools := range loadBalancer.AzPools {
+		item := LoadBalancerAzPoolsItem{AvailabilityZone: azPools.AvailabilityZone, Pools: []string{}}
+		for _, name := range azPools.Pools {
+			item.Pools = append(item.Pools, poolID(name))
+		}
+		options.AzPools = append(options.AzPools, item)
+	}
+	return options
+}
+
+// convertOptions copies the properties of one model to another with the same JSON property names.
+func convertOptions(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
+
+// caseInsensitiveProperties are the enumerated properties whose values the service may return in another case.
+var caseInsensitiveProperties = map[string]bool{
+	"type":   true,
+	"method": true,
+}
+
+// subsetEqual reports whether every property of desired, a decoded JSON value of the property key, has the same value
+// in current. Objects may have properties desired does not list; arrays must have the same length. Strings are
+// compared exactly, except for the values of caseInsensitiveProperties.
+func subsetEqual(key string, desired interface{}, current interface{}) bool {
+	switch desired := desired.(type) {
+	case map[string]interface{}:
+		current, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range desired {
+			if !subsetEqual(key, value, current[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		current, ok := current.([]interface{})
+		if !ok || len(current) != len(desired) {
+			return false
+		}
+		for i := range desired {
+			if !subsetEqual(key, desired[i], current[i]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		current, ok := current.(string)
+		if caseInsensitiveProperties[key] {
+			return ok && strings.EqualFold(desired, current)
+		}
+		return ok && desired == current
+	}
+	return reflect.DeepEqual(desired, current)
+}
+
+// qualifyDomainName returns the fully qualified form of a name relative to a DNS zone.
+func qualifyDomainName(name string, zoneName string) string {
+	name, zoneName = normalizeDomainName(name), normalizeDomainName(zoneName)
+	if name == zoneName || strings.HasSuffix(name, "."+zoneName) {
+		return name
+	}
+	return name + "." + zoneName
+}
+
+func deleteOrder(kind string) int {
+	switch kind {
+	case GlbTopologyNode_Kind_LoadBalancer:
+		return 0
+	case GlbTopologyNode_Kind_Pool:
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,165 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe(`Declarative load balancer configuration`, func() {
+	const specYAML = `
+monitors:
+  - name: http
+    type: HTTP
+    path: /health
+    expected_codes: 2xx
+    headers:
+      Host: [origin.example.com]
+pools:
+  - name: east
+    healthy_origins_threshold: 1
+    monitor: http
+    origins:
+      - name: e1
+        address: 10.0.1.1
+        enabled: true
+  - name: west
+    origins:
+      - name: w1
+        address: 10.0.2.1
+load_balancers:
+  - name: web
+    dnszone: example.com
+    ttl: 60
+    fallback_pool: west
+    default_pools: [east, west]
+    az_pools:
+      - availability_zone: us-south-1
+        pools: [east]
+`
+	var fake *fakednssvcs.Server
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		fake.Add("/instances/instance/pools", map[string]interface{}{
+			"id": "pool-east", "name": "east", "enabled": true, "healthy_origins_threshold": 1,
+			"origins": []interface{}{map[string]interface{}{"name": "e0", "address": "10.0.1.0", "enabled": true}},
+		})
+		fake.Add("/instances/instance/monitors", map[string]interface{}{"id": "monitor-old", "name": "old", "type": "TCP", "port": 22})
+		fake.Add("/instances/instance/dnszones/zone/load_balancers", map[string]interface{}{
+			"id": "glb-api", "name": "api.example.com", "fallback_pool": "pool-east", "default_pools": []string{"pool-east"},
+		})
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke ParseGlbSpec successfully`, func() {
+		spec, err := dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		Expect(err).To(BeNil())
+		Expect(*spec.Pools[0].Origins[0].Address).To(Equal("10.0.1.1"))
+		Expect(*spec.LoadBalancers[0].AzPools[0].AvailabilityZone).To(Equal("us-south-1"))
+
+		spec, err = dnssvcsv1.ParseGlbSpec([]byte(`{"monitors": [{"name": "tcp", "type": "TCP", "port": 22}]}`))
+		Expect(err).To(BeNil())
+		Expect(*spec.Monitors[0].Port).To(Equal(int64(22)))
+
+		_, err = dnssvcsv1.ParseGlbSpec([]byte(`{"monitors": [{"name": "tcp", "ports": 22}]}`))
+		Expect(err).ToNot(BeNil())
+		_, err = dnssvcsv1.ParseGlbSpec([]byte("pools:\n  - name: east\n    treshold: 1\n"))
+		Expect(err).ToNot(BeNil())
+		_, err = dnssvcsv1.ParseGlbSpec([]byte("pools:\n  - name: east\n    origins:\n      - name: e1\n        adress: 10.0.1.1\n"))
+		Expect(err).ToNot(BeNil())
+
+		spec, err = dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		Expect(err).To(BeNil())
+		data, err := yaml.Marshal(spec.Pools[0].Origins)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal("- name: e1\n  address: 10.0.1.1\n  enabled: true\n"))
+	})
+	It(`Invoke PlanGlb and ApplyGlbPlan successfully`, func() {
+		testService := fake.Service()
+		spec, err := dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		Expect(err).To(BeNil())
+		plan, err := testService.PlanGlb(testService.NewPlanGlbOptions("instance", spec).SetPrune(true))
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal(
+			"+ monitor http\n" +
+				"~ pool east (monitor, origins)\n" +
+				"+ pool west\n" +
+				"+ load_balancer web\n" +
+				"- load_balancer api.example.com\n" +
+				"- monitor old"))
+
+		applied, err := testService.ApplyGlbPlan(plan)
+		Expect(err).To(BeNil())
+		Expect(applied).To(HaveLen(6))
+		monitors := fake.List("/instances/instance/monitors")
+		Expect(monitors).To(HaveLen(1))
+		pools := fake.List("/instances/instance/pools")
+		Expect(pools[0]["monitor"]).To(Equal(monitors[0]["id"]))
+		loadBalancers := fake.List("/instances/instance/dnszones/zone/load_balancers")
+		Expect(loadBalancers).To(HaveLen(1))
+		Expect(loadBalancers[0]["default_pools"]).To(Equal([]interface{}{"pool-east", pools[1]["id"]}))
+		Expect(loadBalancers[0]["fallback_pool"]).To(Equal(pools[1]["id"]))
+
+		plan, err = testService.ApplyGlbSpec(testService.NewPlanGlbOptions("instance", spec).SetPrune(true))
+		Expect(err).To(BeNil())
+		Expect(plan.Changes).To(BeEmpty())
+
+		monitors[0]["type"] = "http"
+		monitors[0]["path"] = "/HEALTH"
+		plan, err = testService.PlanGlb(testService.NewPlanGlbOptions("instance", spec).SetPrune(true))
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal("~ monitor http (path)"))
+	})
+	It(`Invoke PlanGlb without pruning`, func() {
+		testService := fake.Service()
+		spec, _ := dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		plan, err := testService.PlanGlb(testService.NewPlanGlbOptions("instance", spec))
+		Expect(err).To(BeNil())
+		for _, change := range plan.Changes {
+			Expect(change.Action).ToNot(Equal(dnssvcsv1.GlbChange_Action_Delete))
+		}
+	})
+	It(`Invoke PlanGlb and ApplyGlbPlan with error`, func() {
+		testService := fake.Service()
+		spec, _ := dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		spec.LoadBalancers[0].DefaultPools = []string{"north"}
+		_, err := testService.PlanGlb(testService.NewPlanGlbOptions("instance", spec))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("references pool north, which the spec does not list"))
+
+		spec, _ = dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		spec.LoadBalancers[0].Dnszone = spec.Pools[0].Name
+		_, err = testService.PlanGlb(testService.NewPlanGlbOptions("instance", spec))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("DNS zone east of load balancer web does not exist"))
+
+		spec, _ = dnssvcsv1.ParseGlbSpec([]byte(specYAML))
+		plan, err := testService.PlanGlb(testService.NewPlanGlbOptions("instance", spec))
+		Expect(err).To(BeNil())
+		fake.Failures["POST /instances/instance/pools"] = 500
+		applied, err := testService.ApplyGlbPlan(plan)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("failed to create pool west"))
+		Expect(applied).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,353 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GlbTopology : The global load balancers, pools and monitors of a service instance, as a graph of their references.
+type GlbTopology struct {
+	// The load balancers, with the DNS zones they belong to.
+	LoadBalancers []GlbTopologyLoadBalancer `json:"load_balancers"`
+
+	// The load balancer pools.
+	Pools []Pool `json:"pools"`
+
+	// The load balancer monitors.
+	Monitors []Monitor `json:"monitors"`
+
+	// The nodes of the graph: load balancers, pools and monitors, including referenced objects that do not exist.
+	Nodes []GlbTopologyNode `json:"nodes"`
+
+	// The edges of the graph, from load balancers to pools and from pools to monitors.
+	Edges []GlbTopologyEdge `json:"edges"`
+
+	// Consistency problems found in the graph.
+	Issues []GlbTopologyIssue `json:"issues"`
+}
+
+// GlbTopologyLoadBalancer : A load balancer with the DNS zone it belongs to.
+type GlbTopologyLoadBalancer struct {
+	// The unique identifier of the DNS zone of the load balancer.
+	DnszoneID string `json:"dnszone_id"`
+
+	// The load balancer.
+	LoadBalancer LoadBalancer `json:"load_balancer"`
+}
+
+// GlbTopologyNode : A node of a GlbTopology graph.
+type GlbTopologyNode struct {
+	// Key of the node, "<kind>/<id>".
+	Key string `json:"key"`
+
+	// The kind of object.
+	Kind string `json:"kind"`
+
+	// Identifier of the object.
+	ID string `json:"id"`
+
+	// Name of the object.
+	Name string `json:"name,omitempty"`
+
+	// Whether the object is enabled. Monitors are always enabled.
+	Enabled bool `json:"enabled"`
+
+	// Whether the object is referenced but does not exist.
+	Dangling bool `json:"dangling,omitempty"`
+}
+
+// Constants associated with the GlbTopologyNode.Kind property.
+// The kind of object.
+const (
+	GlbTopologyNode_Kind_LoadBalancer = "load_balancer"
+	GlbTopologyNode_Kind_Monitor      = "monitor"
+	GlbTopologyNode_Kind_Pool         = "pool"
+)
+
+// GlbTopologyEdge : A reference between two nodes of a GlbTopology graph.
+type GlbTopologyEdge struct {
+	// Key of the referencing node.
+	From string `json:"from"`
+
+	// Key of the referenced node.
+	To string `json:"to"`
+
+	// The kind of reference.
+	Kind string `json:"kind"`
+
+	// The failover priority of a default pool, starting at 0, or the availability zone of an availability zone pool.
+	Label string `json:"label,omitempty"`
+}
+
+// Constants associated with the GlbTopologyEdge.Kind property.
+// The kind of reference.
+const (
+	GlbTopologyEdge_Kind_AzPool       = "az_pool"
+	GlbTopologyEdge_Kind_DefaultPool  = "default_pool"
+	GlbTopologyEdge_Kind_FallbackPool = "fallback_pool"
+	GlbTopologyEdge_Kind_Monitor      = "monitor"
+)
+
+// GlbTopologyIssue : A consistency problem of a GlbTopology.
+type GlbTopologyIssue struct {
+	// The kind of problem.
+	Kind string `json:"kind"`
+
+	// Key of the node the problem is about.
+	Node string `json:"node"`
+
+	// Description of the problem.
+	Message string `json:"message"`
+}
+
+// Constants associated with the GlbTopologyIssue.Kind property.
+// The kind of problem.
+const (
+	GlbTopologyIssue_Kind_DanglingMonitor       = "dangling_monitor"
+	GlbTopologyIssue_Kind_DanglingPool          = "dangling_pool"
+	GlbTopologyIssue_Kind_DisabledOnlyRoute     = "disabled_only_route"
+	GlbTopologyIssue_Kind_FallbackPoolIsDefault = "fallback_pool_is_default"
+	GlbTopologyIssue_Kind_UnusedMonitor         = "unused_monitor"
+	GlbTopologyIssue_Kind_UnusedPool            = "unused_pool"
+)
+
+// GetGlbTopology : Get the load balancer topology of a service instance
+// Load the load balancers of every DNS zone of a service instance together with its pools and monitors, and build
+// the graph of their references.
+func (dnsSvcs *DnsSvcsV1) GetGlbTopology(instanceID string) (topology *GlbTopology, err error) {
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID))
+	if err != nil {
+		return
+	}
+	loadBalancers := []GlbTopologyLoadBalancer{}
+	for _, dnszone := range dnszones {
+		result, _, listErr := dnsSvcs.ListLoadBalancers(dnsSvcs.NewListLoadBalancersOptions(instanceID, *dnszone.ID))
+		if listErr != nil {
+			err = listErr
+			return
+		}
+		for _, loadBalancer := range result.LoadBalancers {
+			loadBalancers = append(loadBalancers, GlbTopologyLoadBalancer{DnszoneID: *dnszone.ID, LoadBalancer: loadBalancer})
+		}
+	}
+	pools, _, err := dnsSvcs.ListPools(dnsSvcs.NewListPoolsOptions(instanceID))
+	if err != nil {
+		return
+	}
+	monitors, _, err := dnsSvcs.ListMonitors(dnsSvcs.NewListMonitorsOptions(instanceID))
+	if err != nil {
+		return
+	}
+	topology = NewGlbTopology(loadBalancers, pools.Pools, monitors.Monitors)
+	return
+}
+
+// NewGlbTopology builds the graph of references between load balancers, pools and monitors and checks it for
+// consistency problems.
+func NewGlbTopology(loadBalancers []GlbTopologyLoadBalancer, pools []Pool, monitors []Monitor) *GlbTopology {
+	topology := &GlbTopology{
+		LoadBalancers: loadBalancers,
+		Pools:         pools,
+		Monitors:      monitors,
+		Nodes:         []GlbTopologyNode{},
+		Edges:         []GlbTopologyEdge{},
+		Issues:        []GlbTopologyIssue{},
+	}
+	nodes := make(map[string]int)
+	addNode := func(node GlbTopologyNode) {
+		node.Key = node.Kind + "/" + node.ID
+		if _, found := nodes[node.Key]; !found {
+			nodes[node.Key] = len(topology.Nodes)
+			topology.Nodes = append(topology.Nodes, node)
+		}
+	}
+	referenced := make(map[string]bool)
+	addEdge := func(from string, kind string, toKind string, toID string, label string) {
+		to := toKind + "/" + toID
+		referenced[to] = true
+		topology.Edges = append(topology.Edges, GlbTopologyEdge{From: from, To: to, Kind: kind, Label: label})
+	}
+
+	for _, item := range loadBalancers {
+		addNode(GlbTopologyNode{Kind: GlbTopologyNode_Kind_LoadBalancer, ID: stringValue(item.LoadBalancer.ID, ""), Name: stringValue(item.LoadBalancer.Name, ""), Enabled: boolValue(item.LoadBalancer.Enabled, true)})
+	}
+	for _, pool := range pools {
+		addNode(GlbTopologyNode{Kind: GlbTopologyNode_Kind_Pool, ID: stringValue(pool.ID, ""), Name: stringValue(pool.Name, ""), Enabled: boolValue(pool.Enabled, true)})
+	}
+	for _, monitor := range monitors {
+		addNode(GlbTopologyNode{Kind: GlbTopologyNode_Kind_Monitor, ID: stringValue(monitor.ID, ""), Name: stringValue(monitor.Name, ""), Enabled: true})
+	}
+
+	for _, item := range loadBalancers {
+		loadBalancer := item.LoadBalancer
+		from := GlbTopologyNode_Kind_LoadBalancer + "/" + stringValue(loadBalancer.ID, "")
+		for i, poolID := range loadBalancer.DefaultPools {
+			addEdge(from, GlbTopologyEdge_Kind_DefaultPool, GlbTopologyNode_Kind_Pool, poolID, strconv.Itoa(i))
+			if loadBalancer.FallbackPool != nil && *loadBalancer.FallbackPool == poolID {
+				topology.addIssue(GlbTopologyIssue_Kind_FallbackPoolIsDefault, from, "load balancer %s uses pool %s both as fallback pool and as default pool", stringValue(loadBalancer.Name, from), poolID)
+			}
+		}
+		if loadBalancer.FallbackPool != nil {
+			addEdge(from, GlbTopologyEdge_Kind_FallbackPool, GlbTopologyNode_Kind_Pool, *loadBalancer.FallbackPool, "")
+		}
+		for _, azPools := range loadBalancer.AzPools {
+			for _, poolID := range azPools.Pools {
+				addEdge(from, GlbTopologyEdge_Kind_AzPool, GlbTopologyNode_Kind_Pool, poolID, stringValue(azPools.AvailabilityZone, ""))
+			}
+		}
+	}
+	for _, pool := range pools {
+		if pool.Monitor != nil && *pool.Monitor != "" {
+			addEdge(GlbTopologyNode_Kind_Pool+"/"+stringValue(pool.ID, ""), GlbTopologyEdge_Kind_Monitor, GlbTopologyNode_Kind_Monitor, *pool.Monitor, "")
+		}
+	}
+
+	for _, edge := range topology.Edges {
+		if _, found := nodes[edge.To]; found {
+			continue
+		}
+		kind, id := splitNodeKey(edge.To)
+		addNode(GlbTopologyNode{Kind: kind, ID: id, Dangling: true})
+		if kind == GlbTopologyNode_Kind_Pool {
+			topology.addIssue(GlbTopologyIssue_Kind_DanglingPool, edge.From, "%s references pool %s, which does not exist", edge.From, id)
+		} else {
+			topology.addIssue(GlbTopologyIssue_Kind_DanglingMonitor, edge.From, "%s references monitor %s, which does not exist", edge.From, id)
+		}
+	}
+	for _, node := range topology.Nodes {
+		if node.Dangling || node.Kind == GlbTopologyNode_Kind_LoadBalancer || referenced[node.Key] {
+			continue
+		}
+		if node.Kind == GlbTopologyNode_Kind_Pool {
+			topology.addIssue(GlbTopologyIssue_Kind_UnusedPool, node.Key, "pool %s is not used by any load balancer", nodeName(node))
+		} else {
+			topology.addIssue(GlbTopologyIssue_Kind_UnusedMonitor, node.Key, "monitor %s is not used by any pool", nodeName(node))
+		}
+	}
+	for _, item := range loadBalancers {
+		from := GlbTopologyNode_Kind_LoadBalancer + "/" + stringValue(item.LoadBalancer.ID, "")
+		routes, enabled := []string{}, 0
+		for _, poolID := range topology.PoolsOf(item.LoadBalancer) {
+			index, found := nodes[GlbTopologyNode_Kind_Pool+"/"+poolID]
+			if !found {
+				continue
+			}
+			routes = append(routes, poolID)
+			if topology.Nodes[index].Enabled {
+				enabled++
+			}
+		}
+		if len(routes) > 0 && enabled == 0 {
+			topology.addIssue(GlbTopologyIssue_Kind_DisabledOnlyRoute, from, "load balancer %s can only route to disabled pools %s", stringValue(item.LoadBalancer.Name, from), strings.Join(routes, ", "))
+		}
+	}
+	return topology
+}
+
+// PoolsOf returns the distinct identifiers of the pools a load balancer references, in the order of its default
+// pools, availability zone pools and fallback pool.
+func (topology *GlbTopology) PoolsOf(loadBalancer LoadBalancer) (poolIDs []string) {
+	seen := make(map[string]bool)
+	add := func(poolID string) {
+		if !seen[poolID] {
+			seen[poolID] = true
+			poolIDs = append(poolIDs, poolID)
+		}
+	}
+	for _, poolID := range loadBalancer.DefaultPools {
+		add(poolID)
+	}
+	for _, azPools := range loadBalancer.AzPools {
+		for _, poolID := range azPools.Pools {
+			add(poolID)
+		}
+	}
+	if loadBalancer.FallbackPool != nil {
+		add(*loadBalancer.FallbackPool)
+	}
+	return
+}
+
+// JSON returns the graph, with its nodes, edges and issues, as indented JSON.
+func (topology *GlbTopology) JSON() ([]byte, error) {
+	return json.MarshalIndent(struct {
+		Nodes  []GlbTopologyNode  `json:"nodes"`
+		Edges  []GlbTopologyEdge  `json:"edges"`
+		Issues []GlbTopologyIssue `json:"issues"`
+	}{topology.Nodes, topology.Edges, topology.Issues}, "", "  ")
+}
+
+// DOT returns the graph in the Graphviz DOT language. Disabled objects are drawn dashed and dangling references red.
+func (topology *GlbTopology) DOT() string {
+	shapes := map[string]string{
+		GlbTopologyNode_Kind_LoadBalancer: "box",
+		GlbTopologyNode_Kind_Pool:         "ellipse",
+		GlbTopologyNode_Kind_Monitor:      "diamond",
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph glb {\n\trankdir=LR;\n")
+	nodes := append([]GlbTopologyNode{}, topology.Nodes...)
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Key < nodes[j].Key })
+	for _, node := range nodes {
+		attributes := []string{"label=" + strconv.Quote(nodeName(node)), "shape=" + shapes[node.Kind]}
+		if !node.Enabled {
+			attributes = append(attributes, "style=dashed")
+		}
+		if node.Dangling {
+			attributes = append(attributes, "color=red")
+		}
+		fmt.Fprintf(&buffer, "\t%s [%s];\n", strconv.Quote(node.Key), strings.Join(attributes, ", "))
+	}
+	for _, edge := range topology.Edges {
+		label := edge.Kind
+		if edge.Label != "" {
+			label += " " + edge.Label
+		}
+		fmt.Fprintf(&buffer, "\t%s -> %s [label=%s];\n", strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(label))
+	}
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+func (topology *GlbTopology) addIssue(kind string, node string, format string, args ...interface{}) {
+	topology.Issues = append(topology.Issues, GlbTopologyIssue{Kind: kind, Node: node, Message: fmt.Sprintf(format, args...)})
+}
+
+func splitNodeKey(key string) (kind string, id string) {
+	pos := strings.Index(key, "/")
+	return key[:pos], key[pos+1:]
+}
+
+func nodeName(node GlbTopologyNode) string {
+	if node.Name != "" {
+		return node.Name
+	}
+	return node.ID
+}
+
+func boolValue(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`GLB topology`, func() {
+	var fake *fakednssvcs.Server
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		fake.Add("/instances/instance/dnszones/zone/load_balancers", map[string]interface{}{
+			"id": "glb-web", "name": "web.example.com", "enabled": true,
+			"default_pools": []string{"pool-east", "pool-gone"}, "fallback_pool": "pool-east",
+			"az_pools": []map[string]interface{}{{"availability_zone": "us-south-1", "pools": []string{"pool-south"}}},
+		})
+		fake.Add("/instances/instance/dnszones/zone/load_balancers", map[string]interface{}{
+			"id": "glb-api", "name": "api.example.com", "enabled": true,
+			"default_pools": []string{"pool-off"}, "fallback_pool": "pool-off",
+		})
+		fake.Add("/instances/instance/pools", map[string]interface{}{"id": "pool-east", "name": "east", "enabled": true, "monitor": "monitor-http"})
+		fake.Add("/instances/instance/pools", map[string]interface{}{"id": "pool-south", "name": "south", "enabled": true, "monitor": "monitor-gone"})
+		fake.Add("/instances/instance/pools", map[string]interface{}{"id": "pool-off", "name": "off", "enabled": false})
+		fake.Add("/instances/instance/pools", map[string]interface{}{"id": "pool-spare", "name": "spare", "enabled": true})
+		fake.Add("/instances/instance/monitors", map[string]interface{}{"id": "monitor-http", "name": "http"})
+		fake.Add("/instances/instance/monitors", map[string]interface{}{"id": "monitor-tcp", "name": "tcp"})
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke GetGlbTopology successfully`, func() {
+		topology, err := fake.Service().GetGlbTopology("instance")
+		Expect(err).To(BeNil())
+		Expect(topology.LoadBalancers).To(HaveLen(2))
+		Expect(topology.LoadBalancers[0].DnszoneID).To(Equal("zone"))
+
+		issues := map[string][]string{}
+		for _, issue := range topology.Issues {
+			issues[issue.Kind] = append(issues[issue.Kind], issue.Node)
+		}
+		Expect(issues).To(Equal(map[string][]string{
+			dnssvcsv1.GlbTopologyIssue_Kind_FallbackPoolIsDefault: {"load_balancer/glb-web", "load_balancer/glb-api"},
+			dnssvcsv1.GlbTopologyIssue_Kind_DanglingPool:          {"load_balancer/glb-web"},
+			dnssvcsv1.GlbTopologyIssue_Kind_DanglingMonitor:       {"pool/pool-south"},
+			dnssvcsv1.GlbTopologyIssue_Kind_UnusedPool:            {"pool/pool-spare"},
+			dnssvcsv1.GlbTopologyIssue_Kind_UnusedMonitor:         {"monitor/monitor-tcp"},
+			dnssvcsv1.GlbTopologyIssue_Kind_DisabledOnlyRoute:     {"load_balancer/glb-api"},
+		}))
+		Expect(topology.PoolsOf(topology.LoadBalancers[0].LoadBalancer)).To(Equal([]string{"pool-east", "pool-gone", "pool-south"}))
+
+		dot := topology.DOT()
+		Expect(dot).To(HavePrefix("digraph glb {"))
+		Expect(dot).To(ContainSubstring(`"load_balancer/glb-web" -> "pool/pool-gone" [label="default_pool 1"];`))
+		Expect(dot).To(ContainSubstring(`"pool/pool-gone" [label="pool-gone", shape=ellipse, style=dashed, color=red];`))
+		Expect(dot).To(ContainSubstring(`"pool/pool-off" [label="off", shape=ellipse, style=dashed];`))
+
+		data, err := topology.JSON()
+		Expect(err).To(BeNil())
+		var graph map[string][]map[string]interface{}
+		Expect(json.Unmarshal(data, &graph)).To(Succeed())
+		Expect(graph["nodes"]).To(HaveLen(10))
+		Expect(graph["edges"]).To(HaveLen(8))
+		Expect(graph["issues"]).To(HaveLen(7))
+	})
+	It(`Invoke GetGlbTopology with error`, func() {
+		fake.Failures["GET /instances/instance/pools"] = 500
+		topology, err := fake.Service().GetGlbTopology("instance")
+		Expect(err).ToNot(BeNil())
+		Expect(topology).To(BeNil())
+	})
+})
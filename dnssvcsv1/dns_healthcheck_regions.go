@@ -0,0 +1,133 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	common "github.com/IBM/dns-svcs-go-sdk/common"
+)
+
+// healthcheckRegions is the registry of the regions pools can run health checks from.
+var healthcheckRegions = struct {
+	sync.RWMutex
+	names map[string]bool
+}{
+	names: map[string]bool{
+		Pool_HealthcheckRegion_AuSyd:   true,
+		Pool_HealthcheckRegion_EuDu:    true,
+		Pool_HealthcheckRegion_EuGb:    true,
+		Pool_HealthcheckRegion_JpTok:   true,
+		Pool_HealthcheckRegion_UsEast:  true,
+		Pool_HealthcheckRegion_UsSouth: true,
+	},
+}
+
+// subnetIDPattern matches VPC subnet IDs: a four character zone prefix followed by a UUID.
+var subnetIDPattern = regexp.MustCompile(`^[0-9a-z]{4}-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// zoneSuffixPattern matches the zone number of a VPC zone name such as us-south-1.
+var zoneSuffixPattern = regexp.MustCompile(`-[0-9]+$`)
+
+// RegisterHealthcheckRegion adds a region to the regions pools can run health checks from, for regions the service
+// supports that have no Pool_HealthcheckRegion_ constant yet.
+func RegisterHealthcheckRegion(region string) {
+	healthcheckRegions.Lock()
+	defer healthcheckRegions.Unlock()
+	healthcheckRegions.names[strings.ToLower(region)] = true
+}
+
+// IsHealthcheckRegion reports whether a region is registered.
+func IsHealthcheckRegion(region string) bool {
+	healthcheckRegions.RLock()
+	defer healthcheckRegions.RUnlock()
+	return healthcheckRegions.names[strings.ToLower(region)]
+}
+
+// HealthcheckRegions returns the registered regions, sorted.
+func HealthcheckRegions() []string {
+	healthcheckRegions.RLock()
+	defer healthcheckRegions.RUnlock()
+	regions := make([]string, 0, len(healthcheckRegions.names))
+	for region := range healthcheckRegions.names {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// ValidateHealthcheckSettings checks the complete health check region and subnets of a pool: the region must be
+// registered, subnets are required whenever a region is set, and every subnet must be a VPC subnet ID listed once.
+// CreatePool and UpdatePool do not call it, as an update may change the region of a pool alone.
+func ValidateHealthcheckSettings(region *string, subnets []string) error {
+	if region != nil {
+		if !IsHealthcheckRegion(*region) {
+			return fmt.Errorf("health check region %s is not one of %s", *region, strings.Join(HealthcheckRegions(), ", "))
+		}
+		if len(subnets) == 0 {
+			return fmt.Errorf("health check region %s requires health check subnets", *region)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, subnet := range subnets {
+		if strings.HasPrefix(subnet, "crn:") {
+			return fmt.Errorf("health check subnet %s is a CRN, use HealthcheckSettingsFromSubnetCrns to get the subnet ID", subnet)
+		}
+		if !subnetIDPattern.MatchString(strings.ToLower(subnet)) {
+			return fmt.Errorf("health check subnet %s is not a VPC subnet ID", subnet)
+		}
+		if seen[strings.ToLower(subnet)] {
+			return fmt.Errorf("health check subnet %s is listed twice", subnet)
+		}
+		seen[strings.ToLower(subnet)] = true
+	}
+	return nil
+}
+
+// HealthcheckSettingsFromSubnetCrns derives the health check region and subnet IDs of a pool from the CRNs of VPC
+// subnets, for example "crn:v1:bluemix:public:is:us-south-1:a/1234::subnet:0716-a4c0c123-594c-4ef4-ace3-a08858540b5e".
+// The subnets must all be in one registered region.
+func HealthcheckSettingsFromSubnetCrns(subnetCrns []string) (region string, subnets []string, err error) {
+	if len(subnetCrns) == 0 {
+		err = fmt.Errorf("no subnet CRNs given")
+		return
+	}
+	for _, subnetCrn := range subnetCrns {
+		parsed, parseErr := common.ParseSubnetCrn(subnetCrn)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		subnetRegion := zoneSuffixPattern.ReplaceAllString(strings.ToLower(parsed.Region), "")
+		if region == "" {
+			region = subnetRegion
+		} else if region != subnetRegion {
+			err = fmt.Errorf("subnets %s and %s are in different regions, %s and %s", subnetCrns[0], subnetCrn, region, subnetRegion)
+			return
+		}
+		subnets = append(subnets, parsed.ResourceID)
+	}
+	err = ValidateHealthcheckSettings(&region, subnets)
+	if err != nil {
+		region, subnets = "", nil
+	}
+	return
+}
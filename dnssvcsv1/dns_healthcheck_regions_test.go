@@ -0,0 +1,85 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Health check regions`, func() {
+	const subnetID = "0716-a4c0c123-594c-4ef4-ace3-a08858540b5e"
+	It(`Invoke RegisterHealthcheckRegion successfully`, func() {
+		Expect(dnssvcsv1.IsHealthcheckRegion("US-South")).To(BeTrue())
+		Expect(dnssvcsv1.IsHealthcheckRegion("br-sao")).To(BeFalse())
+		dnssvcsv1.RegisterHealthcheckRegion("br-sao")
+		Expect(dnssvcsv1.IsHealthcheckRegion("br-sao")).To(BeTrue())
+		Expect(dnssvcsv1.HealthcheckRegions()).To(ContainElement("br-sao"))
+	})
+	It(`Invoke ValidateHealthcheckSettings successfully`, func() {
+		Expect(dnssvcsv1.ValidateHealthcheckSettings(nil, nil)).To(Succeed())
+		Expect(dnssvcsv1.ValidateHealthcheckSettings(core.StringPtr("us-south"), []string{subnetID})).To(Succeed())
+		Expect(dnssvcsv1.ValidateHealthcheckSettings(nil, []string{subnetID})).To(Succeed())
+	})
+	It(`Invoke ValidateHealthcheckSettings with error`, func() {
+		err := dnssvcsv1.ValidateHealthcheckSettings(core.StringPtr("mars-north"), []string{subnetID})
+		Expect(err.Error()).To(ContainSubstring("health check region mars-north is not one of"))
+		err = dnssvcsv1.ValidateHealthcheckSettings(core.StringPtr("us-south"), nil)
+		Expect(err.Error()).To(ContainSubstring("requires health check subnets"))
+		err = dnssvcsv1.ValidateHealthcheckSettings(core.StringPtr("us-south"), []string{"subnet-1"})
+		Expect(err.Error()).To(ContainSubstring("is not a VPC subnet ID"))
+		err = dnssvcsv1.ValidateHealthcheckSettings(core.StringPtr("us-south"), []string{subnetID, subnetID})
+		Expect(err.Error()).To(ContainSubstring("is listed twice"))
+		err = dnssvcsv1.ValidateHealthcheckSettings(core.StringPtr("us-south"), []string{"crn:v1:bluemix:public:is:us-south-1:a/1234::subnet:" + subnetID})
+		Expect(err.Error()).To(ContainSubstring("is a CRN"))
+	})
+	It(`Invoke UpdatePool changing only the health check region`, func() {
+		fake := fakednssvcs.NewServer()
+		defer fake.Close()
+		fake.Add("/instances/instance/pools", map[string]interface{}{"id": "pool", "name": "web", "healthcheck_region": "us-south", "healthcheck_subnets": []interface{}{subnetID}})
+		testService := fake.Service()
+		pool, _, err := testService.UpdatePool(testService.NewUpdatePoolOptions("instance", "pool").SetHealthcheckRegion("us-east"))
+		Expect(err).To(BeNil())
+		Expect(*pool.HealthcheckRegion).To(Equal("us-east"))
+		Expect(pool.HealthcheckSubnets).To(Equal([]string{subnetID}))
+	})
+	It(`Invoke HealthcheckSettingsFromSubnetCrns successfully`, func() {
+		region, subnets, err := dnssvcsv1.HealthcheckSettingsFromSubnetCrns([]string{
+			"crn:v1:bluemix:public:is:us-south-1:a/1234::subnet:" + subnetID,
+			"crn:v1:bluemix:public:is:us-south-2:a/1234::subnet:0726-b49ef064-0f89-4fb1-8212-135b12568f04",
+		})
+		Expect(err).To(BeNil())
+		Expect(region).To(Equal("us-south"))
+		Expect(subnets).To(Equal([]string{subnetID, "0726-b49ef064-0f89-4fb1-8212-135b12568f04"}))
+	})
+	It(`Invoke HealthcheckSettingsFromSubnetCrns with error`, func() {
+		_, _, err := dnssvcsv1.HealthcheckSettingsFromSubnetCrns([]string{
+			"crn:v1:bluemix:public:is:us-south-1:a/1234::subnet:" + subnetID,
+			"crn:v1:bluemix:public:is:eu-gb-1:a/1234::subnet:0726-b49ef064-0f89-4fb1-8212-135b12568f04",
+		})
+		Expect(err.Error()).To(ContainSubstring("in different regions, us-south and eu-gb"))
+		_, _, err = dnssvcsv1.HealthcheckSettingsFromSubnetCrns([]string{"crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-1234"})
+		Expect(err.Error()).To(ContainSubstring("is not the CRN of a VPC subnet"))
+		_, _, err = dnssvcsv1.HealthcheckSettingsFromSubnetCrns([]string{"crn:v1:bluemix:public:is:ca-tor-1:a/1234::subnet:" + subnetID})
+		Expect(err.Error()).To(ContainSubstring("health check region ca-tor is not one of"))
+		_, _, err = dnssvcsv1.HealthcheckSettingsFromSubnetCrns(nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,674 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// InstanceBackupVersion is the version of the InstanceBackup archive format written by BackupInstance. Archives of
+// later versions are rejected by ParseInstanceBackup.
+const InstanceBackupVersion = 1
+
+// InstanceBackup : A point-in-time copy of the DNS zones, resource records, permitted networks, monitors, pools and
+// load balancers of a service instance. Objects keep the IDs they had in the instance; RestoreInstance maps them to
+// the IDs of the objects it creates.
+type InstanceBackup struct {
+	// Version of the archive format.
+	Version int `json:"version"`
+
+	// The unique identifier of the service instance backed up.
+	InstanceID string `json:"instance_id"`
+
+	// When the backup was taken.
+	CreatedAt time.Time `json:"created_at"`
+
+	// The DNS zones with the objects they hold.
+	Dnszones []InstanceBackupDnszone `json:"dnszones"`
+
+	// The load balancer monitors.
+	Monitors []Monitor `json:"monitors"`
+
+	// The load balancer pools.
+	Pools []Pool `json:"pools"`
+}
+
+// InstanceBackupDnszone : A DNS zone of an InstanceBackup.
+type InstanceBackupDnszone struct {
+	// The DNS zone.
+	Dnszone Dnszone `json:"dnszone"`
+
+	// The resource records of the DNS zone.
+	ResourceRecords []ResourceRecord `json:"resource_records"`
+
+	// The permitted networks of the DNS zone.
+	PermittedNetworks []PermittedNetwork `json:"permitted_networks"`
+
+	// The load balancers of the DNS zone.
+	LoadBalancers []LoadBalancer `json:"load_balancers"`
+}
+
+// BackupInstance : Back up a service instance
+// Read every DNS zone of a service instance with its resource records, permitted networks and load balancers, and the
+// monitors and pools of the instance. As load balancers, monitors and pools cannot be listed page by page, an error is
+// returned when the service lists fewer of them than it reports.
+func (dnsSvcs *DnsSvcsV1) BackupInstance(instanceID string) (backup *InstanceBackup, err error) {
+	createdAt := time.Now().UTC()
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID))
+	if err != nil {
+		return
+	}
+	backup = &InstanceBackup{
+		Version:    InstanceBackupVersion,
+		InstanceID: instanceID,
+		CreatedAt:  createdAt,
+		Dnszones:   []InstanceBackupDnszone{},
+	}
+	for _, dnszone := range dnszones {
+		item := InstanceBackupDnszone{Dnszone: dnszone}
+		item.ResourceRecords, err = dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(instanceID, *dnszone.ID))
+		if err != nil {
+			return nil, err
+		}
+		item.PermittedNetworks, err = dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(instanceID, *dnszone.ID))
+		if err != nil {
+			return nil, err
+		}
+		loadBalancers, _, listErr := dnsSvcs.ListLoadBalancers(dnsSvcs.NewListLoadBalancersOptions(instanceID, *dnszone.ID))
+		if listErr == nil {
+			listErr = checkListComplete("load balancers", len(loadBalancers.LoadBalancers), loadBalancers.TotalCount)
+		}
+		if listErr != nil {
+			return nil, listErr
+		}
+		item.LoadBalancers = loadBalancers.LoadBalancers
+		backup.Dnszones = append(backup.Dnszones, item)
+	}
+	monitors, _, err := dnsSvcs.ListMonitors(dnsSvcs.NewListMonitorsOptions(instanceID))
+	if err == nil {
+		err = checkListComplete("monitors", len(monitors.Monitors), monitors.TotalCount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	backup.Monitors = monitors.Monitors
+	pools, _, err := dnsSvcs.ListPools(dnsSvcs.NewListPoolsOptions(instanceID))
+	if err == nil {
+		err = checkListComplete("pools", len(pools.Pools), pools.TotalCount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	backup.Pools = pools.Pools
+	return
+}
+
+// checkListComplete fails when a list of load balancers, monitors or pools, which cannot be paged through, holds
+// fewer objects than the service reports, so that a backup or restore never silently misses any.
+func checkListComplete(kind string, listed int, totalCount *int64) error {
+	if totalCount != nil && *totalCount > int64(listed) {
+		return fmt.Errorf("only %d of the %d %s were listed", listed, *totalCount, kind)
+	}
+	return nil
+}
+
+// JSON returns the backup as an indented JSON archive.
+func (backup *InstanceBackup) JSON() ([]byte, error) {
+	return json.MarshalIndent(backup, "", "  ")
+}
+
+// ParseInstanceBackup parses a JSON archive written by InstanceBackup.JSON. Archives without a version, or of a
+// version later than InstanceBackupVersion, are rejected.
+func ParseInstanceBackup(data []byte) (backup *InstanceBackup, err error) {
+	err = json.Unmarshal(data, &backup)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil || backup.Version < 1 || backup.Version > InstanceBackupVersion {
+		return nil, fmt.Errorf("unsupported instance backup version %d, expected %d", versionOf(backup), InstanceBackupVersion)
+	}
+	return
+}
+
+func versionOf(backup *InstanceBackup) int {
+	if backup == nil {
+		return 0
+	}
+	return backup.Version
+}
+
+// RestoreInstanceOptions : The RestoreInstance options.
+type RestoreInstanceOptions struct {
+	// The unique identifier of the service instance to restore into, the instance backed up or another one.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The backup to restore.
+	Backup *InstanceBackup `json:"backup" validate:"required"`
+
+	// Called after every object restored, skipped or failed.
+	Progress func(RestoreInstanceStep) `json:"-"`
+}
+
+// NewRestoreInstanceOptions : Instantiate RestoreInstanceOptions
+func (*DnsSvcsV1) NewRestoreInstanceOptions(instanceID string, backup *InstanceBackup) *RestoreInstanceOptions {
+	return &RestoreInstanceOptions{
+		InstanceID: core.StringPtr(instanceID),
+		Backup:     backup,
+	}
+}
+
+// SetProgress : Allow user to set Progress
+func (options *RestoreInstanceOptions) SetProgress(progress func(RestoreInstanceStep)) *RestoreInstanceOptions {
+	options.Progress = progress
+	return options
+}
+
+// RestoreInstanceStep : An object of the backup handled by RestoreInstance.
+type RestoreInstanceStep struct {
+	// The kind of object.
+	Kind string `json:"kind"`
+
+	// Name of the object: the name of a DNS zone, monitor, pool or load balancer, "<type> <name>" of a resource
+	// record, or the VPC CRN of a permitted network.
+	Name string `json:"name"`
+
+	// The unique identifier of the object in the backup.
+	ID string `json:"id,omitempty"`
+
+	// Name of the DNS zone of the object, for resource records, permitted networks and load balancers.
+	Dnszone string `json:"dnszone,omitempty"`
+
+	// Whether the object already existed in the instance, or is managed by the service, and was left as it is.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// The error restoring the object.
+	Err error `json:"-"`
+
+	// What the restored object was left without, such as the pools of a load balancer that were not restored.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Constants associated with the RestoreInstanceStep.Kind property.
+// The kind of object.
+const (
+	RestoreInstanceStep_Kind_Dnszone          = "dnszone"
+	RestoreInstanceStep_Kind_LoadBalancer     = "load_balancer"
+	RestoreInstanceStep_Kind_Monitor          = "monitor"
+	RestoreInstanceStep_Kind_PermittedNetwork = "permitted_network"
+	RestoreInstanceStep_Kind_Pool             = "pool"
+	RestoreInstanceStep_Kind_ResourceRecord   = "resource_record"
+)
+
+// String describes the step, for example "pool web (id 1234): restored".
+func (step RestoreInstanceStep) String() string {
+	text := step.Kind + " " + step.Name
+	if step.Dnszone != "" {
+		text += " in " + step.Dnszone
+	}
+	if step.ID != "" {
+		text += " (id " + step.ID + ")"
+	}
+	switch {
+	case step.Err != nil:
+		return text + ": " + step.Err.Error()
+	case step.Skipped:
+		return text + ": skipped"
+	case len(step.Warnings) > 0:
+		return text + ": restored, but " + strings.Join(step.Warnings, "; ")
+	}
+	return text + ": restored"
+}
+
+// MarshalJSON encodes the step with the message of its error as the error property.
+func (step RestoreInstanceStep) MarshalJSON() ([]byte, error) {
+	type plainStep RestoreInstanceStep
+	encoded := struct {
+		plainStep
+		Error string `json:"error,omitempty"`
+	}{plainStep: plainStep(step)}
+	if step.Err != nil {
+		encoded.Error = step.Err.Error()
+	}
+	return json.Marshal(encoded)
+}
+
+// RestoreInstanceResult : Outcome of RestoreInstance.
+type RestoreInstanceResult struct {
+	// The unique identifiers of the DNS zones of the backup mapped to those in the instance.
+	DnszoneIDs map[string]string `json:"dnszone_ids"`
+
+	// The unique identifiers of the monitors of the backup mapped to those in the instance.
+	MonitorIDs map[string]string `json:"monitor_ids"`
+
+	// The unique identifiers of the pools of the backup mapped to those in the instance.
+	PoolIDs map[string]string `json:"pool_ids"`
+
+	// Number of objects created.
+	Restored int `json:"restored"`
+
+	// Number of objects already present in the instance, or managed by the service.
+	Skipped int `json:"skipped"`
+
+	// The objects that could not be restored.
+	Failures []RestoreInstanceStep `json:"failures"`
+
+	// The objects restored without some of what they reference, as told by the Warnings of each step.
+	Partial []RestoreInstanceStep `json:"partial"`
+}
+
+// RestoreInstance : Restore a service instance from a backup
+// Recreate the objects of a backup in a service instance: monitors, then pools with the IDs of the restored monitors,
+// then DNS zones with their resource records and permitted networks, then load balancers with the IDs of the restored
+// pools. Objects already present in the instance, found by name, are used as they are rather than updated, so that
+// restoring again resumes a restore that failed part way. An object referencing one that could not be restored fails
+// too; load balancers only lose references to pools that failed as long as they keep a fallback and a default pool,
+// and are then listed as partial restores with the pools they lost. An error is returned when any object could not be
+// restored; the result then lists them.
+func (dnsSvcs *DnsSvcsV1) RestoreInstance(restoreInstanceOptions *RestoreInstanceOptions) (result *RestoreInstanceResult, err error) {
+	err = core.ValidateNotNil(restoreInstanceOptions, "restoreInstanceOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(restoreInstanceOptions, "restoreInstanceOptions")
+	if err != nil {
+		return
+	}
+	backup := restoreInstanceOptions.Backup
+	if backup.Version < 1 || backup.Version > InstanceBackupVersion {
+		err = fmt.Errorf("unsupported instance backup version %d, expected %d", backup.Version, InstanceBackupVersion)
+		return
+	}
+	restore := &instanceRestore{
+		dnsSvcs:    dnsSvcs,
+		instanceID: *restoreInstanceOptions.InstanceID,
+		progress:   restoreInstanceOptions.Progress,
+		result: &RestoreInstanceResult{
+			DnszoneIDs: make(map[string]string),
+			MonitorIDs: make(map[string]string),
+			PoolIDs:    make(map[string]string),
+		},
+	}
+	result = restore.result
+
+	err = restore.monitors(backup.Monitors)
+	if err == nil {
+		err = restore.pools(backup.Pools)
+	}
+	if err == nil {
+		err = restore.dnszones(backup.Dnszones)
+	}
+	if err == nil && len(result.Failures) > 0 {
+		err = fmt.Errorf("%d objects could not be restored to instance %s, the first: %s", len(result.Failures), restore.instanceID, result.Failures[0].String())
+	}
+	return
+}
+
+// instanceRestore holds the state of a RestoreInstance workflow.
+type instanceRestore struct {
+	dnsSvcs    *DnsSvcsV1
+	instanceID string
+	progress   func(RestoreInstanceStep)
+	result     *RestoreInstanceResult
+}
+
+func (restore *instanceRestore) report(step RestoreInstanceStep) {
+	switch {
+	case step.Err != nil:
+		restore.result.Failures = append(restore.result.Failures, step)
+	case step.Skipped:
+		restore.result.Skipped++
+	default:
+		restore.result.Restored++
+		if len(step.Warnings) > 0 {
+			restore.result.Partial = append(restore.result.Partial, step)
+		}
+	}
+	if restore.progress != nil {
+		restore.progress(step)
+	}
+}
+
+func (restore *instanceRestore) monitors(monitors []Monitor) error {
+	dnsSvcs := restore.dnsSvcs
+	existing, _, err := dnsSvcs.ListMonitors(dnsSvcs.NewListMonitorsOptions(restore.instanceID))
+	if err == nil {
+		err = checkListComplete("monitors", len(existing.Monitors), existing.TotalCount)
+	}
+	if err != nil {
+		return err
+	}
+	idsByName := make(map[string]string)
+	for _, monitor := range existing.Monitors {
+		idsByName[strings.ToLower(stringValue(monitor.Name, ""))] = stringValue(monitor.ID, "")
+	}
+	for _, monitor := range monitors {
+		step := RestoreInstanceStep{Kind: RestoreInstanceStep_Kind_Monitor, Name: stringValue(monitor.Name, ""), ID: stringValue(monitor.ID, "")}
+		if id, found := idsByName[strings.ToLower(step.Name)]; found && step.Name != "" {
+			restore.result.MonitorIDs[step.ID] = id
+			step.Skipped = true
+			restore.report(step)
+			continue
+		}
+		options := &CreateMonitorOptions{
+			InstanceID:      core.StringPtr(restore.instanceID),
+			Name:            monitor.Name,
+			Description:     monitor.Description,
+			Type:            monitor.Type,
+			Port:            monitor.Port,
+			Interval:        monitor.Interval,
+			Retries:         monitor.Retries,
+			Timeout:         monitor.Timeout,
+			Method:          monitor.Method,
+			Path:            monitor.Path,
+			HeadersVar:      monitor.HeadersVar,
+			AllowInsecure:   monitor.AllowInsecure,
+			ExpectedCodes:   monitor.ExpectedCodes,
+			ExpectedBody:    monitor.ExpectedBody,
+			FollowRedirects: monitor.FollowRedirects,
+		}
+		var created *Monitor
+		created, _, step.Err = dnsSvcs.CreateMonitor(options)
+		if step.Err == nil {
+			restore.result.MonitorIDs[step.ID] = stringValue(created.ID, "")
+		}
+		restore.report(step)
+	}
+	return nil
+}
+
+func (restore *instanceRestore) pools(pools []Pool) error {
+	dnsSvcs := restore.dnsSvcs
+	existing, _, err := dnsSvcs.ListPools(dnsSvcs.NewListPoolsOptions(restore.instanceID))
+	if err == nil {
+		err = checkListComplete("pools", len(existing.Pools), existing.TotalCount)
+	}
+	if err != nil {
+		return err
+	}
+	idsByName := make(map[string]string)
+	for _, pool := range existing.Pools {
+		idsByName[strings.ToLower(stringValue(pool.Name, ""))] = stringValue(pool.ID, "")
+	}
+	for _, pool := range pools {
+		step := RestoreInstanceStep{Kind: RestoreInstanceStep_Kind_Pool, Name: stringValue(pool.Name, ""), ID: stringValue(pool.ID, "")}
+		if id, found := idsByName[strings.ToLower(step.Name)]; found && step.Name != "" {
+			restore.result.PoolIDs[step.ID] = id
+			step.Skipped = true
+			restore.report(step)
+			continue
+		}
+		options := &CreatePoolOptions{
+			InstanceID:              core.StringPtr(restore.instanceID),
+			Name:                    pool.Name,
+			Description:             pool.Description,
+			Enabled:                 pool.Enabled,
+			HealthyOriginsThreshold: pool.HealthyOriginsThreshold,
+			NotificationChannel:     pool.NotificationChannel,
+			HealthcheckRegion:       pool.HealthcheckRegion,
+			HealthcheckSubnets:      pool.HealthcheckSubnets,
+		}
+		step.Err = convertOptions(pool.Origins, &options.Origins)
+		if pool.Monitor != nil && step.Err == nil {
+			monitorID, found := restore.result.MonitorIDs[*pool.Monitor]
+			if !found {
+				step.Err = fmt.Errorf("monitor %s was not restored", *pool.Monitor)
+			}
+			options.Monitor = core.StringPtr(monitorID)
+		}
+		if step.Err == nil {
+			var created *Pool
+			created, _, step.Err = dnsSvcs.CreatePool(options)
+			if step.Err == nil {
+				restore.result.PoolIDs[step.ID] = stringValue(created.ID, "")
+			}
+		}
+		restore.report(step)
+	}
+	return nil
+}
+
+func (restore *instanceRestore) dnszones(dnszones []InstanceBackupDnszone) error {
+	dnsSvcs := restore.dnsSvcs
+	for i := range dnszones {
+		item := &dnszones[i]
+		name := stringValue(item.Dnszone.Name, "")
+		step := RestoreInstanceStep{Kind: RestoreInstanceStep_Kind_Dnszone, Name: name, ID: stringValue(item.Dnszone.ID, "")}
+		existing, err := dnsSvcs.findDnszone(restore.instanceID, name)
+		if err != nil {
+			return err
+		}
+		dnszone := existing
+		if existing != nil {
+			step.Skipped = true
+		} else {
+			createOptions := dnsSvcs.NewCreateDnszoneOptions(restore.instanceID, name)
+			createOptions.Description = item.Dnszone.Description
+			createOptions.Label = item.Dnszone.Label
+			dnszone, _, step.Err = dnsSvcs.CreateDnszone(createOptions)
+		}
+		restore.report(step)
+		if step.Err != nil {
+			restore.dependentsFailed(item, step.Err)
+			continue
+		}
+		dnszoneID := stringValue(dnszone.ID, "")
+		restore.result.DnszoneIDs[step.ID] = dnszoneID
+
+		err = restore.resourceRecords(item, dnszoneID)
+		if err == nil {
+			err = restore.permittedNetworks(item, dnszoneID)
+		}
+		if err == nil {
+			err = restore.loadBalancers(item, dnszoneID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependentsFailed reports the objects of a DNS zone that could not be restored as failed.
+func (restore *instanceRestore) dependentsFailed(item *InstanceBackupDnszone, err error) {
+	dnszone := stringValue(item.Dnszone.Name, "")
+	err = fmt.Errorf("DNS zone %s was not restored: %s", dnszone, err.Error())
+	for _, record := range item.ResourceRecords {
+		if isCreatableRecordType(record.Type) {
+			restore.report(RestoreInstanceStep{Kind: RestoreInstanceStep_Kind_ResourceRecord, Name: stringValue(record.Type, "") + " " + stringValue(record.Name, ""), ID: stringValue(record.ID, ""), Dnszone: dnszone, Err: err})
+		}
+	}
+	for _, network := range item.PermittedNetworks {
+		restore.report(RestoreInstanceStep{Kind: RestoreInstanceStep_Kind_PermittedNetwork, Name: permittedNetworkVpcCrn(&network), ID: stringValue(network.ID, ""), Dnszone: dnszone, Err: err})
+	}
+	for _, loadBalancer := range item.LoadBalancers {
+		restore.report(RestoreInstanceStep{Kind: RestoreInstanceStep_Kind_LoadBalancer, Name: stringValue(loadBalancer.Name, ""), ID: stringValue(loadBalancer.ID, ""), Dnszone: dnszone, Err: err})
+	}
+}
+
+func (restore *instanceRestore) resourceRecords(item *InstanceBackupDnszone, dnszoneID string) error {
+	dnsSvcs := restore.dnsSvcs
+	name := stringValue(item.Dnszone.Name, "")
+	existing, err := dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(restore.instanceID, dnszoneID))
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool)
+	for i := range existing {
+		keys[resourceRecordKey(&existing[i], name, name)] = true
+	}
+	for i := range item.ResourceRecords {
+		record := item.ResourceRecords[i]
+		step := RestoreInstanceStep{
+			Kind:    RestoreInstanceStep_Kind_ResourceRecord,
+			Name:    stringValue(record.Type, "") + " " + stringValue(record.Name, ""),
+			ID:      stringValue(record.ID, ""),
+			Dnszone: name,
+		}
+		if !isCreatableRecordType(record.Type) || keys[resourceRecordKey(&record, name, name)] {
+			step.Skipped = true
+			restore.report(step)
+			continue
+		}
+		var createOptions *CreateResourceRecordOptions
+		createOptions, step.Err = newCreateResourceRecordOptionsFromRecord(restore.instanceID, dnszoneID, &record)
+		if step.Err == nil {
+			_, _, step.Err = dnsSvcs.CreateResourceRecord(createOptions)
+		}
+		restore.report(step)
+	}
+	return nil
+}
+
+func (restore *instanceRestore) permittedNetworks(item *InstanceBackupDnszone, dnszoneID string) error {
+	dnsSvcs := restore.dnsSvcs
+	existing, err := dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(restore.instanceID, dnszoneID))
+	if err != nil {
+		return err
+	}
+	vpcCrns := make(map[string]bool)
+	for i := range existing {
+		vpcCrns[permittedNetworkVpcCrn(&existing[i])] = true
+	}
+	for i := range item.PermittedNetworks {
+		network := &item.PermittedNetworks[i]
+		step := RestoreInstanceStep{
+			Kind:    RestoreInstanceStep_Kind_PermittedNetwork,
+			Name:    permittedNetworkVpcCrn(network),
+			ID:      stringValue(network.ID, ""),
+			Dnszone: stringValue(item.Dnszone.Name, ""),
+		}
+		if step.Name == "" || vpcCrns[step.Name] {
+			step.Skipped = true
+			restore.report(step)
+			continue
+		}
+		createOptions := dnsSvcs.NewCreatePermittedNetworkOptions(restore.instanceID, dnszoneID)
+		createOptions.SetType(CreatePermittedNetworkOptions_Type_Vpc)
+		createOptions.SetPermittedNetwork(&PermittedNetworkVpc{VpcCrn: core.StringPtr(step.Name)})
+		_, _, step.Err = dnsSvcs.CreatePermittedNetwork(createOptions)
+		restore.report(step)
+	}
+	return nil
+}
+
+func (restore *instanceRestore) loadBalancers(item *InstanceBackupDnszone, dnszoneID string) error {
+	dnsSvcs := restore.dnsSvcs
+	name := stringValue(item.Dnszone.Name, "")
+	existing, _, err := dnsSvcs.ListLoadBalancers(dnsSvcs.NewListLoadBalancersOptions(restore.instanceID, dnszoneID))
+	if err == nil {
+		err = checkListComplete("load balancers", len(existing.LoadBalancers), existing.TotalCount)
+	}
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool)
+	for _, loadBalancer := range existing.LoadBalancers {
+		names[qualifyDomainName(stringValue(loadBalancer.Name, ""), name)] = true
+	}
+	for _, loadBalancer := range item.LoadBalancers {
+		step := RestoreInstanceStep{
+			Kind:    RestoreInstanceStep_Kind_LoadBalancer,
+			Name:    stringValue(loadBalancer.Name, ""),
+			ID:      stringValue(loadBalancer.ID, ""),
+			Dnszone: name,
+		}
+		if names[qualifyDomainName(step.Name, name)] {
+			step.Skipped = true
+			restore.report(step)
+			continue
+		}
+		options := dnsSvcs.NewCreateLoadBalancerOptions(restore.instanceID, dnszoneID)
+		options.Name = loadBalancer.Name
+		options.Description = loadBalancer.Description
+		options.Enabled = loadBalancer.Enabled
+		options.TTL = loadBalancer.TTL
+		var missing []string
+		options.DefaultPools, missing = restore.poolIDs(loadBalancer.DefaultPools)
+		if len(missing) > 0 {
+			step.Warnings = append(step.Warnings, fmt.Sprintf("default pools %s were not restored", strings.Join(missing, ", ")))
+		}
+		for _, azPools := range loadBalancer.AzPools {
+			var poolIDs []string
+			poolIDs, missing = restore.poolIDs(azPools.Pools)
+			if len(missing) > 0 {
+				step.Warnings = append(step.Warnings, fmt.Sprintf("pools %s of availability zone %s were not restored", strings.Join(missing, ", "), stringValue(azPools.AvailabilityZone, "")))
+			}
+			if len(poolIDs) == 0 && len(missing) > 0 {
+				continue
+			}
+			options.AzPools = append(options.AzPools, LoadBalancerAzPoolsItem{
+				AvailabilityZone: azPools.AvailabilityZone,
+				Pools:            poolIDs,
+			})
+		}
+		if loadBalancer.FallbackPool != nil {
+			if poolID, found := restore.result.PoolIDs[*loadBalancer.FallbackPool]; found {
+				options.FallbackPool = core.StringPtr(poolID)
+			} else {
+				step.Err = fmt.Errorf("fallback pool %s was not restored", *loadBalancer.FallbackPool)
+			}
+		}
+		if step.Err == nil && len(loadBalancer.DefaultPools) > 0 && len(options.DefaultPools) == 0 {
+			step.Err = fmt.Errorf("none of the default pools %s were restored", strings.Join(loadBalancer.DefaultPools, ", "))
+		}
+		if step.Err == nil {
+			_, _, step.Err = dnsSvcs.CreateLoadBalancer(options)
+		}
+		restore.report(step)
+	}
+	return nil
+}
+
+// poolIDs maps the pool IDs of the backup to those of the restored pools, leaving out and returning as missing the
+// pools that were not restored.
+func (restore *instanceRestore) poolIDs(backupIDs []string) (ids []string, missing []string) {
+	ids = []string{}
+	for _, backupID := range backupIDs {
+		if id, found := restore.result.PoolIDs[backupID]; found {
+			ids = append(ids, id)
+		} else {
+			missing = append(missing, backupID)
+		}
+	}
+	return
+}
+
+// findDnszone returns the DNS zone of an instance with a name, nil when there is none.
+func (dnsSvcs *DnsSvcsV1) findDnszone(instanceID string, name string) (*Dnszone, error) {
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID))
+	if err != nil {
+		return nil, err
+	}
+	for i := range dnszones {
+		if dnszones[i].Name != nil && normalizeDomainName(*dnszones[i].Name) == normalizeDomainName(name) {
+			return &dnszones[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func permittedNetworkVpcCrn(network *PermittedNetwork) string {
+	if network.PermittedNetwork == nil {
+		return ""
+	}
+	return stringValue(network.PermittedNetwork.VpcCrn, "")
+}
@@ -0,0 +1,167 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Instance backup`, func() {
+	const vpcCrn = "crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-1"
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+		fake.Add("/instances/source/dnszones", map[string]interface{}{"id": "zone", "name": "example.com", "description": "main", "label": "prod"})
+		fake.Add("/instances/source/dnszones/zone/resource_records", map[string]interface{}{"id": "soa", "type": "SOA", "name": "example.com", "rdata": map[string]interface{}{"mname": "ns1.example.com"}})
+		fake.Add("/instances/source/dnszones/zone/resource_records", map[string]interface{}{"id": "www", "type": "A", "name": "www.example.com", "ttl": 300, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		fake.Add("/instances/source/dnszones/zone/resource_records", map[string]interface{}{"id": "sip", "type": "SRV", "name": "_sip._udp.example.com", "service": "_sip", "protocol": "udp", "rdata": map[string]interface{}{"priority": 1, "weight": 5, "port": 5060, "target": "sip.example.com"}})
+		fake.Add("/instances/source/dnszones/zone/permitted_networks", map[string]interface{}{"id": "network", "type": "vpc", "permitted_network": map[string]interface{}{"vpc_crn": vpcCrn}, "state": "ACTIVE"})
+		fake.Add("/instances/source/monitors", map[string]interface{}{"id": "old-monitor", "name": "http", "type": "HTTP", "path": "/health", "headers": []interface{}{map[string]interface{}{"name": "Host", "value": []interface{}{"example.com"}}}})
+		fake.Add("/instances/source/pools", map[string]interface{}{"id": "old-web", "name": "web", "monitor": "old-monitor", "healthcheck_subnets": []interface{}{"0716-a4c3e7b4-2b32-4e4a-9c1e-8d2ad1fbc0ab"}, "origins": []interface{}{map[string]interface{}{"name": "a", "address": "10.1.0.1", "enabled": true}}})
+		fake.Add("/instances/source/pools", map[string]interface{}{"id": "old-spare", "name": "spare", "origins": []interface{}{map[string]interface{}{"name": "b", "address": "10.1.0.2", "enabled": true}}})
+		fake.Add("/instances/source/dnszones/zone/load_balancers", map[string]interface{}{"id": "glb", "name": "glb.example.com", "ttl": 60, "fallback_pool": "old-spare", "default_pools": []interface{}{"old-web", "old-spare"}, "az_pools": []interface{}{map[string]interface{}{"availability_zone": "us-south-1", "pools": []interface{}{"old-web"}}}})
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke BackupInstance and ParseInstanceBackup successfully`, func() {
+		backup, err := testService.BackupInstance("source")
+		Expect(err).To(BeNil())
+		Expect(backup.Version).To(Equal(dnssvcsv1.InstanceBackupVersion))
+		Expect(backup.InstanceID).To(Equal("source"))
+		Expect(backup.Dnszones).To(HaveLen(1))
+		Expect(backup.Dnszones[0].ResourceRecords).To(HaveLen(3))
+		Expect(backup.Dnszones[0].PermittedNetworks).To(HaveLen(1))
+		Expect(backup.Dnszones[0].LoadBalancers).To(HaveLen(1))
+		Expect(backup.Monitors).To(HaveLen(1))
+		Expect(backup.Pools).To(HaveLen(2))
+
+		data, err := backup.JSON()
+		Expect(err).To(BeNil())
+		parsed, err := dnssvcsv1.ParseInstanceBackup(data)
+		Expect(err).To(BeNil())
+		Expect(parsed.CreatedAt.Equal(backup.CreatedAt)).To(BeTrue())
+		Expect(*parsed.Dnszones[0].LoadBalancers[0].FallbackPool).To(Equal("old-spare"))
+
+		for _, invalid := range []string{`{"version": 2}`, `{}`, `null`, `[`} {
+			_, err = dnssvcsv1.ParseInstanceBackup([]byte(invalid))
+			Expect(err).ToNot(BeNil(), invalid)
+		}
+	})
+	It(`Invoke BackupInstance and RestoreInstance with lists missing objects`, func() {
+		fake.PageLimit = 1
+		_, err := testService.BackupInstance("source")
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("only 1 of the 2 pools were listed"))
+
+		fake.PageLimit = 0
+		backup, err := testService.BackupInstance("source")
+		Expect(err).To(BeNil())
+		fake.Add("/instances/target/monitors", map[string]interface{}{"id": "other-monitor", "name": "other", "type": "HTTP"})
+		fake.Add("/instances/target/monitors", map[string]interface{}{"id": "new-monitor", "name": "http", "type": "HTTP"})
+		fake.PageLimit = 1
+		_, err = testService.RestoreInstance(testService.NewRestoreInstanceOptions("target", backup))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("only 1 of the 2 monitors were listed"))
+		Expect(fake.List("/instances/target/monitors")).To(HaveLen(2))
+	})
+	It(`Invoke RestoreInstance into another instance successfully`, func() {
+		backup, err := testService.BackupInstance("source")
+		Expect(err).To(BeNil())
+		var steps []string
+		result, err := testService.RestoreInstance(testService.NewRestoreInstanceOptions("target", backup).SetProgress(func(step dnssvcsv1.RestoreInstanceStep) {
+			steps = append(steps, step.String())
+		}))
+		Expect(err).To(BeNil())
+		Expect(result.Failures).To(BeEmpty())
+		Expect(result.Restored).To(Equal(8))
+		Expect(result.Skipped).To(Equal(1))
+		Expect(steps).To(ContainElement("pool web (id old-web): restored"))
+		Expect(steps).To(ContainElement("resource_record SOA example.com in example.com (id soa): skipped"))
+
+		monitorID := result.MonitorIDs["old-monitor"]
+		webID, spareID := result.PoolIDs["old-web"], result.PoolIDs["old-spare"]
+		Expect(monitorID).ToNot(BeEmpty())
+		Expect(webID).ToNot(Equal("old-web"))
+		Expect(fake.Bodies["POST /instances/target/monitors"]["headers"]).To(HaveLen(1))
+		pools := fake.List("/instances/target/pools")
+		Expect(pools[0]["monitor"]).To(Equal(monitorID))
+		Expect(pools[0]["healthcheck_subnets"]).To(Equal([]interface{}{"0716-a4c3e7b4-2b32-4e4a-9c1e-8d2ad1fbc0ab"}))
+
+		zoneID := result.DnszoneIDs["zone"]
+		zones := fake.List("/instances/target/dnszones")
+		Expect(zones).To(HaveLen(1))
+		Expect(zones[0]["label"]).To(Equal("prod"))
+		Expect(fake.SortedNames("/instances/target/dnszones/" + zoneID + "/resource_records")).To(Equal([]string{"_sip._udp.example.com", "www.example.com"}))
+		Expect(fake.List("/instances/target/dnszones/" + zoneID + "/permitted_networks")[0]["permitted_network"]).To(Equal(map[string]interface{}{"vpc_crn": vpcCrn}))
+		loadBalancer := fake.List("/instances/target/dnszones/" + zoneID + "/load_balancers")[0]
+		Expect(loadBalancer["fallback_pool"]).To(Equal(spareID))
+		Expect(loadBalancer["default_pools"]).To(Equal([]interface{}{webID, spareID}))
+		Expect(loadBalancer["az_pools"]).To(Equal([]interface{}{map[string]interface{}{"availability_zone": "us-south-1", "pools": []interface{}{webID}}}))
+
+		result, err = testService.RestoreInstance(testService.NewRestoreInstanceOptions("target", backup))
+		Expect(err).To(BeNil())
+		Expect(result.Restored).To(Equal(0))
+		Expect(result.Skipped).To(Equal(9))
+		Expect(result.PoolIDs["old-web"]).To(Equal(webID))
+	})
+	It(`Invoke RestoreInstance with failures`, func() {
+		backup, err := testService.BackupInstance("source")
+		Expect(err).To(BeNil())
+		fake.Failures["POST /instances/target/monitors"] = 500
+		fake.Failures["POST /instances/target/dnszones/*/permitted_networks"] = 409
+
+		result, err := testService.RestoreInstance(testService.NewRestoreInstanceOptions("target", backup))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("3 objects could not be restored"))
+		kinds := []string{}
+		for _, failure := range result.Failures {
+			kinds = append(kinds, failure.Kind)
+		}
+		Expect(kinds).To(Equal([]string{
+			dnssvcsv1.RestoreInstanceStep_Kind_Monitor,
+			dnssvcsv1.RestoreInstanceStep_Kind_Pool,
+			dnssvcsv1.RestoreInstanceStep_Kind_PermittedNetwork,
+		}))
+		Expect(result.Failures[1].Err.Error()).To(Equal("monitor old-monitor was not restored"))
+
+		zoneID := result.DnszoneIDs["zone"]
+		loadBalancer := fake.List("/instances/target/dnszones/" + zoneID + "/load_balancers")[0]
+		Expect(loadBalancer["default_pools"]).To(Equal([]interface{}{result.PoolIDs["old-spare"]}))
+		Expect(loadBalancer["az_pools"]).To(BeNil())
+		Expect(result.Partial).To(HaveLen(1))
+		Expect(result.Partial[0].Kind).To(Equal(dnssvcsv1.RestoreInstanceStep_Kind_LoadBalancer))
+		Expect(result.Partial[0].String()).To(Equal("load_balancer glb.example.com in example.com (id glb): restored, but " +
+			"default pools old-web were not restored; pools old-web of availability zone us-south-1 were not restored"))
+
+		fake.Failures["POST /instances/other/dnszones"] = 500
+		result, err = testService.RestoreInstance(testService.NewRestoreInstanceOptions("other", backup))
+		Expect(err).ToNot(BeNil())
+		Expect(result.Failures).To(HaveLen(1 + 2 + 1 + 1))
+
+		backup.Version = 99
+		_, err = testService.RestoreInstance(testService.NewRestoreInstanceOptions("target", backup))
+		Expect(err).ToNot(BeNil())
+		_, err = testService.RestoreInstance(nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
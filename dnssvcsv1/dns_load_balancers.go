@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	common "github.com/IBM/dns-svcs-go-sdk/common"
 	"github.com/IBM/go-sdk-core/v4/core"
@@ -724,6 +726,10 @@ func (dnsSvcs *DnsSvcsV1) CreateMonitor(createMonitorOptions *CreateMonitorOptio
 	if err != nil {
 		return
 	}
+	err = validateHealthcheckHeaders(createMonitorOptions.HeadersVar)
+	if err != nil {
+		return
+	}
 
 	pathSegments := []string{"instances", "monitors"}
 	pathParameters := []string{*createMonitorOptions.InstanceID}
@@ -788,6 +794,9 @@ func (dnsSvcs *DnsSvcsV1) CreateMonitor(createMonitorOptions *CreateMonitorOptio
 	if createMonitorOptions.ExpectedBody != nil {
 		body["expected_body"] = createMonitorOptions.ExpectedBody
 	}
+	if createMonitorOptions.FollowRedirects != nil {
+		body["follow_redirects"] = createMonitorOptions.FollowRedirects
+	}
 	_, err = builder.SetBodyContentJSON(body)
 	if err != nil {
 		return
@@ -919,6 +928,10 @@ func (dnsSvcs *DnsSvcsV1) UpdateMonitor(updateMonitorOptions *UpdateMonitorOptio
 	if err != nil {
 		return
 	}
+	err = validateHealthcheckHeaders(updateMonitorOptions.HeadersVar)
+	if err != nil {
+		return
+	}
 
 	pathSegments := []string{"instances", "monitors"}
 	pathParameters := []string{*updateMonitorOptions.InstanceID, *updateMonitorOptions.MonitorID}
@@ -983,6 +996,9 @@ func (dnsSvcs *DnsSvcsV1) UpdateMonitor(updateMonitorOptions *UpdateMonitorOptio
 	if updateMonitorOptions.ExpectedBody != nil {
 		body["expected_body"] = updateMonitorOptions.ExpectedBody
 	}
+	if updateMonitorOptions.FollowRedirects != nil {
+		body["follow_redirects"] = updateMonitorOptions.FollowRedirects
+	}
 	_, err = builder.SetBodyContentJSON(body)
 	if err != nil {
 		return
@@ -1168,6 +1184,9 @@ type CreateMonitorOptions struct {
 	// marked as unhealthy. This parameter is only valid for HTTP and HTTPS monitors.
 	ExpectedBody *string `json:"expected_body,omitempty"`
 
+	// Follow redirects returned by the origin. This parameter is only valid for HTTP and HTTPS monitors.
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
 	// Uniquely identifying a request.
 	XCorrelationID *string `json:"X-Correlation-ID,omitempty"`
 
@@ -1281,6 +1300,18 @@ func (options *CreateMonitorOptions) SetExpectedBody(expectedBody string) *Creat
 	return options
 }
 
+// SetFollowRedirects : Allow user to set FollowRedirects
+func (options *CreateMonitorOptions) SetFollowRedirects(followRedirects bool) *CreateMonitorOptions {
+	options.FollowRedirects = core.BoolPtr(followRedirects)
+	return options
+}
+
+// SetHeader : Allow user to set HeadersVar from a map of header names to values
+func (options *CreateMonitorOptions) SetHeader(header map[string]string) *CreateMonitorOptions {
+	options.HeadersVar = newHealthcheckHeaderBuilder().SetAll(header).HealthcheckHeaders()
+	return options
+}
+
 // SetXCorrelationID : Allow user to set XCorrelationID
 func (options *CreateMonitorOptions) SetXCorrelationID(xCorrelationID string) *CreateMonitorOptions {
 	options.XCorrelationID = core.StringPtr(xCorrelationID)
@@ -2046,6 +2077,9 @@ type UpdateMonitorOptions struct {
 	// marked as unhealthy. This parameter is only valid for HTTP and HTTPS monitors.
 	ExpectedBody *string `json:"expected_body,omitempty"`
 
+	// Follow redirects returned by the origin. This parameter is only valid for HTTP and HTTPS monitors.
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
 	// Uniquely identifying a request.
 	XCorrelationID *string `json:"X-Correlation-ID,omitempty"`
 
@@ -2166,6 +2200,18 @@ func (options *UpdateMonitorOptions) SetExpectedBody(expectedBody string) *Updat
 	return options
 }
 
+// SetFollowRedirects : Allow user to set FollowRedirects
+func (options *UpdateMonitorOptions) SetFollowRedirects(followRedirects bool) *UpdateMonitorOptions {
+	options.FollowRedirects = core.BoolPtr(followRedirects)
+	return options
+}
+
+// SetHeader : Allow user to set HeadersVar from a map of header names to values
+func (options *UpdateMonitorOptions) SetHeader(header map[string]string) *UpdateMonitorOptions {
+	options.HeadersVar = newHealthcheckHeaderBuilder().SetAll(header).HealthcheckHeaders()
+	return options
+}
+
 // SetXCorrelationID : Allow user to set XCorrelationID
 func (options *UpdateMonitorOptions) SetXCorrelationID(xCorrelationID string) *UpdateMonitorOptions {
 	options.XCorrelationID = core.StringPtr(xCorrelationID)
@@ -2353,6 +2399,91 @@ func UnmarshalHealthcheckHeader(m map[string]json.RawMessage, result interface{}
 	return
 }
 
+// HealthcheckHeaderBuilder : Builds the HTTP request headers of a health check from header names and values.
+type HealthcheckHeaderBuilder struct {
+	names  []string
+	values map[string][]string
+}
+
+// NewHealthcheckHeaders : Instantiate HealthcheckHeaderBuilder
+func (*DnsSvcsV1) NewHealthcheckHeaders() *HealthcheckHeaderBuilder {
+	return newHealthcheckHeaderBuilder()
+}
+
+func newHealthcheckHeaderBuilder() *HealthcheckHeaderBuilder {
+	return &HealthcheckHeaderBuilder{
+		values: make(map[string][]string),
+	}
+}
+
+// Add : Add a value to a header. Header names are compared case-insensitively.
+func (builder *HealthcheckHeaderBuilder) Add(name string, value string) *HealthcheckHeaderBuilder {
+	key := builder.key(name)
+	builder.values[key] = append(builder.values[key], value)
+	return builder
+}
+
+// Set : Replace the values of a header.
+func (builder *HealthcheckHeaderBuilder) Set(name string, values ...string) *HealthcheckHeaderBuilder {
+	builder.values[builder.key(name)] = values
+	return builder
+}
+
+// SetAll : Replace the values of the headers of a map, in the order of their names.
+func (builder *HealthcheckHeaderBuilder) SetAll(header map[string]string) *HealthcheckHeaderBuilder {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		builder.Set(name, header[name])
+	}
+	return builder
+}
+
+// HealthcheckHeaders : Return the headers in the order they were first added.
+func (builder *HealthcheckHeaderBuilder) HealthcheckHeaders() []HealthcheckHeader {
+	headers := make([]HealthcheckHeader, 0, len(builder.names))
+	for _, name := range builder.names {
+		headers = append(headers, HealthcheckHeader{
+			Name:  core.StringPtr(name),
+			Value: builder.values[name],
+		})
+	}
+	return headers
+}
+
+// Build : Return the headers, or an error when they cannot be sent in a health check.
+func (builder *HealthcheckHeaderBuilder) Build() (headers []HealthcheckHeader, err error) {
+	headers = builder.HealthcheckHeaders()
+	err = validateHealthcheckHeaders(headers)
+	if err != nil {
+		headers = nil
+	}
+	return
+}
+
+func (builder *HealthcheckHeaderBuilder) key(name string) string {
+	for _, existing := range builder.names {
+		if strings.EqualFold(existing, name) {
+			return existing
+		}
+	}
+	builder.names = append(builder.names, name)
+	return name
+}
+
+// validateHealthcheckHeaders rejects health check headers the service does not allow to be set.
+func validateHealthcheckHeaders(headers []HealthcheckHeader) error {
+	for _, header := range headers {
+		if header.Name != nil && strings.EqualFold(strings.TrimSpace(*header.Name), "User-Agent") {
+			return fmt.Errorf("the User-Agent header of a health check cannot be overridden")
+		}
+	}
+	return nil
+}
+
 // ListLoadBalancers : List Global Load Balancers response.
 type ListLoadBalancers struct {
 	// An array of Global Load Balancers.
@@ -2676,6 +2807,9 @@ type Monitor struct {
 	// marked as unhealthy. This parameter is only valid for HTTP and HTTPS monitors.
 	ExpectedBody *string `json:"expected_body,omitempty"`
 
+	// Follow redirects returned by the origin. This parameter is only valid for HTTP and HTTPS monitors.
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
 	// the time when a load balancer monitor is created.
 	CreatedOn *string `json:"created_on,omitempty"`
 
@@ -2683,6 +2817,14 @@ type Monitor struct {
 	ModifiedOn *string `json:"modified_on,omitempty"`
 }
 
+// Constants associated with the Monitor.Type property.
+// The protocol to use for the health check. Currently supported protocols are 'HTTP','HTTPS' and 'TCP'.
+const (
+	Monitor_Type_Http  = "HTTP"
+	Monitor_Type_Https = "HTTPS"
+	Monitor_Type_Tcp   = "TCP"
+)
+
 // Constants associated with the Monitor.Method property.
 // The method to use for the health check applicable to HTTP/HTTPS based checks, the default value is 'GET'.
 const (
@@ -2749,6 +2891,10 @@ func UnmarshalMonitor(m map[string]json.RawMessage, result interface{}) (err err
 	if err != nil {
 		return
 	}
+	err = core.UnmarshalPrimitive(m, "follow_redirects", &obj.FollowRedirects)
+	if err != nil {
+		return
+	}
 	err = core.UnmarshalPrimitive(m, "created_on", &obj.CreatedOn)
 	if err != nil {
 		return
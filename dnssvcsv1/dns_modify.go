@@ -0,0 +1,195 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DefaultModifyAttempts is the number of times ModifyPool and ModifyLoadBalancer read and modify an object before
+// giving up on concurrent modifications.
+const DefaultModifyAttempts = 3
+
+// modifyBackoff is the wait before a retry, multiplied by the number of the attempt.
+const modifyBackoff = 50 * time.Millisecond
+
+// readOnlyProperties are the properties of pools and load balancers set by the service.
+var readOnlyProperties = map[string]bool{"id": true, "health": true, "created_on": true, "modified_on": true}
+
+// ConcurrentModificationError : The object changed while it was being modified, on every attempt.
+type ConcurrentModificationError struct {
+	// The kind of object.
+	Kind string
+
+	// Identifier of the object.
+	ID string
+
+	// The modification time of the object when it was read.
+	ReadModifiedOn string
+
+	// The modification time of the object when the update was about to be sent.
+	CurrentModifiedOn string
+
+	// Number of attempts made.
+	Attempts int
+}
+
+// Error returns the description of the error.
+func (e *ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("%s %s was modified concurrently (modified_on %s, then %s) on each of %d attempts", e.Kind, e.ID, e.ReadModifiedOn, e.CurrentModifiedOn, e.Attempts)
+}
+
+// ModifyPool : Modify a load balancer pool
+// Read a pool, apply modify to it and send only the properties it changed. The pool is read again before the update
+// is sent; when its modification time changed in between, modify is applied to the fresh pool, up to
+// DefaultModifyAttempts times before a *ConcurrentModificationError is returned. The pool is read from the service
+// both times, bypassing the response cache. Properties set to nil are not cleared.
+// The context is checked before every request and while waiting to retry; a request in flight is not interrupted.
+func (dnsSvcs *DnsSvcsV1) ModifyPool(ctx context.Context, instanceID string, poolID string, modify func(*Pool) error) (result *Pool, err error) {
+	object, err := readModifyWrite(ctx, GlbTopologyNode_Kind_Pool, poolID,
+		func() (interface{}, *string, error) {
+			pool, _, err := dnsSvcs.GetPool(dnsSvcs.NewGetPoolOptions(instanceID, poolID).SetHeaders(withoutCache(nil)))
+			if err != nil {
+				return nil, nil, err
+			}
+			return pool, pool.ModifiedOn, nil
+		},
+		func(object interface{}) error {
+			return modify(object.(*Pool))
+		},
+		func(changes map[string]interface{}) (interface{}, error) {
+			updateOptions := dnsSvcs.NewUpdatePoolOptions(instanceID, poolID)
+			err := convertOptions(changes, updateOptions)
+			if err != nil {
+				return nil, err
+			}
+			pool, _, err := dnsSvcs.UpdatePool(updateOptions)
+			return pool, err
+		})
+	if object != nil {
+		result = object.(*Pool)
+	}
+	return
+}
+
+// ModifyLoadBalancer : Modify a load balancer
+// Read a load balancer, apply modify to it and send only the properties it changed, retrying on concurrent
+// modifications as ModifyPool does.
+func (dnsSvcs *DnsSvcsV1) ModifyLoadBalancer(ctx context.Context, instanceID string, dnszoneID string, lbID string, modify func(*LoadBalancer) error) (result *LoadBalancer, err error) {
+	object, err := readModifyWrite(ctx, GlbTopologyNode_Kind_LoadBalancer, lbID,
+		func() (interface{}, *string, error) {
+			loadBalancer, _, err := dnsSvcs.GetLoadBalancer(dnsSvcs.NewGetLoadBalancerOptions(instanceID, dnszoneID, lbID).SetHeaders(withoutCache(nil)))
+			if err != nil {
+				return nil, nil, err
+			}
+			return loadBalancer, loadBalancer.ModifiedOn, nil
+		},
+		func(object interface{}) error {
+			return modify(object.(*LoadBalancer))
+		},
+		func(changes map[string]interface{}) (interface{}, error) {
+			updateOptions := dnsSvcs.NewUpdateLoadBalancerOptions(instanceID, dnszoneID, lbID)
+			err := convertOptions(changes, updateOptions)
+			if err != nil {
+				return nil, err
+			}
+			loadBalancer, _, err := dnsSvcs.UpdateLoadBalancer(updateOptions)
+			return loadBalancer, err
+		})
+	if object != nil {
+		result = object.(*LoadBalancer)
+	}
+	return
+}
+
+// readModifyWrite runs the read, modify, check and update cycle shared by ModifyPool and ModifyLoadBalancer. get
+// returns a pointer to the object and its modification time.
+func readModifyWrite(ctx context.Context, kind string, id string, get func() (interface{}, *string, error), modify func(interface{}) error, update func(map[string]interface{}) (interface{}, error)) (result interface{}, err error) {
+	conflict := &ConcurrentModificationError{Kind: kind, ID: id}
+	for attempt := 1; attempt <= DefaultModifyAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * modifyBackoff):
+			}
+		}
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		current, modifiedOn, getErr := get()
+		if getErr != nil {
+			return nil, getErr
+		}
+		modified := reflect.New(reflect.TypeOf(current).Elem()).Interface()
+		err = convertOptions(current, modified)
+		if err != nil {
+			return
+		}
+		err = modify(modified)
+		if err != nil {
+			return
+		}
+		changes, changesErr := changedProperties(current, modified)
+		if changesErr != nil {
+			return nil, changesErr
+		}
+		if len(changes) == 0 {
+			return current, nil
+		}
+
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		_, latestModifiedOn, getErr := get()
+		if getErr != nil {
+			return nil, getErr
+		}
+		if stringValue(latestModifiedOn, "") != stringValue(modifiedOn, "") {
+			conflict.ReadModifiedOn = stringValue(modifiedOn, "")
+			conflict.CurrentModifiedOn = stringValue(latestModifiedOn, "")
+			conflict.Attempts = attempt
+			continue
+		}
+		return update(changes)
+	}
+	return nil, conflict
+}
+
+// changedProperties returns the writable properties that differ between two versions of an object, with their new
+// values.
+func changedProperties(before interface{}, after interface{}) (changes map[string]interface{}, err error) {
+	var beforeProperties, afterProperties map[string]interface{}
+	err = convertOptions(before, &beforeProperties)
+	if err != nil {
+		return
+	}
+	err = convertOptions(after, &afterProperties)
+	if err != nil {
+		return
+	}
+	changes = make(map[string]interface{})
+	for key, value := range afterProperties {
+		if !readOnlyProperties[key] && !reflect.DeepEqual(value, beforeProperties[key]) {
+			changes[key] = value
+		}
+	}
+	return
+}
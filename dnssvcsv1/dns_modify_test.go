@@ -0,0 +1,157 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Read-modify-write of pools and load balancers`, func() {
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+		fake.Add("/instances/instance/pools", map[string]interface{}{
+			"id": "pool", "name": "web", "description": "web servers", "enabled": true, "healthy_origins_threshold": 1,
+			"origins": []interface{}{
+				map[string]interface{}{"name": "o1", "address": "10.0.0.1", "enabled": true},
+				map[string]interface{}{"name": "o2", "address": "10.0.0.2", "enabled": true},
+			},
+		})
+		fake.Add("/instances/instance/dnszones/zone/load_balancers", map[string]interface{}{
+			"id": "glb", "name": "web.example.com", "ttl": 60, "fallback_pool": "pool", "default_pools": []string{"pool"},
+		})
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke ModifyPool successfully`, func() {
+		pool, err := testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			pool.Origins[1].Enabled = core.BoolPtr(false)
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(*pool.Origins[1].Enabled).To(BeFalse())
+		body := fake.Bodies["PUT /instances/instance/pools/pool"]
+		Expect(body).To(HaveLen(1))
+		Expect(body).To(HaveKey("origins"))
+		Expect(body["origins"].([]interface{})[0]).ToNot(HaveKey("health"))
+	})
+	It(`Invoke ModifyPool without changes`, func() {
+		pool, err := testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(*pool.Name).To(Equal("web"))
+		Expect(fake.Requests).To(Equal([]string{"GET /instances/instance/pools/pool"}))
+	})
+	It(`Invoke ModifyPool with a concurrent modification`, func() {
+		calls := 0
+		pool, err := testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			calls++
+			if calls == 1 {
+				_, _, err := testService.UpdatePool(testService.NewUpdatePoolOptions("instance", "pool").SetDescription("changed elsewhere"))
+				Expect(err).To(BeNil())
+			}
+			pool.HealthyOriginsThreshold = core.Int64Ptr(2)
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal(2))
+		Expect(*pool.Description).To(Equal("changed elsewhere"))
+		Expect(*pool.HealthyOriginsThreshold).To(Equal(int64(2)))
+	})
+	It(`Invoke ModifyPool with a concurrent modification and the response cache enabled`, func() {
+		Expect(testService.EnableResponseCache(testService.NewResponseCacheOptions(time.Minute))).To(Succeed())
+		_, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		other := fake.Service()
+		calls := 0
+		pool, err := testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			calls++
+			if calls == 1 {
+				_, _, err := other.UpdatePool(other.NewUpdatePoolOptions("instance", "pool").SetDescription("changed elsewhere"))
+				Expect(err).To(BeNil())
+			}
+			pool.HealthyOriginsThreshold = core.Int64Ptr(2)
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal(2))
+		Expect(*pool.Description).To(Equal("changed elsewhere"))
+		Expect(fake.List("/instances/instance/pools")[0]["description"]).To(Equal("changed elsewhere"))
+	})
+	It(`Invoke ModifyPool changing only the health check region`, func() {
+		fake.List("/instances/instance/pools")[0]["healthcheck_region"] = "us-south"
+		fake.List("/instances/instance/pools")[0]["healthcheck_subnets"] = []interface{}{"0716-a4c0c123-594c-4ef4-ace3-a08858540b5e"}
+		pool, err := testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			pool.HealthcheckRegion = core.StringPtr("us-east")
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(*pool.HealthcheckRegion).To(Equal("us-east"))
+		Expect(fake.Bodies["PUT /instances/instance/pools/pool"]).To(Equal(map[string]interface{}{"healthcheck_region": "us-east"}))
+	})
+	It(`Invoke ModifyPool with error`, func() {
+		_, err := testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			_, _, err := testService.UpdatePool(testService.NewUpdatePoolOptions("instance", "pool").SetDescription(fake.Requests[len(fake.Requests)-1]))
+			Expect(err).To(BeNil())
+			pool.Enabled = core.BoolPtr(false)
+			return nil
+		})
+		var conflict *dnssvcsv1.ConcurrentModificationError
+		Expect(errors.As(err, &conflict)).To(BeTrue())
+		Expect(conflict.Attempts).To(Equal(dnssvcsv1.DefaultModifyAttempts))
+		Expect(conflict.ReadModifiedOn).ToNot(Equal(conflict.CurrentModifiedOn))
+
+		mutationErr := errors.New("no origin to disable")
+		_, err = testService.ModifyPool(context.Background(), "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			return mutationErr
+		})
+		Expect(err).To(Equal(mutationErr))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = testService.ModifyPool(ctx, "instance", "pool", func(pool *dnssvcsv1.Pool) error {
+			return nil
+		})
+		Expect(err).To(Equal(context.Canceled))
+
+		_, err = testService.ModifyPool(context.Background(), "instance", "missing", func(pool *dnssvcsv1.Pool) error {
+			return nil
+		})
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke ModifyLoadBalancer successfully`, func() {
+		loadBalancer, err := testService.ModifyLoadBalancer(context.Background(), "instance", "zone", "glb", func(loadBalancer *dnssvcsv1.LoadBalancer) error {
+			loadBalancer.TTL = core.Int64Ptr(120)
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(*loadBalancer.TTL).To(Equal(int64(120)))
+		Expect(fake.Bodies["PUT /instances/instance/dnszones/zone/load_balancers/glb"]).To(Equal(map[string]interface{}{"ttl": float64(120)}))
+	})
+})
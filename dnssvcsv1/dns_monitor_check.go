@@ -0,0 +1,285 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// Defaults applied by CheckMonitor to properties a monitor leaves unset.
+const (
+	DefaultMonitorCheckExpectedCodes = "200"
+	DefaultMonitorCheckTimeout       = 5
+	maxMonitorCheckBodySize          = 1 << 20
+)
+
+// MonitorCheckReport : The outcome of running a monitor against an origin locally.
+type MonitorCheckReport struct {
+	// The origin address checked.
+	Origin string
+
+	// Whether the origin would be considered healthy.
+	Passed bool
+
+	// Why the check failed, taken from the last attempt.
+	Reason string
+
+	// The attempts made, the first check followed by its retries.
+	Attempts []MonitorCheckAttempt
+}
+
+// MonitorCheckAttempt : A single attempt of a monitor check.
+type MonitorCheckAttempt struct {
+	// The URL requested by HTTP and HTTPS checks, or the address dialled by TCP checks.
+	Target string
+
+	// The HTTP status code received.
+	StatusCode int
+
+	// How long the attempt took.
+	Duration time.Duration
+
+	// Whether the attempt passed.
+	Passed bool
+
+	// Why the attempt failed.
+	Reason string
+
+	// Whether the attempt failed before a response was received, for example because of a timeout. Only such attempts
+	// are retried.
+	Retryable bool
+}
+
+// CheckMonitorOptions runs the health check described by monitor creation options against an origin address.
+func CheckMonitorOptions(createMonitorOptions *CreateMonitorOptions, origin string) *MonitorCheckReport {
+	if err := core.ValidateNotNil(createMonitorOptions, "createMonitorOptions cannot be nil"); err != nil {
+		return &MonitorCheckReport{Origin: origin, Reason: err.Error()}
+	}
+	return CheckMonitor(&Monitor{
+		Type:            createMonitorOptions.Type,
+		Port:            createMonitorOptions.Port,
+		Retries:         createMonitorOptions.Retries,
+		Timeout:         createMonitorOptions.Timeout,
+		Method:          createMonitorOptions.Method,
+		Path:            createMonitorOptions.Path,
+		HeadersVar:      createMonitorOptions.HeadersVar,
+		AllowInsecure:   createMonitorOptions.AllowInsecure,
+		ExpectedCodes:   createMonitorOptions.ExpectedCodes,
+		ExpectedBody:    createMonitorOptions.ExpectedBody,
+		FollowRedirects: createMonitorOptions.FollowRedirects,
+	}, origin)
+}
+
+// CheckMonitor runs the health check described by a monitor against an origin address, a host name or IP address
+// with an optional port. The port of the monitor takes precedence over the port of the origin; without either the
+// default port of the protocol is used. The check is attempted once more for each of the monitor's retries when it
+// fails without a response.
+func CheckMonitor(monitor *Monitor, origin string) *MonitorCheckReport {
+	report := &MonitorCheckReport{Origin: origin}
+	if err := core.ValidateNotNil(monitor, "monitor cannot be nil"); err != nil {
+		report.Reason = err.Error()
+		return report
+	}
+	checkType := stringValue(monitor.Type, Monitor_Type_Http)
+	timeout := time.Duration(int64Value(monitor.Timeout, DefaultMonitorCheckTimeout)) * time.Second
+	retries := int(int64Value(monitor.Retries, 0))
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: monitor.AllowInsecure != nil && *monitor.AllowInsecure}, // #nosec G402
+	}
+	defer transport.CloseIdleConnections()
+
+	for attempt := 0; attempt <= retries; attempt++ {
+		var result MonitorCheckAttempt
+		start := time.Now()
+		switch strings.ToUpper(checkType) {
+		case Monitor_Type_Tcp:
+			result = checkTCP(monitor, origin, timeout)
+		case Monitor_Type_Http, Monitor_Type_Https:
+			result = checkHTTP(monitor, strings.ToLower(checkType), origin, timeout, transport)
+		default:
+			result = MonitorCheckAttempt{Reason: fmt.Sprintf("unsupported monitor type '%s'", checkType)}
+		}
+		result.Duration = time.Since(start)
+		report.Attempts = append(report.Attempts, result)
+		report.Passed, report.Reason = result.Passed, result.Reason
+		if result.Passed || !result.Retryable {
+			break
+		}
+	}
+	return report
+}
+
+func checkTCP(monitor *Monitor, origin string, timeout time.Duration) (result MonitorCheckAttempt) {
+	host, port := splitOrigin(origin)
+	if monitor.Port != nil {
+		port = strconv.FormatInt(*monitor.Port, 10)
+	}
+	if port == "" {
+		result.Reason = "TCP monitors require a port"
+		return
+	}
+	result.Target = net.JoinHostPort(host, port)
+	conn, err := net.DialTimeout("tcp", result.Target, timeout)
+	if err != nil {
+		result.Reason = err.Error()
+		result.Retryable = true
+		return
+	}
+	conn.Close()
+	result.Passed = true
+	return
+}
+
+func checkHTTP(monitor *Monitor, scheme string, origin string, timeout time.Duration, transport http.RoundTripper) (result MonitorCheckAttempt) {
+	host, port := splitOrigin(origin)
+	if monitor.Port != nil {
+		port = strconv.FormatInt(*monitor.Port, 10)
+	}
+	address := host
+	if strings.Contains(host, ":") {
+		address = "[" + host + "]"
+	}
+	if port != "" {
+		address = net.JoinHostPort(host, port)
+	}
+	path := stringValue(monitor.Path, "/")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	result.Target = scheme + "://" + address + path
+
+	method := strings.ToUpper(stringValue(monitor.Method, Monitor_Method_Get))
+	request, err := http.NewRequest(method, result.Target, nil)
+	if err != nil {
+		result.Reason = err.Error()
+		return
+	}
+	for _, header := range monitor.HeadersVar {
+		if header.Name == nil {
+			continue
+		}
+		for _, value := range header.Value {
+			if strings.EqualFold(*header.Name, "Host") {
+				request.Host = value
+			} else {
+				request.Header.Add(*header.Name, value)
+			}
+		}
+	}
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+	if monitor.FollowRedirects == nil || !*monitor.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		result.Reason = err.Error()
+		result.Retryable = true
+		return
+	}
+	defer response.Body.Close()
+	result.StatusCode = response.StatusCode
+
+	expectedCodes := stringValue(monitor.ExpectedCodes, DefaultMonitorCheckExpectedCodes)
+	if !matchesExpectedCodes(response.StatusCode, expectedCodes) {
+		result.Reason = fmt.Sprintf("status code %d does not match expected codes '%s'", response.StatusCode, expectedCodes)
+		return
+	}
+	if monitor.ExpectedBody != nil && *monitor.ExpectedBody != "" && method != http.MethodHead {
+		body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxMonitorCheckBodySize))
+		if err != nil {
+			result.Reason = err.Error()
+			result.Retryable = true
+			return
+		}
+		if !strings.Contains(strings.ToLower(string(body)), strings.ToLower(*monitor.ExpectedBody)) {
+			result.Reason = fmt.Sprintf("response body does not contain '%s'", *monitor.ExpectedBody)
+			return
+		}
+	}
+	result.Passed = true
+	return
+}
+
+// matchesExpectedCodes reports whether a status code matches a list of expected codes, such as "200", "2xx" or
+// "200,301-302", separated by commas or spaces.
+func matchesExpectedCodes(statusCode int, expectedCodes string) bool {
+	code := strconv.Itoa(statusCode)
+	for _, expected := range strings.FieldsFunc(expectedCodes, func(r rune) bool { return r == ',' || r == ' ' }) {
+		expected = strings.ToLower(expected)
+		if pos := strings.Index(expected, "-"); pos > 0 {
+			low, lowErr := strconv.Atoi(expected[:pos])
+			high, highErr := strconv.Atoi(expected[pos+1:])
+			if lowErr == nil && highErr == nil && statusCode >= low && statusCode <= high {
+				return true
+			}
+			continue
+		}
+		if len(expected) != len(code) {
+			continue
+		}
+		matched := true
+		for i := range expected {
+			if expected[i] != 'x' && expected[i] != code[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// splitOrigin splits an origin address into host and, when present, port.
+func splitOrigin(origin string) (host string, port string) {
+	if h, p, err := net.SplitHostPort(origin); err == nil {
+		return h, p
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(origin, "["), "]"), ""
+}
+
+func stringValue(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+	return *value
+}
+
+func int64Value(value *int64, defaultValue int64) int64 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
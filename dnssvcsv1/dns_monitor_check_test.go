@@ -0,0 +1,172 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CheckMonitor(monitor *Monitor, origin string)`, func() {
+	var testServer *httptest.Server
+	testService, _ := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+		URL:           "http://dnssvcsv1modelgenerator.com",
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		defer GinkgoRecover()
+		switch req.URL.Path {
+		case "/health":
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.Host).To(Equal("origin.example.com"))
+			Expect(req.Header.Get("X-App-ID")).To(Equal("abc123"))
+			res.WriteHeader(200)
+			fmt.Fprintf(res, `Status: ALIVE`)
+		case "/moved":
+			http.Redirect(res, req, "/health", http.StatusFound)
+		default:
+			res.WriteHeader(503)
+		}
+	}
+	newMonitor := func() *dnssvcsv1.Monitor {
+		return &dnssvcsv1.Monitor{
+			Type:          core.StringPtr(dnssvcsv1.Monitor_Type_Http),
+			Path:          core.StringPtr("/health"),
+			Timeout:       core.Int64Ptr(2),
+			ExpectedCodes: core.StringPtr("2xx"),
+			ExpectedBody:  core.StringPtr("alive"),
+			HeadersVar:    testService.NewHealthcheckHeaders().Set("Host", "origin.example.com").Set("X-App-ID", "abc123").HealthcheckHeaders(),
+		}
+	}
+	Context(`Using an HTTP origin`, func() {
+		BeforeEach(func() {
+			testServer = httptest.NewServer(http.HandlerFunc(handler))
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+		It(`Invoke CheckMonitor successfully`, func() {
+			report := dnssvcsv1.CheckMonitor(newMonitor(), strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeTrue())
+			Expect(report.Attempts).To(HaveLen(1))
+			Expect(report.Attempts[0].StatusCode).To(Equal(200))
+			Expect(report.Attempts[0].Target).To(Equal(testServer.URL + "/health"))
+		})
+		It(`Invoke CheckMonitor with unexpected status code and body`, func() {
+			monitor := newMonitor()
+			monitor.ExpectedCodes = core.StringPtr("201")
+			report := dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Reason).To(ContainSubstring("status code 200"))
+
+			monitor = newMonitor()
+			monitor.ExpectedBody = core.StringPtr("ready")
+			monitor.Retries = core.Int64Ptr(2)
+			report = dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Attempts).To(HaveLen(1))
+			Expect(report.Reason).To(ContainSubstring("'ready'"))
+		})
+		It(`Invoke CheckMonitor with redirects`, func() {
+			monitor := newMonitor()
+			monitor.Path = core.StringPtr("/moved")
+			monitor.ExpectedCodes = core.StringPtr("200, 201")
+			report := dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Attempts[0].StatusCode).To(Equal(http.StatusFound))
+
+			monitor.FollowRedirects = core.BoolPtr(true)
+			report = dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeTrue())
+
+			monitor.FollowRedirects = nil
+			monitor.ExpectedCodes = core.StringPtr("300-399")
+			monitor.ExpectedBody = nil
+			report = dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeTrue())
+		})
+		It(`Invoke CheckMonitorOptions with the port of the monitor`, func() {
+			_, port, _ := net.SplitHostPort(strings.TrimPrefix(testServer.URL, "http://"))
+			createMonitorOptionsModel := new(dnssvcsv1.CreateMonitorOptions).SetType(dnssvcsv1.CreateMonitorOptions_Type_Http).SetPath("health").SetExpectedCodes("2XX")
+			createMonitorOptionsModel.SetHeader(map[string]string{"Host": "origin.example.com", "X-App-ID": "abc123"})
+			var portNumber int64
+			fmt.Sscan(port, &portNumber)
+			createMonitorOptionsModel.SetPort(portNumber)
+			report := dnssvcsv1.CheckMonitorOptions(createMonitorOptionsModel, "127.0.0.1")
+			Expect(report.Passed).To(BeTrue())
+		})
+		It(`Invoke CheckMonitor for TCP`, func() {
+			monitor := &dnssvcsv1.Monitor{Type: core.StringPtr(dnssvcsv1.Monitor_Type_Tcp), Timeout: core.Int64Ptr(1), Retries: core.Int64Ptr(1)}
+			report := dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "http://"))
+			Expect(report.Passed).To(BeTrue())
+
+			report = dnssvcsv1.CheckMonitor(monitor, "127.0.0.1")
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Reason).To(ContainSubstring("require a port"))
+
+			listener, _ := net.Listen("tcp", "127.0.0.1:0")
+			closedAddress := listener.Addr().String()
+			listener.Close()
+			report = dnssvcsv1.CheckMonitor(monitor, closedAddress)
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Attempts).To(HaveLen(2))
+		})
+		It(`Invoke CheckMonitor and CheckMonitorOptions with nil`, func() {
+			report := dnssvcsv1.CheckMonitor(nil, "127.0.0.1")
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Reason).To(Equal("monitor cannot be nil"))
+			Expect(report.Attempts).To(BeEmpty())
+
+			report = dnssvcsv1.CheckMonitorOptions(nil, "127.0.0.1")
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Reason).To(Equal("createMonitorOptions cannot be nil"))
+		})
+	})
+	Context(`Using an HTTPS origin`, func() {
+		BeforeEach(func() {
+			testServer = httptest.NewUnstartedServer(http.HandlerFunc(handler))
+			testServer.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+			testServer.StartTLS()
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+		It(`Invoke CheckMonitor with AllowInsecure`, func() {
+			monitor := newMonitor()
+			monitor.Type = core.StringPtr(dnssvcsv1.Monitor_Type_Https)
+			monitor.Retries = core.Int64Ptr(1)
+			report := dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "https://"))
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Attempts).To(HaveLen(2))
+			Expect(report.Attempts[1].Retryable).To(BeTrue())
+
+			monitor.AllowInsecure = core.BoolPtr(true)
+			report = dnssvcsv1.CheckMonitor(monitor, strings.TrimPrefix(testServer.URL, "https://"))
+			Expect(report.Passed).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,382 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	common "github.com/IBM/dns-svcs-go-sdk/common"
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// Default durations of the SyncPermittedNetworks workflow.
+const (
+	DefaultSyncPermittedNetworksTimeout      = 5 * time.Minute
+	DefaultSyncPermittedNetworksPollInterval = 5 * time.Second
+)
+
+// SyncPermittedNetworksOptions : The SyncPermittedNetworks options.
+type SyncPermittedNetworksOptions struct {
+	// The unique identifier of a service instance.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The unique identifier of a DNS zone.
+	DnszoneID *string `json:"dnszone_id" validate:"required"`
+
+	// The CRNs of the VPCs the DNS zone must be reachable from, and only those. An empty set removes every permitted
+	// network.
+	VpcCrns []string `json:"vpc_crns"`
+
+	// Whether to return the changes without making them.
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// How long to wait for added permitted networks to become ACTIVE and removed ones to disappear,
+	// DefaultSyncPermittedNetworksTimeout when zero.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// How often the permitted networks are listed while waiting, DefaultSyncPermittedNetworksPollInterval when zero.
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+
+	// Called after every change is made and again when it has taken effect.
+	Progress func(SyncPermittedNetworksChange) `json:"-"`
+}
+
+// NewSyncPermittedNetworksOptions : Instantiate SyncPermittedNetworksOptions
+func (*DnsSvcsV1) NewSyncPermittedNetworksOptions(instanceID string, dnszoneID string, vpcCrns []string) *SyncPermittedNetworksOptions {
+	return &SyncPermittedNetworksOptions{
+		InstanceID: core.StringPtr(instanceID),
+		DnszoneID:  core.StringPtr(dnszoneID),
+		VpcCrns:    vpcCrns,
+	}
+}
+
+// SetDryRun : Allow user to set DryRun
+func (options *SyncPermittedNetworksOptions) SetDryRun(dryRun bool) *SyncPermittedNetworksOptions {
+	options.DryRun = core.BoolPtr(dryRun)
+	return options
+}
+
+// SetTimeout : Allow user to set Timeout
+func (options *SyncPermittedNetworksOptions) SetTimeout(timeout time.Duration) *SyncPermittedNetworksOptions {
+	options.Timeout = timeout
+	return options
+}
+
+// SetPollInterval : Allow user to set PollInterval
+func (options *SyncPermittedNetworksOptions) SetPollInterval(pollInterval time.Duration) *SyncPermittedNetworksOptions {
+	options.PollInterval = pollInterval
+	return options
+}
+
+// SetProgress : Allow user to set Progress
+func (options *SyncPermittedNetworksOptions) SetProgress(progress func(SyncPermittedNetworksChange)) *SyncPermittedNetworksOptions {
+	options.Progress = progress
+	return options
+}
+
+// SyncPermittedNetworksChange : A permitted network added, removed or kept by the SyncPermittedNetworks workflow.
+type SyncPermittedNetworksChange struct {
+	// The change.
+	Action string
+
+	// The CRN of the VPC.
+	VpcCrn string
+
+	// The unique identifier of the permitted network, empty for additions of a dry run.
+	ID string
+
+	// The last state seen of the permitted network; empty once it is removed.
+	State string
+
+	// Whether the change has taken effect: the permitted network is ACTIVE, or no longer listed.
+	Done bool
+
+	// The error of the change.
+	Err error
+}
+
+// Constants associated with the SyncPermittedNetworksChange.Action property.
+// The change.
+const (
+	SyncPermittedNetworksChange_Action_Add    = "add"
+	SyncPermittedNetworksChange_Action_Keep   = "keep"
+	SyncPermittedNetworksChange_Action_Remove = "remove"
+)
+
+// String describes the change, for example "add crn:v1:...: ACTIVE".
+func (change SyncPermittedNetworksChange) String() string {
+	status := change.State
+	switch {
+	case change.Err != nil:
+		status = "failed: " + change.Err.Error()
+	case change.Action == SyncPermittedNetworksChange_Action_Remove && change.Done:
+		status = "removed"
+	case status == "":
+		status = "pending"
+	}
+	return fmt.Sprintf("%s %s: %s", change.Action, change.VpcCrn, status)
+}
+
+// SyncPermittedNetworksResult : Outcome of the SyncPermittedNetworks workflow.
+type SyncPermittedNetworksResult struct {
+	// Every permitted network of the DNS zone and of the desired set, with the change made, sorted by VPC CRN.
+	Changes []SyncPermittedNetworksChange
+
+	// The number of permitted networks added, removed and kept.
+	Added   int
+	Removed int
+	Kept    int
+
+	// The state of the DNS zone after the changes, for example pending_network_add while it has no permitted network.
+	DnszoneState string
+
+	// The changes that failed or did not take effect in time.
+	Failures []SyncPermittedNetworksChange
+}
+
+// SyncPermittedNetworks : Make a DNS zone reachable from exactly a set of VPCs
+// Compare the permitted networks of a DNS zone with a set of VPC CRNs, create the permitted networks that are missing
+// and then delete those not in the set, so that the DNS zone stays reachable from the VPCs it keeps. The workflow
+// waits until the added permitted networks are ACTIVE, the removed ones are gone and a DNS zone that was in
+// pending_network_add is active. A VPC of the set whose permitted network is still being removed is added again once
+// the removal has finished. The DNS zone and its permitted networks are read from the service, bypassing the response
+// cache. Malformed VPC CRNs are rejected before any change is made. The returned error summarizes the first failure.
+// The context interrupts the waits, leaving the changes made so far in place.
+func (dnsSvcs *DnsSvcsV1) SyncPermittedNetworks(ctx context.Context, syncPermittedNetworksOptions *SyncPermittedNetworksOptions) (result *SyncPermittedNetworksResult, err error) {
+	err = core.ValidateNotNil(syncPermittedNetworksOptions, "syncPermittedNetworksOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(syncPermittedNetworksOptions, "syncPermittedNetworksOptions")
+	if err != nil {
+		return
+	}
+	options := syncPermittedNetworksOptions
+	progress := func(change SyncPermittedNetworksChange) {
+		if options.Progress != nil {
+			options.Progress(change)
+		}
+	}
+	instanceID, dnszoneID := *options.InstanceID, *options.DnszoneID
+
+	desired := make(map[string]bool)
+	for _, vpcCrn := range options.VpcCrns {
+		parsed, parseErr := common.ParseVpcCrn(vpcCrn)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+		desired[parsed.String()] = true
+	}
+
+	dnszone, _, err := dnsSvcs.GetDnszone(dnsSvcs.NewGetDnszoneOptions(instanceID, dnszoneID).SetHeaders(withoutCache(nil)))
+	if err != nil {
+		return
+	}
+	state := stringValue(dnszone.State, "")
+	if state == Dnszone_State_Deleted || state == Dnszone_State_PendingDelete {
+		err = fmt.Errorf("DNS zone %s is %s, its permitted networks cannot be changed", dnszoneID, state)
+		return
+	}
+	existing, err := dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(instanceID, dnszoneID).SetHeaders(withoutCache(nil)))
+	if err != nil {
+		return
+	}
+	result = &SyncPermittedNetworksResult{DnszoneState: state}
+
+	// A permitted network already being removed is waited for, and added again once it is gone when it is in the
+	// desired set; both changes are listed, the removal first.
+	var sorted []*SyncPermittedNetworksChange
+	listed := make(map[string]*SyncPermittedNetworksChange)
+	for _, network := range existing {
+		vpcCrn := permittedNetworkVpcCrn(&network)
+		if parsed, parseErr := common.ParseVpcCrn(vpcCrn); parseErr == nil {
+			vpcCrn = parsed.String()
+		}
+		change := &SyncPermittedNetworksChange{Action: SyncPermittedNetworksChange_Action_Keep, VpcCrn: vpcCrn, ID: stringValue(network.ID, ""), State: stringValue(network.State, "")}
+		if !desired[vpcCrn] || change.State == PermittedNetwork_State_RemovalInProgress {
+			change.Action = SyncPermittedNetworksChange_Action_Remove
+		}
+		sorted = append(sorted, change)
+		listed[vpcCrn] = change
+	}
+	removing := make(map[*SyncPermittedNetworksChange]*SyncPermittedNetworksChange)
+	for vpcCrn := range desired {
+		change, found := listed[vpcCrn]
+		if found && change.State != PermittedNetwork_State_RemovalInProgress {
+			continue
+		}
+		addition := &SyncPermittedNetworksChange{Action: SyncPermittedNetworksChange_Action_Add, VpcCrn: vpcCrn}
+		if found {
+			removing[addition] = change
+		}
+		sorted = append(sorted, addition)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].VpcCrn < sorted[j].VpcCrn
+	})
+
+	dryRun := options.DryRun != nil && *options.DryRun
+	if !dryRun {
+		deadline := time.Now().Add(durationOrDefault(options.Timeout, DefaultSyncPermittedNetworksTimeout))
+		for _, action := range []string{SyncPermittedNetworksChange_Action_Add, SyncPermittedNetworksChange_Action_Remove} {
+			for _, change := range sorted {
+				if change.Action != action || removing[change] != nil {
+					continue
+				}
+				if action == SyncPermittedNetworksChange_Action_Add {
+					dnsSvcs.addPermittedNetwork(instanceID, dnszoneID, change)
+				} else if change.State != PermittedNetwork_State_RemovalInProgress {
+					_, _, change.Err = dnsSvcs.DeletePermittedNetwork(dnsSvcs.NewDeletePermittedNetworkOptions(instanceID, dnszoneID, change.ID))
+				}
+				progress(*change)
+			}
+		}
+
+		if len(removing) > 0 {
+			var removals []*SyncPermittedNetworksChange
+			for _, change := range sorted {
+				if removal := removing[change]; removal != nil {
+					removals = append(removals, removal)
+				}
+			}
+			err = dnsSvcs.waitForPermittedNetworks(ctx, instanceID, dnszoneID, removals, deadline, options, &SyncPermittedNetworksResult{}, progress)
+			if err != nil {
+				return
+			}
+			for _, change := range sorted {
+				if removal := removing[change]; removal != nil {
+					if removal.Err != nil {
+						change.Err = fmt.Errorf("permitted network %s was still being removed", removal.ID)
+					} else {
+						dnsSvcs.addPermittedNetwork(instanceID, dnszoneID, change)
+					}
+					progress(*change)
+				}
+			}
+		}
+
+		err = dnsSvcs.waitForPermittedNetworks(ctx, instanceID, dnszoneID, sorted, deadline, options, result, progress)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, change := range sorted {
+		if change.Action == SyncPermittedNetworksChange_Action_Keep {
+			change.Done = true
+		}
+		result.Changes = append(result.Changes, *change)
+		switch {
+		case change.Err != nil:
+			result.Failures = append(result.Failures, *change)
+		case change.Action == SyncPermittedNetworksChange_Action_Add:
+			result.Added++
+		case change.Action == SyncPermittedNetworksChange_Action_Remove:
+			result.Removed++
+		default:
+			result.Kept++
+		}
+	}
+	if len(result.Failures) > 0 {
+		err = fmt.Errorf("%d permitted networks of DNS zone %s could not be synchronized, the first: %s", len(result.Failures), dnszoneID, result.Failures[0].String())
+	}
+	return
+}
+
+// addPermittedNetwork creates the permitted network of an add change, recording its ID and state or the error.
+func (dnsSvcs *DnsSvcsV1) addPermittedNetwork(instanceID string, dnszoneID string, change *SyncPermittedNetworksChange) {
+	createOptions := dnsSvcs.NewCreatePermittedNetworkOptions(instanceID, dnszoneID)
+	createOptions.SetType(CreatePermittedNetworkOptions_Type_Vpc)
+	createOptions.SetPermittedNetwork(&PermittedNetworkVpc{VpcCrn: core.StringPtr(change.VpcCrn)})
+	network, _, err := dnsSvcs.CreatePermittedNetwork(createOptions)
+	change.Err = err
+	if err == nil {
+		change.ID, change.State = stringValue(network.ID, ""), stringValue(network.State, "")
+	}
+}
+
+// waitForPermittedNetworks lists the permitted networks of a DNS zone until every change has taken effect and the DNS
+// zone has left pending_network_add, the deadline passes or the context is done. Changes that did not take effect in
+// time get an error.
+func (dnsSvcs *DnsSvcsV1) waitForPermittedNetworks(ctx context.Context, instanceID string, dnszoneID string, changes []*SyncPermittedNetworksChange, deadline time.Time, options *SyncPermittedNetworksOptions, result *SyncPermittedNetworksResult, progress func(SyncPermittedNetworksChange)) (err error) {
+	timeout := durationOrDefault(options.Timeout, DefaultSyncPermittedNetworksTimeout)
+	pollInterval := durationOrDefault(options.PollInterval, DefaultSyncPermittedNetworksPollInterval)
+	for {
+		var networks []PermittedNetwork
+		networks, err = dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(instanceID, dnszoneID).SetHeaders(withoutCache(nil)))
+		if err != nil {
+			return
+		}
+		listed := make(map[string]*PermittedNetwork)
+		for i := range networks {
+			listed[stringValue(networks[i].ID, "")] = &networks[i]
+		}
+		pending := false
+		for _, change := range changes {
+			if change.Err != nil || change.Done || change.Action == SyncPermittedNetworksChange_Action_Keep {
+				continue
+			}
+			network := listed[change.ID]
+			if network != nil {
+				change.State = stringValue(network.State, "")
+			}
+			if change.Action == SyncPermittedNetworksChange_Action_Add {
+				change.Done = change.State == PermittedNetwork_State_Active
+			} else {
+				change.Done = network == nil
+				if change.Done {
+					change.State = ""
+				}
+			}
+			if change.Done {
+				progress(*change)
+			}
+			pending = pending || !change.Done
+		}
+
+		if result.DnszoneState == Dnszone_State_PendingNetworkAdd && len(networks) > 0 {
+			var dnszone *Dnszone
+			dnszone, _, err = dnsSvcs.GetDnszone(dnsSvcs.NewGetDnszoneOptions(instanceID, dnszoneID).SetHeaders(withoutCache(nil)))
+			if err != nil {
+				return
+			}
+			result.DnszoneState = stringValue(dnszone.State, "")
+			pending = pending || result.DnszoneState == Dnszone_State_PendingNetworkAdd
+		}
+
+		if !pending {
+			return
+		}
+		if !time.Now().Add(pollInterval).Before(deadline) {
+			for _, change := range changes {
+				if change.Err == nil && !change.Done && change.Action != SyncPermittedNetworksChange_Action_Keep {
+					change.Err = fmt.Errorf("the change did not take effect within %s", timeout)
+				}
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
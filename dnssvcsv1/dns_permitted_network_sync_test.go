@@ -0,0 +1,196 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SyncPermittedNetworks`, func() {
+	const (
+		vpcA = "crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-a"
+		vpcB = "crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-b"
+		vpcC = "crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-c"
+	)
+	const networks = "/instances/i/dnszones/z/permitted_networks"
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	vpcCrnsOf := func(objects []map[string]interface{}) (vpcCrns []string) {
+		for _, object := range objects {
+			vpcCrns = append(vpcCrns, object["permitted_network"].(map[string]interface{})["vpc_crn"].(string))
+		}
+		return
+	}
+	It(`Invoke SyncPermittedNetworks successfully`, func() {
+		fake.Add("/instances/i/dnszones", map[string]interface{}{"id": "z", "name": "example.com", "state": "active"})
+		fake.Add(networks, map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcA}})
+		fake.Add(networks, map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcB}})
+		fake.SettleLists = 2
+
+		options := testService.NewSyncPermittedNetworksOptions("i", "z", []string{vpcB, vpcC}).SetDryRun(true)
+		result, err := testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect([]int{result.Added, result.Removed, result.Kept}).To(Equal([]int{1, 1, 1}))
+		Expect(fake.List(networks)).To(HaveLen(2))
+
+		var progress []string
+		options.SetDryRun(false).SetPollInterval(time.Millisecond).SetProgress(func(change dnssvcsv1.SyncPermittedNetworksChange) {
+			progress = append(progress, change.String())
+		})
+		result, err = testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect(result.Failures).To(BeEmpty())
+		Expect([]int{result.Added, result.Removed, result.Kept}).To(Equal([]int{1, 1, 1}))
+		Expect(result.Changes).To(HaveLen(3))
+		Expect(result.Changes[0].String()).To(Equal("remove " + vpcA + ": removed"))
+		Expect(result.Changes[2].String()).To(Equal("add " + vpcC + ": ACTIVE"))
+		Expect(progress).To(Equal([]string{
+			"add " + vpcC + ": pending",
+			"remove " + vpcA + ": ACTIVE",
+			"remove " + vpcA + ": removed",
+			"add " + vpcC + ": ACTIVE",
+		}))
+		Expect(vpcCrnsOf(fake.List(networks))).To(Equal([]string{vpcB, vpcC}))
+
+		result, err = testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect([]int{result.Added, result.Removed, result.Kept}).To(Equal([]int{0, 0, 2}))
+	})
+	It(`Invoke SyncPermittedNetworks on a DNS zone pending a permitted network`, func() {
+		fake.Add("/instances/i/dnszones", map[string]interface{}{"id": "z", "name": "example.com", "state": "pending_network_add"})
+		options := testService.NewSyncPermittedNetworksOptions("i", "z", nil)
+		result, err := testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect(result.Changes).To(BeEmpty())
+		Expect(result.DnszoneState).To(Equal(dnssvcsv1.Dnszone_State_PendingNetworkAdd))
+
+		fake.SettleLists = 1
+		result, err = testService.SyncPermittedNetworks(context.Background(), options.SetPollInterval(time.Millisecond).SetTimeout(time.Second).SetProgress(nil))
+		Expect(err).To(BeNil())
+		options.VpcCrns = []string{vpcA}
+		result, err = testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect(result.Added).To(Equal(1))
+		Expect(result.DnszoneState).To(Equal(dnssvcsv1.Dnszone_State_Active))
+	})
+	It(`Invoke SyncPermittedNetworks on a permitted network being removed`, func() {
+		fake.Add("/instances/i/dnszones", map[string]interface{}{"id": "z", "name": "example.com", "state": "active"})
+		id := fake.Add(networks, map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcA}})
+		fake.SettleLists = 3
+		_, _, err := testService.DeletePermittedNetwork(testService.NewDeletePermittedNetworkOptions("i", "z", id))
+		Expect(err).To(BeNil())
+
+		options := testService.NewSyncPermittedNetworksOptions("i", "z", []string{vpcA}).SetDryRun(true)
+		result, err := testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect([]int{result.Added, result.Removed, result.Kept}).To(Equal([]int{1, 1, 0}))
+
+		var progress []string
+		options.SetDryRun(false).SetPollInterval(time.Millisecond).SetProgress(func(change dnssvcsv1.SyncPermittedNetworksChange) {
+			progress = append(progress, change.String())
+		})
+		result, err = testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect([]int{result.Added, result.Removed, result.Kept}).To(Equal([]int{1, 1, 0}))
+		Expect(result.Changes).To(HaveLen(2))
+		Expect(result.Changes[0].String()).To(Equal("remove " + vpcA + ": removed"))
+		Expect(result.Changes[1].String()).To(Equal("add " + vpcA + ": ACTIVE"))
+		Expect(progress).To(Equal([]string{
+			"remove " + vpcA + ": REMOVAL_IN_PROGRESS",
+			"remove " + vpcA + ": removed",
+			"add " + vpcA + ": pending",
+			"add " + vpcA + ": ACTIVE",
+		}))
+		Expect(vpcCrnsOf(fake.List(networks))).To(Equal([]string{vpcA}))
+		Expect(fake.List(networks)[0]["id"]).ToNot(Equal(id))
+
+		fake.SettleLists = 1000
+		_, _, err = testService.DeletePermittedNetwork(testService.NewDeletePermittedNetworkOptions("i", "z", fake.List(networks)[0]["id"].(string)))
+		Expect(err).To(BeNil())
+		result, err = testService.SyncPermittedNetworks(context.Background(), options.SetTimeout(10*time.Millisecond).SetProgress(nil))
+		Expect(err).ToNot(BeNil())
+		Expect(result.Failures).To(HaveLen(2))
+		Expect(result.Failures[1].Err.Error()).To(ContainSubstring("was still being removed"))
+		Expect(fake.List(networks)).To(HaveLen(1))
+	})
+	It(`Invoke SyncPermittedNetworks with the response cache enabled`, func() {
+		fake.Add("/instances/i/dnszones", map[string]interface{}{"id": "z", "name": "example.com", "state": "active"})
+		Expect(testService.EnableResponseCache(testService.NewResponseCacheOptions(time.Minute))).To(Succeed())
+		_, err := testService.ListAllPermittedNetworks(testService.NewListPermittedNetworksOptions("i", "z"))
+		Expect(err).To(BeNil())
+		fake.Add(networks, map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcA}})
+		fake.SettleLists = 2
+
+		options := testService.NewSyncPermittedNetworksOptions("i", "z", []string{vpcA, vpcB}).SetTimeout(time.Second).SetPollInterval(time.Millisecond)
+		result, err := testService.SyncPermittedNetworks(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect([]int{result.Added, result.Removed, result.Kept}).To(Equal([]int{1, 0, 1}))
+		Expect(result.Changes[1].String()).To(Equal("add " + vpcB + ": ACTIVE"))
+	})
+	It(`Invoke SyncPermittedNetworks with a cancelled context`, func() {
+		fake.Add("/instances/i/dnszones", map[string]interface{}{"id": "z", "name": "example.com", "state": "active"})
+		fake.SettleLists = 1000
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		options := testService.NewSyncPermittedNetworksOptions("i", "z", []string{vpcA}).SetPollInterval(time.Minute)
+		options.SetProgress(func(change dnssvcsv1.SyncPermittedNetworksChange) {
+			cancel()
+		})
+		_, err := testService.SyncPermittedNetworks(ctx, options)
+		Expect(err).To(Equal(context.Canceled))
+		Expect(vpcCrnsOf(fake.List(networks))).To(Equal([]string{vpcA}))
+	})
+	It(`Invoke SyncPermittedNetworks with error`, func() {
+		fake.Add("/instances/i/dnszones", map[string]interface{}{"id": "z", "name": "example.com", "state": "active"})
+		fake.Add(networks, map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcA}})
+
+		_, err := testService.SyncPermittedNetworks(context.Background(), testService.NewSyncPermittedNetworksOptions("i", "z", []string{"vpc crn"}))
+		Expect(err).ToNot(BeNil())
+		Expect(fake.Bodies).To(BeEmpty())
+
+		fake.Failures["POST "+networks] = 409
+		result, err := testService.SyncPermittedNetworks(context.Background(), testService.NewSyncPermittedNetworksOptions("i", "z", []string{vpcB}))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("1 permitted networks of DNS zone z could not be synchronized"))
+		Expect(result.Failures).To(HaveLen(1))
+		Expect(result.Removed).To(Equal(1))
+
+		delete(fake.Failures, "POST "+networks)
+		fake.SettleLists = 1000
+		result, err = testService.SyncPermittedNetworks(context.Background(), testService.NewSyncPermittedNetworksOptions("i", "z", []string{vpcA}).SetTimeout(10*time.Millisecond).SetPollInterval(time.Millisecond))
+		Expect(err).ToNot(BeNil())
+		Expect(result.Failures[0].Err.Error()).To(Equal("the change did not take effect within 10ms"))
+
+		_, err = testService.SyncPermittedNetworks(context.Background(), testService.NewSyncPermittedNetworksOptions("i", "missing", nil))
+		Expect(err).ToNot(BeNil())
+		_, err = testService.SyncPermittedNetworks(context.Background(), nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
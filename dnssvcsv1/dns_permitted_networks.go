@@ -86,6 +86,38 @@ func (dnsSvcs *DnsSvcsV1) ListPermittedNetworks(listPermittedNetworksOptions *Li
 	return
 }
 
+// ListAllPermittedNetworks : List all permitted networks
+// List the permitted networks of a given DNS zone, walking every page from the given Offset. The Limit of the options
+// sets the page size.
+func (dnsSvcs *DnsSvcsV1) ListAllPermittedNetworks(listPermittedNetworksOptions *ListPermittedNetworksOptions) (permittedNetworks []PermittedNetwork, err error) {
+	err = core.ValidateNotNil(listPermittedNetworksOptions, "listPermittedNetworksOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	pageOptions := *listPermittedNetworksOptions
+	if pageOptions.Offset == nil {
+		pageOptions.Offset = core.Int64Ptr(0)
+	}
+	if pageOptions.Limit == nil {
+		pageOptions.Limit = core.Int64Ptr(defaultPageLimit)
+	}
+	for {
+		result, _, listErr := dnsSvcs.ListPermittedNetworks(&pageOptions)
+		if listErr != nil {
+			err = listErr
+			return
+		}
+		permittedNetworks = append(permittedNetworks, result.PermittedNetworks...)
+		if len(result.PermittedNetworks) == 0 || result.TotalCount == nil {
+			return
+		}
+		pageOptions.Offset = core.Int64Ptr(*pageOptions.Offset + int64(len(result.PermittedNetworks)))
+		if *pageOptions.Offset >= *result.TotalCount {
+			return
+		}
+	}
+}
+
 // CreatePermittedNetwork : Create a permitted network
 // Create a permitted network for a given DNS zone.
 func (dnsSvcs *DnsSvcsV1) CreatePermittedNetwork(createPermittedNetworkOptions *CreatePermittedNetworkOptions) (result *PermittedNetwork, response *core.DetailedResponse, err error) {
@@ -640,6 +672,30 @@ func (*DnsSvcsV1) NewPermittedNetworkVpc(vpcCrn string) (model *PermittedNetwork
 	return
 }
 
+// NewPermittedNetworkVpcFromCrn : Instantiate PermittedNetworkVpc from the CRN of a VPC, checking that it is one.
+func (*DnsSvcsV1) NewPermittedNetworkVpcFromCrn(vpcCrn *common.Crn) (model *PermittedNetworkVpc, err error) {
+	err = core.ValidateNotNil(vpcCrn, "vpcCrn cannot be nil")
+	if err != nil {
+		return
+	}
+	_, err = common.ParseVpcCrn(vpcCrn.String())
+	if err != nil {
+		return
+	}
+	model = &PermittedNetworkVpc{
+		VpcCrn: core.StringPtr(vpcCrn.String()),
+	}
+	return
+}
+
+// ParsedVpcCrn returns the parsed CRN of the VPC, or an error if it is missing or not the CRN of a VPC.
+func (permittedNetworkVpc *PermittedNetworkVpc) ParsedVpcCrn() (*common.Crn, error) {
+	if permittedNetworkVpc.VpcCrn == nil {
+		return nil, fmt.Errorf("the permitted network has no VPC CRN")
+	}
+	return common.ParseVpcCrn(*permittedNetworkVpc.VpcCrn)
+}
+
 // UnmarshalPermittedNetworkVpc unmarshals an instance of PermittedNetworkVpc from the specified map of raw messages.
 func UnmarshalPermittedNetworkVpc(m map[string]json.RawMessage, result interface{}) (err error) {
 	obj := new(PermittedNetworkVpc)
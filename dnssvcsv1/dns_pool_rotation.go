@@ -0,0 +1,375 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// Default durations of the RotatePoolOrigins workflow.
+const (
+	DefaultRotateHealthTimeout = 5 * time.Minute
+	DefaultRotatePollInterval  = 10 * time.Second
+	DefaultRotateDrainPeriod   = 60 * time.Second
+)
+
+// RotatePoolOriginsOptions : The RotatePoolOrigins options.
+type RotatePoolOriginsOptions struct {
+	// The unique identifier of a service instance.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The unique identifier of a load balancer pool.
+	PoolID *string `json:"pool_id" validate:"required"`
+
+	// The origins to add to the pool. Origins are added enabled; an origin with Enabled set to false is rejected, as it
+	// could never report healthy.
+	Add []OriginInput `json:"add,omitempty"`
+
+	// The origins to remove from the pool, by name or address.
+	Remove []string `json:"remove,omitempty"`
+
+	// How long to wait for the added origins to report healthy before rolling back, DefaultRotateHealthTimeout when
+	// zero.
+	HealthTimeout time.Duration `json:"health_timeout,omitempty"`
+
+	// How often the pool is read while waiting for the added origins, DefaultRotatePollInterval when zero.
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+
+	// How long disabled origins keep draining connections before they are removed, DefaultRotateDrainPeriod when zero.
+	DrainPeriod time.Duration `json:"drain_period,omitempty"`
+
+	// Called after every step of the workflow.
+	Progress func(RotatePoolOriginsProgress) `json:"-"`
+}
+
+// NewRotatePoolOriginsOptions : Instantiate RotatePoolOriginsOptions
+func (*DnsSvcsV1) NewRotatePoolOriginsOptions(instanceID string, poolID string) *RotatePoolOriginsOptions {
+	return &RotatePoolOriginsOptions{
+		InstanceID: core.StringPtr(instanceID),
+		PoolID:     core.StringPtr(poolID),
+	}
+}
+
+// SetAdd : Allow user to set Add
+func (options *RotatePoolOriginsOptions) SetAdd(add []OriginInput) *RotatePoolOriginsOptions {
+	options.Add = add
+	return options
+}
+
+// SetRemove : Allow user to set Remove
+func (options *RotatePoolOriginsOptions) SetRemove(remove []string) *RotatePoolOriginsOptions {
+	options.Remove = remove
+	return options
+}
+
+// SetHealthTimeout : Allow user to set HealthTimeout
+func (options *RotatePoolOriginsOptions) SetHealthTimeout(healthTimeout time.Duration) *RotatePoolOriginsOptions {
+	options.HealthTimeout = healthTimeout
+	return options
+}
+
+// SetPollInterval : Allow user to set PollInterval
+func (options *RotatePoolOriginsOptions) SetPollInterval(pollInterval time.Duration) *RotatePoolOriginsOptions {
+	options.PollInterval = pollInterval
+	return options
+}
+
+// SetDrainPeriod : Allow user to set DrainPeriod
+func (options *RotatePoolOriginsOptions) SetDrainPeriod(drainPeriod time.Duration) *RotatePoolOriginsOptions {
+	options.DrainPeriod = drainPeriod
+	return options
+}
+
+// SetProgress : Allow user to set Progress
+func (options *RotatePoolOriginsOptions) SetProgress(progress func(RotatePoolOriginsProgress)) *RotatePoolOriginsOptions {
+	options.Progress = progress
+	return options
+}
+
+// RotatePoolOriginsProgress : A step of the RotatePoolOrigins workflow.
+type RotatePoolOriginsProgress struct {
+	// The step of the workflow.
+	Step string
+
+	// The origins the step applies to, by name or address.
+	Origins []string
+
+	// Explanation of the step.
+	Message string
+
+	// The error of the step.
+	Err error
+}
+
+// Constants associated with the RotatePoolOriginsProgress.Step property.
+// The step of the workflow.
+const (
+	RotatePoolOriginsProgress_Step_AddOrigins     = "add_origins"
+	RotatePoolOriginsProgress_Step_WaitHealthy    = "wait_healthy"
+	RotatePoolOriginsProgress_Step_DisableOrigins = "disable_origins"
+	RotatePoolOriginsProgress_Step_Drain          = "drain"
+	RotatePoolOriginsProgress_Step_RemoveOrigins  = "remove_origins"
+	RotatePoolOriginsProgress_Step_Rollback       = "rollback"
+)
+
+// RotatePoolOriginsResult : Outcome of the RotatePoolOrigins workflow.
+type RotatePoolOriginsResult struct {
+	// The pool after the last update.
+	Pool *Pool
+
+	// The added origins that did not report healthy in time.
+	Unhealthy []string
+
+	// Whether the pool was restored to its original origins.
+	RolledBack bool
+}
+
+// RotatePoolOrigins : Replace the origins of a load balancer pool
+// Add origins to a pool and wait until every one of them reports healthy, then disable the origins being replaced,
+// wait for the drain period and remove them. The pool never has fewer enabled origins than its healthy origins
+// threshold: the request is rejected up front when the remaining origins would fall below it, or when an added origin
+// is disabled. The pool is read from the service, bypassing the response cache. When the added origins
+// do not report healthy within the health timeout, or the wait for them fails, the original origins are restored and
+// an error is returned. The context interrupts the waits; cancelled while draining, the disabled origins are left
+// disabled in the pool.
+func (dnsSvcs *DnsSvcsV1) RotatePoolOrigins(ctx context.Context, rotatePoolOriginsOptions *RotatePoolOriginsOptions) (result *RotatePoolOriginsResult, err error) {
+	err = core.ValidateNotNil(rotatePoolOriginsOptions, "rotatePoolOriginsOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(rotatePoolOriginsOptions, "rotatePoolOriginsOptions")
+	if err != nil {
+		return
+	}
+	options := rotatePoolOriginsOptions
+	progress := func(step RotatePoolOriginsProgress) {
+		if options.Progress != nil {
+			options.Progress(step)
+		}
+	}
+	instanceID, poolID := *options.InstanceID, *options.PoolID
+
+	pool, _, err := dnsSvcs.GetPool(dnsSvcs.NewGetPoolOptions(instanceID, poolID).SetHeaders(withoutCache(nil)))
+	if err != nil {
+		return
+	}
+	original := originInputsOf(pool.Origins, nil)
+	removed, added, err := planOriginRotation(pool, options.Add, options.Remove)
+	if err != nil {
+		return
+	}
+	result = &RotatePoolOriginsResult{Pool: pool}
+
+	updatePool := func(step string, origins []OriginInput, names []string, message string) error {
+		updateOptions := dnsSvcs.NewUpdatePoolOptions(instanceID, poolID)
+		updateOptions.SetOrigins(origins)
+		pool, _, err := dnsSvcs.UpdatePool(updateOptions)
+		if err == nil {
+			result.Pool = pool
+		}
+		progress(RotatePoolOriginsProgress{Step: step, Origins: names, Message: message, Err: err})
+		return err
+	}
+
+	rotated := append(append([]OriginInput{}, original...), options.Add...)
+	for i := len(original); i < len(rotated); i++ {
+		if rotated[i].Enabled == nil {
+			rotated[i].Enabled = core.BoolPtr(true)
+		}
+	}
+	err = updatePool(RotatePoolOriginsProgress_Step_AddOrigins, rotated, added, fmt.Sprintf("added %d origins", len(added)))
+	if err != nil {
+		return
+	}
+
+	result.Unhealthy, err = dnsSvcs.waitForHealthyOrigins(ctx, instanceID, poolID, added, options, result)
+	progress(RotatePoolOriginsProgress{Step: RotatePoolOriginsProgress_Step_WaitHealthy, Origins: added, Message: fmt.Sprintf("%d of %d added origins healthy", len(added)-len(result.Unhealthy), len(added)), Err: err})
+	if err != nil || len(result.Unhealthy) > 0 {
+		reason := fmt.Sprintf("origins %s of pool %s did not become healthy within %s", strings.Join(result.Unhealthy, ", "), poolID, durationOrDefault(options.HealthTimeout, DefaultRotateHealthTimeout))
+		if err != nil {
+			reason = fmt.Sprintf("waiting for origins %s of pool %s to become healthy failed: %s", strings.Join(added, ", "), poolID, err.Error())
+		}
+		err = updatePool(RotatePoolOriginsProgress_Step_Rollback, original, added, "restored the original origins")
+		if err != nil {
+			err = fmt.Errorf("%s and restoring the original origins failed: %s", reason, err.Error())
+			return
+		}
+		result.RolledBack = true
+		err = fmt.Errorf("%s, the original origins were restored", reason)
+		return
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	err = updatePool(RotatePoolOriginsProgress_Step_DisableOrigins, originInputsOf(result.Pool.Origins, removed), options.Remove, fmt.Sprintf("disabled %d origins", len(options.Remove)))
+	if err != nil {
+		return
+	}
+	drainPeriod := durationOrDefault(options.DrainPeriod, DefaultRotateDrainPeriod)
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-time.After(drainPeriod):
+	}
+	progress(RotatePoolOriginsProgress{Step: RotatePoolOriginsProgress_Step_Drain, Origins: options.Remove, Message: fmt.Sprintf("drained for %s", drainPeriod), Err: err})
+	if err != nil {
+		return
+	}
+
+	var remaining []OriginInput
+	for _, origin := range originInputsOf(result.Pool.Origins, nil) {
+		if !removed[originKey(origin.Name, origin.Address)] {
+			remaining = append(remaining, origin)
+		}
+	}
+	err = updatePool(RotatePoolOriginsProgress_Step_RemoveOrigins, remaining, options.Remove, fmt.Sprintf("removed %d origins", len(options.Remove)))
+	return
+}
+
+// planOriginRotation checks a rotation against the current origins of a pool, and returns the keys of the origins to
+// remove and the labels of the origins to add.
+func planOriginRotation(pool *Pool, add []OriginInput, remove []string) (removed map[string]bool, added []string, err error) {
+	current := make(map[string]bool)
+	for _, origin := range pool.Origins {
+		for _, value := range []*string{origin.Name, origin.Address} {
+			if value != nil && *value != "" {
+				current[strings.ToLower(*value)] = true
+			}
+		}
+	}
+	removed = make(map[string]bool)
+	for _, name := range remove {
+		if !current[strings.ToLower(name)] {
+			err = fmt.Errorf("origin %s is not in pool %s", name, poolLabel(pool))
+			return
+		}
+		for _, origin := range pool.Origins {
+			if strings.EqualFold(stringValue(origin.Name, ""), name) || strings.EqualFold(stringValue(origin.Address, ""), name) {
+				removed[originKey(origin.Name, origin.Address)] = true
+			}
+		}
+	}
+	for _, origin := range add {
+		if origin.Address == nil {
+			err = fmt.Errorf("origins added to pool %s require an address", poolLabel(pool))
+			return
+		}
+		if current[strings.ToLower(*origin.Address)] || (origin.Name != nil && current[strings.ToLower(*origin.Name)]) {
+			err = fmt.Errorf("origin %s is already in pool %s", originLabel(origin.Name, origin.Address), poolLabel(pool))
+			return
+		}
+		if !boolValue(origin.Enabled, true) {
+			err = fmt.Errorf("origin %s is added to pool %s disabled, and would never report healthy", originLabel(origin.Name, origin.Address), poolLabel(pool))
+			return
+		}
+		added = append(added, originLabel(origin.Name, origin.Address))
+	}
+
+	enabled := 0
+	for _, origin := range pool.Origins {
+		if boolValue(origin.Enabled, true) && !removed[originKey(origin.Name, origin.Address)] {
+			enabled++
+		}
+	}
+	enabled += len(add)
+	threshold := int(int64Value(pool.HealthyOriginsThreshold, 1))
+	if enabled < threshold {
+		err = fmt.Errorf("rotating the origins of pool %s leaves %d enabled origins, below its healthy origins threshold %d", poolLabel(pool), enabled, threshold)
+	}
+	return
+}
+
+// waitForHealthyOrigins polls a pool until every added origin reports healthy or the health timeout expires, and
+// returns the origins still not healthy. Unless the pool returned by the update already reports them healthy, the pool
+// is read at least once, however short the health timeout.
+func (dnsSvcs *DnsSvcsV1) waitForHealthyOrigins(ctx context.Context, instanceID string, poolID string, added []string, options *RotatePoolOriginsOptions, result *RotatePoolOriginsResult) (unhealthy []string, err error) {
+	deadline := time.Now().Add(durationOrDefault(options.HealthTimeout, DefaultRotateHealthTimeout))
+	pollInterval := durationOrDefault(options.PollInterval, DefaultRotatePollInterval)
+	for polls := 0; ; polls++ {
+		unhealthy = nil
+		for _, label := range added {
+			healthy := false
+			for _, origin := range result.Pool.Origins {
+				if originLabel(origin.Name, origin.Address) == label {
+					healthy = boolValue(origin.Health, false)
+				}
+			}
+			if !healthy {
+				unhealthy = append(unhealthy, label)
+			}
+		}
+		if len(unhealthy) == 0 || (polls > 0 && !time.Now().Before(deadline)) {
+			return
+		}
+		wait := pollInterval
+		if remaining := time.Until(deadline); remaining < wait {
+			wait = remaining
+		}
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(wait):
+			}
+		}
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		var pool *Pool
+		pool, _, err = dnsSvcs.GetPool(dnsSvcs.NewGetPoolOptions(instanceID, poolID).SetHeaders(withoutCache(nil)))
+		if err != nil {
+			return
+		}
+		result.Pool = pool
+	}
+}
+
+// originInputsOf converts the origins of a pool for UpdatePool, disabling those whose key is in disabled.
+func originInputsOf(origins []Origin, disabled map[string]bool) []OriginInput {
+	inputs := make([]OriginInput, 0, len(origins))
+	for _, origin := range origins {
+		input := OriginInput{Name: origin.Name, Description: origin.Description, Address: origin.Address, Enabled: origin.Enabled}
+		if disabled[originKey(origin.Name, origin.Address)] {
+			input.Enabled = core.BoolPtr(false)
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+func originKey(name *string, address *string) string {
+	return strings.ToLower(stringValue(name, "")) + " " + strings.ToLower(stringValue(address, ""))
+}
+
+func originLabel(name *string, address *string) string {
+	if name != nil && *name != "" {
+		return *name
+	}
+	return stringValue(address, "")
+}
+
+func durationOrDefault(duration time.Duration, defaultDuration time.Duration) time.Duration {
+	if duration <= 0 {
+		return defaultDuration
+	}
+	return duration
+}
@@ -0,0 +1,183 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`RotatePoolOrigins(ctx context.Context, rotatePoolOriginsOptions *RotatePoolOriginsOptions)`, func() {
+	var fake *fakednssvcs.Server
+	var steps []string
+	newOptions := func() *dnssvcsv1.RotatePoolOriginsOptions {
+		steps = nil
+		options := fake.Service().NewRotatePoolOriginsOptions("instance", "pool")
+		options.SetAdd([]dnssvcsv1.OriginInput{{Name: core.StringPtr("new-1"), Address: core.StringPtr("10.0.0.3")}})
+		options.SetRemove([]string{"10.0.0.1"})
+		options.SetHealthTimeout(50 * time.Millisecond).SetPollInterval(5 * time.Millisecond).SetDrainPeriod(time.Millisecond)
+		options.SetProgress(func(step dnssvcsv1.RotatePoolOriginsProgress) {
+			steps = append(steps, step.Step)
+		})
+		return options
+	}
+	originsOf := func() (origins []string) {
+		for _, origin := range fake.List("/instances/instance/pools")[0]["origins"].([]interface{}) {
+			origin := origin.(map[string]interface{})
+			origins = append(origins, fmt.Sprintf("%v %v", origin["name"], origin["enabled"]))
+		}
+		return
+	}
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		fake.Add("/instances/instance/pools", map[string]interface{}{
+			"id": "pool", "name": "web", "enabled": true, "healthy_origins_threshold": 1,
+			"origins": []interface{}{
+				map[string]interface{}{"name": "old-1", "address": "10.0.0.1", "enabled": true},
+				map[string]interface{}{"name": "old-2", "address": "10.0.0.2", "enabled": true},
+			},
+		})
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke RotatePoolOrigins successfully`, func() {
+		result, err := fake.Service().RotatePoolOrigins(context.Background(), newOptions())
+		Expect(err).To(BeNil())
+		Expect(result.RolledBack).To(BeFalse())
+		Expect(steps).To(Equal([]string{
+			dnssvcsv1.RotatePoolOriginsProgress_Step_AddOrigins,
+			dnssvcsv1.RotatePoolOriginsProgress_Step_WaitHealthy,
+			dnssvcsv1.RotatePoolOriginsProgress_Step_DisableOrigins,
+			dnssvcsv1.RotatePoolOriginsProgress_Step_Drain,
+			dnssvcsv1.RotatePoolOriginsProgress_Step_RemoveOrigins,
+		}))
+		Expect(originsOf()).To(Equal([]string{"old-2 true", "new-1 true"}))
+		Expect(result.Pool.Origins).To(HaveLen(2))
+	})
+	It(`Invoke RotatePoolOrigins and roll back when health does not converge`, func() {
+		fake.OriginHealth["10.0.0.3"] = false
+		result, err := fake.Service().RotatePoolOrigins(context.Background(), newOptions())
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("origins new-1 of pool pool did not become healthy"))
+		Expect(result.RolledBack).To(BeTrue())
+		Expect(result.Unhealthy).To(Equal([]string{"new-1"}))
+		Expect(steps[len(steps)-1]).To(Equal(dnssvcsv1.RotatePoolOriginsProgress_Step_Rollback))
+		Expect(originsOf()).To(Equal([]string{"old-1 true", "old-2 true"}))
+	})
+	It(`Invoke RotatePoolOrigins and roll back when the pool cannot be read`, func() {
+		fake.OriginHealth["10.0.0.3"] = false
+		options := newOptions()
+		options.SetProgress(func(step dnssvcsv1.RotatePoolOriginsProgress) {
+			steps = append(steps, step.Step)
+			if step.Step == dnssvcsv1.RotatePoolOriginsProgress_Step_AddOrigins {
+				fake.Failures["GET /instances/instance/pools/pool"] = 500
+			}
+		})
+		result, err := fake.Service().RotatePoolOrigins(context.Background(), options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("waiting for origins new-1 of pool pool to become healthy failed"))
+		Expect(result.RolledBack).To(BeTrue())
+		Expect(steps[len(steps)-1]).To(Equal(dnssvcsv1.RotatePoolOriginsProgress_Step_Rollback))
+		Expect(originsOf()).To(Equal([]string{"old-1 true", "old-2 true"}))
+	})
+	It(`Invoke RotatePoolOrigins with a health timeout shorter than the poll interval`, func() {
+		fake.OriginHealth["10.0.0.3"] = false
+		options := newOptions().SetHealthTimeout(time.Millisecond).SetPollInterval(time.Minute)
+		options.SetProgress(func(step dnssvcsv1.RotatePoolOriginsProgress) {
+			if step.Step == dnssvcsv1.RotatePoolOriginsProgress_Step_AddOrigins {
+				fake.OriginHealth["10.0.0.3"] = true
+			}
+		})
+		result, err := fake.Service().RotatePoolOrigins(context.Background(), options)
+		Expect(err).To(BeNil())
+		Expect(result.RolledBack).To(BeFalse())
+		Expect(originsOf()).To(Equal([]string{"old-2 true", "new-1 true"}))
+	})
+	It(`Invoke RotatePoolOrigins with the response cache enabled`, func() {
+		fake.OriginHealth["10.0.0.3"] = false
+		testService := fake.Service()
+		reads := 0
+		testService.Service.Client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			response, err := http.DefaultTransport.RoundTrip(req)
+			if req.Method == http.MethodGet {
+				reads++
+				if reads == 2 {
+					fake.OriginHealth["10.0.0.3"] = true
+				}
+			}
+			return response, err
+		})
+		Expect(testService.EnableResponseCache(testService.NewResponseCacheOptions(time.Minute))).To(Succeed())
+		result, err := testService.RotatePoolOrigins(context.Background(), newOptions())
+		Expect(err).To(BeNil())
+		Expect(result.RolledBack).To(BeFalse())
+		Expect(reads).To(Equal(3))
+		Expect(originsOf()).To(Equal([]string{"old-2 true", "new-1 true"}))
+	})
+	It(`Invoke RotatePoolOrigins with a cancelled context`, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		options := newOptions().SetDrainPeriod(time.Minute)
+		options.SetProgress(func(step dnssvcsv1.RotatePoolOriginsProgress) {
+			steps = append(steps, step.Step)
+			if step.Step == dnssvcsv1.RotatePoolOriginsProgress_Step_DisableOrigins {
+				cancel()
+			}
+		})
+		_, err := fake.Service().RotatePoolOrigins(ctx, options)
+		Expect(err).To(Equal(context.Canceled))
+		Expect(steps[len(steps)-1]).To(Equal(dnssvcsv1.RotatePoolOriginsProgress_Step_Drain))
+		Expect(originsOf()).To(Equal([]string{"old-1 false", "old-2 true", "new-1 true"}))
+	})
+	It(`Invoke RotatePoolOrigins with error`, func() {
+		options := newOptions()
+		options.SetRemove([]string{"old-1", "old-2"}).SetAdd(nil)
+		result, err := fake.Service().RotatePoolOrigins(context.Background(), options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("below its healthy origins threshold 1"))
+		Expect(result).To(BeNil())
+
+		options = newOptions()
+		options.SetAdd([]dnssvcsv1.OriginInput{{Name: core.StringPtr("new-1"), Address: core.StringPtr("10.0.0.3"), Enabled: core.BoolPtr(false)}})
+		_, err = fake.Service().RotatePoolOrigins(context.Background(), options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("origin new-1 is added to pool web disabled, and would never report healthy"))
+
+		options = newOptions()
+		options.SetRemove([]string{"10.0.0.9"})
+		_, err = fake.Service().RotatePoolOrigins(context.Background(), options)
+		Expect(err).ToNot(BeNil())
+		Expect(fake.Requests).To(Equal([]string{"GET /instances/instance/pools/pool", "GET /instances/instance/pools/pool", "GET /instances/instance/pools/pool"}))
+
+		fake.Failures["PUT /instances/instance/pools/pool"] = 500
+		_, err = fake.Service().RotatePoolOrigins(context.Background(), newOptions())
+		Expect(err).ToNot(BeNil())
+		Expect(originsOf()).To(Equal([]string{"old-1 true", "old-2 true"}))
+
+		_, err = fake.Service().RotatePoolOrigins(context.Background(), nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,387 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	reverseZoneSuffixV4 = ".in-addr.arpa"
+	reverseZoneSuffixV6 = ".ip6.arpa"
+	hexDigits           = "0123456789abcdef"
+)
+
+// ReverseName returns the name of the PTR record of an IP address: the reversed octets under "in-addr.arpa" for
+// IPv4, and the reversed nibbles under "ip6.arpa" for IPv6.
+func ReverseName(ip string) (reverseName string, err error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		err = fmt.Errorf("'%s' is not a valid IP address", ip)
+		return
+	}
+	if ipv4 := addr.To4(); ipv4 != nil {
+		reverseName = fmt.Sprintf("%d.%d.%d.%d%s", ipv4[3], ipv4[2], ipv4[1], ipv4[0], reverseZoneSuffixV4)
+		return
+	}
+	nibbles := make([]string, 0, 2*net.IPv6len)
+	for i := net.IPv6len - 1; i >= 0; i-- {
+		nibbles = append(nibbles, string(hexDigits[addr[i]&0x0f]), string(hexDigits[addr[i]>>4]))
+	}
+	reverseName = strings.Join(nibbles, ".") + reverseZoneSuffixV6
+	return
+}
+
+// ReverseNameToIP returns the IP address a PTR record name refers to. It is the inverse of ReverseName.
+func ReverseNameToIP(reverseName string) (ip net.IP, err error) {
+	name := strings.ToLower(strings.TrimSuffix(reverseName, "."))
+	switch {
+	case strings.HasSuffix(name, reverseZoneSuffixV4):
+		labels := strings.Split(strings.TrimSuffix(name, reverseZoneSuffixV4), ".")
+		if len(labels) == net.IPv4len {
+			ip = net.ParseIP(strings.Join([]string{labels[3], labels[2], labels[1], labels[0]}, "."))
+		}
+	case strings.HasSuffix(name, reverseZoneSuffixV6):
+		labels := strings.Split(strings.TrimSuffix(name, reverseZoneSuffixV6), ".")
+		if len(labels) == 2*net.IPv6len {
+			ip = make(net.IP, net.IPv6len)
+			for i, label := range labels {
+				nibble := strings.Index(hexDigits, label)
+				if len(label) != 1 || nibble < 0 {
+					ip = nil
+					break
+				}
+				ip[net.IPv6len-1-i/2] |= byte(nibble) << (4 * uint(i%2))
+			}
+		}
+	}
+	if ip == nil {
+		err = fmt.Errorf("'%s' is not a valid reverse-lookup name", reverseName)
+	}
+	return
+}
+
+// PtrRecordManager : Keeps the PTR records of a reverse zone ("in-addr.arpa" or "ip6.arpa") in step with the A and
+// AAAA records of a forward zone.
+type PtrRecordManager struct {
+	// The unique identifier of a service instance.
+	InstanceID string
+
+	// The unique identifier of the forward DNS zone holding A and AAAA records.
+	ForwardZoneID string
+
+	// The unique identifier of the reverse DNS zone holding PTR records.
+	ReverseZoneID string
+
+	dnsSvcs *DnsSvcsV1
+}
+
+// NewPtrRecordManager : Instantiate PtrRecordManager
+func (dnsSvcs *DnsSvcsV1) NewPtrRecordManager(instanceID string, forwardZoneID string, reverseZoneID string) *PtrRecordManager {
+	return &PtrRecordManager{
+		InstanceID:    instanceID,
+		ForwardZoneID: forwardZoneID,
+		ReverseZoneID: reverseZoneID,
+		dnsSvcs:       dnsSvcs,
+	}
+}
+
+// CreateAddressRecord creates an A or AAAA record, depending on the IP version, in the forward zone together with its
+// PTR record in the reverse zone. The address record is deleted again when the PTR record cannot be created.
+func (manager *PtrRecordManager) CreateAddressRecord(name string, ip string, ttl int64) (record *ResourceRecord, ptrRecord *ResourceRecord, err error) {
+	reverseName, err := ReverseName(ip)
+	if err != nil {
+		return
+	}
+	createOptions := manager.dnsSvcs.NewCreateResourceRecordOptions(manager.InstanceID, manager.ForwardZoneID)
+	createOptions.SetName(name)
+	createOptions.SetTTL(ttl)
+	if net.ParseIP(ip).To4() != nil {
+		rdata, _ := manager.dnsSvcs.NewResourceRecordInputRdataRdataARecord(ip)
+		createOptions.SetType(CreateResourceRecordOptions_Type_A).SetRdata(rdata)
+	} else {
+		rdata, _ := manager.dnsSvcs.NewResourceRecordInputRdataRdataAaaaRecord(ip)
+		createOptions.SetType(CreateResourceRecordOptions_Type_Aaaa).SetRdata(rdata)
+	}
+	record, _, err = manager.dnsSvcs.CreateResourceRecord(createOptions)
+	if err != nil {
+		return
+	}
+
+	ptrRecord, err = manager.createPtrRecord(reverseName, *record.Name, ttl)
+	if err != nil {
+		deleteOptions := manager.dnsSvcs.NewDeleteResourceRecordOptions(manager.InstanceID, manager.ForwardZoneID, *record.ID)
+		if _, deleteErr := manager.dnsSvcs.DeleteResourceRecord(deleteOptions); deleteErr != nil {
+			err = fmt.Errorf("%s; removing A/AAAA record %s also failed: %s", err.Error(), *record.ID, deleteErr.Error())
+		}
+		record = nil
+	}
+	return
+}
+
+// UpdateAddressRecord updates the name, IP address and TTL of an A or AAAA record and moves its PTR record along. An
+// empty name or ip, or a zero ttl, keeps the current value. The address record and the PTR records are restored when
+// the PTR record cannot be moved; an error reports any of them that could not be restored.
+func (manager *PtrRecordManager) UpdateAddressRecord(recordID string, name string, ip string, ttl int64) (record *ResourceRecord, ptrRecord *ResourceRecord, err error) {
+	current, err := manager.getAddressRecord(recordID)
+	if err != nil {
+		return
+	}
+	currentIP, err := addressOf(current)
+	if err != nil {
+		return
+	}
+	if ip == "" {
+		ip = currentIP
+	}
+	reverseName, err := ReverseName(ip)
+	if err != nil {
+		return
+	}
+
+	updateOptions := manager.dnsSvcs.NewUpdateResourceRecordOptions(manager.InstanceID, manager.ForwardZoneID, recordID)
+	if name != "" {
+		updateOptions.SetName(name)
+	}
+	if ttl != 0 {
+		updateOptions.SetTTL(ttl)
+	}
+	if *current.Type == ResourceRecord_Type_A {
+		rdata, _ := manager.dnsSvcs.NewResourceRecordUpdateInputRdataRdataARecord(ip)
+		updateOptions.SetRdata(rdata)
+	} else {
+		rdata, _ := manager.dnsSvcs.NewResourceRecordUpdateInputRdataRdataAaaaRecord(ip)
+		updateOptions.SetRdata(rdata)
+	}
+	record, _, err = manager.dnsSvcs.UpdateResourceRecord(updateOptions)
+	if err != nil {
+		return
+	}
+
+	ptrRecord, err = manager.movePtrRecord(current, currentIP, record, reverseName)
+	if err != nil {
+		if restoreErr := manager.restoreAddressRecord(current, currentIP); restoreErr != nil {
+			err = fmt.Errorf("%s; restoring A/AAAA record %s also failed: %s", err.Error(), recordID, restoreErr.Error())
+		}
+		record = nil
+	}
+	return
+}
+
+// movePtrRecord replaces the PTR records of the previous address record by one for the updated record. The PTR
+// records deleted are created again when the new one cannot be created.
+func (manager *PtrRecordManager) movePtrRecord(previous *ResourceRecord, previousIP string, record *ResourceRecord, reverseName string) (ptrRecord *ResourceRecord, err error) {
+	ptrRecords, err := manager.findPtrRecords(previousIP, *previous.Name)
+	if err != nil {
+		return
+	}
+	var deleted []ResourceRecord
+	for _, stale := range ptrRecords {
+		deleteOptions := manager.dnsSvcs.NewDeleteResourceRecordOptions(manager.InstanceID, manager.ReverseZoneID, *stale.ID)
+		_, err = manager.dnsSvcs.DeleteResourceRecord(deleteOptions)
+		if err != nil {
+			break
+		}
+		deleted = append(deleted, stale)
+	}
+	if err == nil {
+		ptrRecord, err = manager.createPtrRecord(reverseName, *record.Name, *record.TTL)
+	}
+	if err != nil {
+		for _, stale := range deleted {
+			if _, createErr := manager.createPtrRecord(*stale.Name, *previous.Name, int64Value(stale.TTL, 0)); createErr != nil {
+				err = fmt.Errorf("%s; restoring PTR record %s also failed: %s", err.Error(), *stale.Name, createErr.Error())
+			}
+		}
+	}
+	return
+}
+
+// restoreAddressRecord sets the name, IP address and TTL of an A or AAAA record back to those of previous.
+func (manager *PtrRecordManager) restoreAddressRecord(previous *ResourceRecord, previousIP string) (err error) {
+	updateOptions := manager.dnsSvcs.NewUpdateResourceRecordOptions(manager.InstanceID, manager.ForwardZoneID, *previous.ID)
+	updateOptions.SetName(*previous.Name)
+	if previous.TTL != nil {
+		updateOptions.SetTTL(*previous.TTL)
+	}
+	if *previous.Type == ResourceRecord_Type_A {
+		rdata, _ := manager.dnsSvcs.NewResourceRecordUpdateInputRdataRdataARecord(previousIP)
+		updateOptions.SetRdata(rdata)
+	} else {
+		rdata, _ := manager.dnsSvcs.NewResourceRecordUpdateInputRdataRdataAaaaRecord(previousIP)
+		updateOptions.SetRdata(rdata)
+	}
+	_, _, err = manager.dnsSvcs.UpdateResourceRecord(updateOptions)
+	return
+}
+
+// DeleteAddressRecord deletes an A or AAAA record together with the PTR records pointing back at it.
+func (manager *PtrRecordManager) DeleteAddressRecord(recordID string) (deletedPtrRecords []ResourceRecord, err error) {
+	current, err := manager.getAddressRecord(recordID)
+	if err != nil {
+		return
+	}
+	currentIP, err := addressOf(current)
+	if err != nil {
+		return
+	}
+	ptrRecords, err := manager.findPtrRecords(currentIP, *current.Name)
+	if err != nil {
+		return
+	}
+
+	deleteOptions := manager.dnsSvcs.NewDeleteResourceRecordOptions(manager.InstanceID, manager.ForwardZoneID, recordID)
+	_, err = manager.dnsSvcs.DeleteResourceRecord(deleteOptions)
+	if err != nil {
+		return
+	}
+	for _, ptrRecord := range ptrRecords {
+		deleteOptions = manager.dnsSvcs.NewDeleteResourceRecordOptions(manager.InstanceID, manager.ReverseZoneID, *ptrRecord.ID)
+		_, err = manager.dnsSvcs.DeleteResourceRecord(deleteOptions)
+		if err != nil {
+			return
+		}
+		deletedPtrRecords = append(deletedPtrRecords, ptrRecord)
+	}
+	return
+}
+
+// FindOrphanedPtrRecords returns the PTR records of the reverse zone which point at a name in the forward zone that
+// has no A or AAAA record with the matching IP address.
+func (manager *PtrRecordManager) FindOrphanedPtrRecords() (orphans []ResourceRecord, err error) {
+	forwardZone, _, err := manager.dnsSvcs.GetDnszone(manager.dnsSvcs.NewGetDnszoneOptions(manager.InstanceID, manager.ForwardZoneID))
+	if err != nil {
+		return
+	}
+	forwardRecords, err := manager.dnsSvcs.ListAllResourceRecords(manager.dnsSvcs.NewListResourceRecordsOptions(manager.InstanceID, manager.ForwardZoneID))
+	if err != nil {
+		return
+	}
+	addresses := make(map[string]bool)
+	for i := range forwardRecords {
+		if ip, addrErr := addressOf(&forwardRecords[i]); addrErr == nil {
+			addresses[normalizeDomainName(*forwardRecords[i].Name)+" "+ip] = true
+		}
+	}
+
+	reverseRecords, err := manager.dnsSvcs.ListAllResourceRecords(manager.dnsSvcs.NewListResourceRecordsOptions(manager.InstanceID, manager.ReverseZoneID).SetType(ListResourceRecordsOptions_Type_Ptr))
+	if err != nil {
+		return
+	}
+	zoneSuffix := "." + normalizeDomainName(*forwardZone.Name)
+	for _, ptrRecord := range reverseRecords {
+		if ptrRecord.Type == nil || *ptrRecord.Type != ResourceRecord_Type_Ptr {
+			continue
+		}
+		ip, ptrdname := ptrTargetOf(&ptrRecord)
+		if ptrdname != zoneSuffix[1:] && !strings.HasSuffix(ptrdname, zoneSuffix) {
+			continue
+		}
+		if ip == nil || !addresses[ptrdname+" "+ip.String()] {
+			orphans = append(orphans, ptrRecord)
+		}
+	}
+	return
+}
+
+func (manager *PtrRecordManager) getAddressRecord(recordID string) (record *ResourceRecord, err error) {
+	record, _, err = manager.dnsSvcs.GetResourceRecord(manager.dnsSvcs.NewGetResourceRecordOptions(manager.InstanceID, manager.ForwardZoneID, recordID))
+	if err != nil {
+		return
+	}
+	if record.Type == nil || (*record.Type != ResourceRecord_Type_A && *record.Type != ResourceRecord_Type_Aaaa) {
+		err = fmt.Errorf("resource record %s is not an A or AAAA record", recordID)
+	}
+	return
+}
+
+func (manager *PtrRecordManager) createPtrRecord(reverseName string, ptrdname string, ttl int64) (ptrRecord *ResourceRecord, err error) {
+	rdata, err := manager.dnsSvcs.NewResourceRecordInputRdataRdataPtrRecord(ptrdname)
+	if err != nil {
+		return
+	}
+	createOptions := manager.dnsSvcs.NewCreateResourceRecordOptions(manager.InstanceID, manager.ReverseZoneID)
+	createOptions.SetName(reverseName)
+	createOptions.SetType(CreateResourceRecordOptions_Type_Ptr)
+	createOptions.SetRdata(rdata)
+	if ttl != 0 {
+		createOptions.SetTTL(ttl)
+	}
+	ptrRecord, _, err = manager.dnsSvcs.CreateResourceRecord(createOptions)
+	return
+}
+
+// findPtrRecords returns the PTR records of the reverse zone mapping ip to ptrdname.
+func (manager *PtrRecordManager) findPtrRecords(ip string, ptrdname string) (ptrRecords []ResourceRecord, err error) {
+	reverseRecords, err := manager.dnsSvcs.ListAllResourceRecords(manager.dnsSvcs.NewListResourceRecordsOptions(manager.InstanceID, manager.ReverseZoneID).SetType(ListResourceRecordsOptions_Type_Ptr))
+	if err != nil {
+		return
+	}
+	wanted := net.ParseIP(ip)
+	for _, ptrRecord := range reverseRecords {
+		if ptrRecord.Type == nil || *ptrRecord.Type != ResourceRecord_Type_Ptr {
+			continue
+		}
+		recordIP, recordPtrdname := ptrTargetOf(&ptrRecord)
+		if recordIP != nil && recordIP.Equal(wanted) && recordPtrdname == normalizeDomainName(ptrdname) {
+			ptrRecords = append(ptrRecords, ptrRecord)
+		}
+	}
+	return
+}
+
+// addressOf returns the IP address of an A or AAAA record.
+func addressOf(record *ResourceRecord) (ip string, err error) {
+	if record.Type == nil || (*record.Type != ResourceRecord_Type_A && *record.Type != ResourceRecord_Type_Aaaa) {
+		err = fmt.Errorf("resource record is not an A or AAAA record")
+		return
+	}
+	var rdata *ResourceRecordInputRdataRdataARecord
+	err = record.GetRdataModel(UnmarshalResourceRecordInputRdataRdataARecord, &rdata)
+	if err != nil {
+		return
+	}
+	if rdata.Ip == nil || net.ParseIP(*rdata.Ip) == nil {
+		err = fmt.Errorf("resource record has no valid IP address")
+		return
+	}
+	ip = net.ParseIP(*rdata.Ip).String()
+	return
+}
+
+// ptrTargetOf returns the IP address named by a PTR record and the normalized host name it points at. The record
+// name may be either the reverse-lookup name or the IP address itself.
+func ptrTargetOf(ptrRecord *ResourceRecord) (ip net.IP, ptrdname string) {
+	if ptrRecord.Name != nil {
+		ip = net.ParseIP(*ptrRecord.Name)
+		if ip == nil {
+			ip, _ = ReverseNameToIP(*ptrRecord.Name)
+		}
+	}
+	var rdata *ResourceRecordInputRdataRdataPtrRecord
+	if ptrRecord.GetRdataModel(UnmarshalResourceRecordInputRdataRdataPtrRecord, &rdata) == nil && rdata.Ptrdname != nil {
+		ptrdname = normalizeDomainName(*rdata.Ptrdname)
+	}
+	return
+}
+
+// normalizeDomainName lower-cases a domain name and strips its trailing dot.
+func normalizeDomainName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
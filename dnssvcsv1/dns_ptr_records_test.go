@@ -0,0 +1,130 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"net/http"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`PTR record helpers`, func() {
+	Describe(`ReverseName(ip string)`, func() {
+		It(`Compute IPv4 and IPv6 reverse names`, func() {
+			name, err := dnssvcsv1.ReverseName("192.0.2.10")
+			Expect(err).To(BeNil())
+			Expect(name).To(Equal("10.2.0.192.in-addr.arpa"))
+
+			name, err = dnssvcsv1.ReverseName("2001:db8::567:89ab")
+			Expect(err).To(BeNil())
+			Expect(name).To(Equal("b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"))
+
+			ip, err := dnssvcsv1.ReverseNameToIP(name + ".")
+			Expect(err).To(BeNil())
+			Expect(ip.String()).To(Equal("2001:db8::567:89ab"))
+
+			ip, err = dnssvcsv1.ReverseNameToIP("10.2.0.192.in-addr.arpa")
+			Expect(err).To(BeNil())
+			Expect(ip.String()).To(Equal("192.0.2.10"))
+		})
+		It(`Invoke ReverseName and ReverseNameToIP with invalid input`, func() {
+			_, err := dnssvcsv1.ReverseName("192.0.2")
+			Expect(err).ToNot(BeNil())
+			_, err = dnssvcsv1.ReverseNameToIP("2.0.192.in-addr.arpa")
+			Expect(err).ToNot(BeNil())
+			_, err = dnssvcsv1.ReverseNameToIP("www.example.com")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+	Describe(`PtrRecordManager`, func() {
+		var fake *fakednssvcs.Server
+		var manager *dnssvcsv1.PtrRecordManager
+		forwardRecords := "/instances/instance/dnszones/forward/resource_records"
+		reverseRecords := "/instances/instance/dnszones/reverse/resource_records"
+		BeforeEach(func() {
+			fake = fakednssvcs.NewServer()
+			fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "forward", "name": "example.com"})
+			fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "reverse", "name": "2.0.192.in-addr.arpa"})
+			manager = fake.Service().NewPtrRecordManager("instance", "forward", "reverse")
+		})
+		AfterEach(func() {
+			fake.Close()
+		})
+		It(`Create, update and delete an A record with its PTR record`, func() {
+			record, ptrRecord, err := manager.CreateAddressRecord("www", "192.0.2.10", 300)
+			Expect(err).To(BeNil())
+			Expect(*record.Type).To(Equal(dnssvcsv1.ResourceRecord_Type_A))
+			Expect(*record.Name).To(Equal("www.example.com"))
+			Expect(*ptrRecord.Name).To(Equal("10.2.0.192.in-addr.arpa"))
+			Expect(ptrRecord.Rdata).To(Equal(map[string]interface{}{"ptrdname": "www.example.com"}))
+
+			record, ptrRecord, err = manager.UpdateAddressRecord(*record.ID, "web", "192.0.2.11", 0)
+			Expect(err).To(BeNil())
+			Expect(*record.Name).To(Equal("web.example.com"))
+			Expect(*ptrRecord.Name).To(Equal("11.2.0.192.in-addr.arpa"))
+			Expect(fake.SortedNames(reverseRecords)).To(Equal([]string{"11.2.0.192.in-addr.arpa"}))
+
+			deleted, err := manager.DeleteAddressRecord(*record.ID)
+			Expect(err).To(BeNil())
+			Expect(deleted).To(HaveLen(1))
+			Expect(fake.List(forwardRecords)).To(BeEmpty())
+			Expect(fake.List(reverseRecords)).To(BeEmpty())
+		})
+		It(`Remove the A record when its PTR record cannot be created`, func() {
+			fake.Failures["POST "+reverseRecords] = http.StatusBadRequest
+			record, ptrRecord, err := manager.CreateAddressRecord("www", "192.0.2.10", 300)
+			Expect(err).ToNot(BeNil())
+			Expect(record).To(BeNil())
+			Expect(ptrRecord).To(BeNil())
+			Expect(fake.List(forwardRecords)).To(BeEmpty())
+		})
+		It(`Restore the A record when its PTR record cannot be moved`, func() {
+			record, _, err := manager.CreateAddressRecord("www", "192.0.2.10", 300)
+			Expect(err).To(BeNil())
+
+			fake.Failures["DELETE "+reverseRecords+"/*"] = http.StatusBadRequest
+			updated, ptrRecord, err := manager.UpdateAddressRecord(*record.ID, "web", "192.0.2.11", 0)
+			Expect(err).ToNot(BeNil())
+			Expect(updated).To(BeNil())
+			Expect(ptrRecord).To(BeNil())
+			Expect(fake.List(forwardRecords)[0]["name"]).To(Equal("www.example.com"))
+			Expect(fake.List(forwardRecords)[0]["rdata"]).To(Equal(map[string]interface{}{"ip": "192.0.2.10"}))
+			Expect(fake.SortedNames(reverseRecords)).To(Equal([]string{"10.2.0.192.in-addr.arpa"}))
+
+			delete(fake.Failures, "DELETE "+reverseRecords+"/*")
+			fake.Failures["POST "+reverseRecords] = http.StatusBadRequest
+			_, _, err = manager.UpdateAddressRecord(*record.ID, "web", "192.0.2.11", 0)
+			Expect(err.Error()).To(ContainSubstring("restoring PTR record 10.2.0.192.in-addr.arpa also failed"))
+			Expect(fake.List(forwardRecords)[0]["name"]).To(Equal("www.example.com"))
+			Expect(fake.List(forwardRecords)[0]["rdata"]).To(Equal(map[string]interface{}{"ip": "192.0.2.10"}))
+		})
+		It(`Report orphaned PTR records`, func() {
+			fake.Add(forwardRecords, map[string]interface{}{"name": "www.example.com", "type": "A", "rdata": map[string]interface{}{"ip": "192.0.2.10"}})
+			fake.Add(reverseRecords, map[string]interface{}{"name": "10.2.0.192.in-addr.arpa", "type": "PTR", "rdata": map[string]interface{}{"ptrdname": "www.example.com"}})
+			fake.Add(reverseRecords, map[string]interface{}{"name": "12.2.0.192.in-addr.arpa", "type": "PTR", "rdata": map[string]interface{}{"ptrdname": "gone.example.com"}})
+			fake.Add(reverseRecords, map[string]interface{}{"name": "13.2.0.192.in-addr.arpa", "type": "PTR", "rdata": map[string]interface{}{"ptrdname": "mail.example.org"}})
+
+			orphans, err := manager.FindOrphanedPtrRecords()
+			Expect(err).To(BeNil())
+			Expect(orphans).To(HaveLen(1))
+			Expect(*orphans[0].Name).To(Equal("12.2.0.192.in-addr.arpa"))
+		})
+	})
+})
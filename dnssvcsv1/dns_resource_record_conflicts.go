@@ -0,0 +1,103 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// ResourceRecordConflictError : A conditional UpdateResourceRecord found the resource record changed since the caller
+// read it. The update was not sent.
+type ResourceRecordConflictError struct {
+	// The modified_on time the caller expected, when one was given.
+	ExpectedModifiedOn string
+
+	// The content hash the caller expected, when one was given.
+	ExpectedContentHash string
+
+	// The resource record as it is now.
+	Current *ResourceRecord
+
+	// The content hash of the resource record as it is now.
+	CurrentContentHash string
+
+	// The update that was not sent.
+	Update *UpdateResourceRecordOptions
+}
+
+// Error returns the description of the error.
+func (e *ResourceRecordConflictError) Error() string {
+	if e.ExpectedModifiedOn != "" && e.ExpectedModifiedOn != stringValue(e.Current.ModifiedOn, "") {
+		return fmt.Sprintf("resource record %s was modified at %s, after %s", stringValue(e.Current.ID, ""), stringValue(e.Current.ModifiedOn, ""), e.ExpectedModifiedOn)
+	}
+	return fmt.Sprintf("resource record %s has content hash %s, not %s", stringValue(e.Current.ID, ""), e.CurrentContentHash, e.ExpectedContentHash)
+}
+
+// ContentHash returns a hash of the content of the resource record: its type, name, TTL, rdata, service and
+// protocol. Identifiers and times are not part of it, so two records with the same content have the same hash.
+func (resourceRecord *ResourceRecord) ContentHash() string {
+	content := map[string]interface{}{
+		"type":     strings.ToUpper(stringValue(resourceRecord.Type, "")),
+		"name":     normalizeDomainName(stringValue(resourceRecord.Name, "")),
+		"ttl":      int64Value(resourceRecord.TTL, 0),
+		"rdata":    resourceRecord.Rdata,
+		"service":  stringValue(resourceRecord.Service, ""),
+		"protocol": stringValue(resourceRecord.Protocol, ""),
+	}
+	// Rdata is normalized through JSON first so that a model and the map it unmarshals to hash alike.
+	data, _ := json.Marshal(content["rdata"])
+	var rdata interface{}
+	_ = json.Unmarshal(data, &rdata)
+	content["rdata"] = rdata
+	data, _ = json.Marshal(content)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkResourceRecordVersion reads the resource record an update applies to from the service, bypassing the response
+// cache, and returns a *ResourceRecordConflictError when it no longer matches the expected modified_on time or content
+// hash.
+func (dnsSvcs *DnsSvcsV1) checkResourceRecordVersion(options *UpdateResourceRecordOptions) (response *core.DetailedResponse, err error) {
+	getOptions := dnsSvcs.NewGetResourceRecordOptions(*options.InstanceID, *options.DnszoneID, *options.RecordID)
+	getOptions.XCorrelationID = options.XCorrelationID
+	getOptions.Headers = withoutCache(options.Headers)
+	current, response, err := dnsSvcs.GetResourceRecord(getOptions)
+	if err != nil {
+		return
+	}
+	conflict := &ResourceRecordConflictError{
+		ExpectedModifiedOn:  stringValue(options.ExpectedModifiedOn, ""),
+		ExpectedContentHash: stringValue(options.ExpectedContentHash, ""),
+		Current:             current,
+		CurrentContentHash:  current.ContentHash(),
+		Update:              options,
+	}
+	if options.ExpectedModifiedOn != nil && *options.ExpectedModifiedOn != stringValue(current.ModifiedOn, "") {
+		err = conflict
+		return
+	}
+	if options.ExpectedContentHash != nil && !strings.EqualFold(*options.ExpectedContentHash, conflict.CurrentContentHash) {
+		err = conflict
+	}
+	return
+}
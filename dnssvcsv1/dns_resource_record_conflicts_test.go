@@ -0,0 +1,95 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Conditional UpdateResourceRecord`, func() {
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	var record *dnssvcsv1.ResourceRecord
+	const recordsPath = "/instances/instance/dnszones/zone/resource_records"
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		fake.Add(recordsPath, map[string]interface{}{"id": "record", "type": "A", "name": "www.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		var err error
+		record, _, err = testService.GetResourceRecord(testService.NewGetResourceRecordOptions("instance", "zone", "record"))
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	newUpdate := func(ip string) *dnssvcsv1.UpdateResourceRecordOptions {
+		return testService.NewUpdateResourceRecordOptions("instance", "zone", "record").SetRdata(&dnssvcsv1.ResourceRecordUpdateInputRdataRdataARecord{Ip: core.StringPtr(ip)})
+	}
+	It(`Invoke ContentHash successfully`, func() {
+		same := &dnssvcsv1.ResourceRecord{Type: core.StringPtr("a"), Name: core.StringPtr("WWW.example.com."), TTL: core.Int64Ptr(900), Rdata: &dnssvcsv1.ResourceRecordUpdateInputRdataRdataARecord{Ip: core.StringPtr("10.0.0.1")}}
+		Expect(same.ContentHash()).To(Equal(record.ContentHash()))
+		same.TTL = core.Int64Ptr(60)
+		Expect(same.ContentHash()).ToNot(Equal(record.ContentHash()))
+	})
+	It(`Invoke UpdateResourceRecord with the expected version`, func() {
+		result, _, err := testService.UpdateResourceRecord(newUpdate("10.0.0.2").SetExpectedModifiedOn(*record.ModifiedOn))
+		Expect(err).To(BeNil())
+		Expect(result.Rdata).To(Equal(map[string]interface{}{"ip": "10.0.0.2"}))
+
+		_, _, err = testService.UpdateResourceRecord(newUpdate("10.0.0.3").SetExpectedContentHash(result.ContentHash()))
+		Expect(err).To(BeNil())
+		Expect(fake.Requests).To(HaveLen(5))
+	})
+	It(`Invoke UpdateResourceRecord with a conflict`, func() {
+		_, _, err := testService.UpdateResourceRecord(newUpdate("10.0.0.2"))
+		Expect(err).To(BeNil())
+
+		_, _, err = testService.UpdateResourceRecord(newUpdate("10.0.0.3").SetExpectedModifiedOn(*record.ModifiedOn))
+		var conflict *dnssvcsv1.ResourceRecordConflictError
+		Expect(errors.As(err, &conflict)).To(BeTrue())
+		Expect(conflict.ExpectedModifiedOn).To(Equal(*record.ModifiedOn))
+		Expect(conflict.Current.Rdata).To(Equal(map[string]interface{}{"ip": "10.0.0.2"}))
+		Expect(conflict.Update.Rdata).To(Equal(&dnssvcsv1.ResourceRecordUpdateInputRdataRdataARecord{Ip: core.StringPtr("10.0.0.3")}))
+		Expect(err.Error()).To(ContainSubstring("resource record record was modified at"))
+
+		_, _, err = testService.UpdateResourceRecord(newUpdate("10.0.0.3").SetExpectedContentHash(record.ContentHash()))
+		Expect(errors.As(err, &conflict)).To(BeTrue())
+		Expect(conflict.CurrentContentHash).ToNot(Equal(record.ContentHash()))
+		Expect(fake.List(recordsPath)[0]["rdata"]).To(Equal(map[string]interface{}{"ip": "10.0.0.2"}))
+	})
+	It(`Invoke UpdateResourceRecord with a conflict and the response cache enabled`, func() {
+		Expect(testService.EnableResponseCache(testService.NewResponseCacheOptions(time.Minute))).To(Succeed())
+		cached, _, err := testService.GetResourceRecord(testService.NewGetResourceRecordOptions("instance", "zone", "record"))
+		Expect(err).To(BeNil())
+		_, _, err = fake.Service().UpdateResourceRecord(newUpdate("10.0.0.2"))
+		Expect(err).To(BeNil())
+
+		_, _, err = testService.UpdateResourceRecord(newUpdate("10.0.0.3").SetExpectedModifiedOn(*cached.ModifiedOn))
+		var conflict *dnssvcsv1.ResourceRecordConflictError
+		Expect(errors.As(err, &conflict)).To(BeTrue())
+		Expect(conflict.Current.Rdata).To(Equal(map[string]interface{}{"ip": "10.0.0.2"}))
+		Expect(fake.List(recordsPath)[0]["rdata"]).To(Equal(map[string]interface{}{"ip": "10.0.0.2"}))
+	})
+})
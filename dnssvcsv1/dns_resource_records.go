@@ -20,7 +20,10 @@ package dnssvcsv1
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
 
 	common "github.com/IBM/dns-svcs-go-sdk/common"
 	"github.com/IBM/go-sdk-core/v4/core"
@@ -66,6 +69,12 @@ func (dnsSvcs *DnsSvcsV1) ListResourceRecords(listResourceRecordsOptions *ListRe
 	if listResourceRecordsOptions.Limit != nil {
 		builder.AddQuery("limit", fmt.Sprint(*listResourceRecordsOptions.Limit))
 	}
+	if listResourceRecordsOptions.Type != nil {
+		builder.AddQuery("type", fmt.Sprint(*listResourceRecordsOptions.Type))
+	}
+	if listResourceRecordsOptions.Name != nil && listResourceRecordsOptions.nameMatch() == ListResourceRecordsOptions_NameMatch_Exact {
+		builder.AddQuery("name", normalizeDomainName(*listResourceRecordsOptions.Name))
+	}
 
 	request, err := builder.Build()
 	if err != nil {
@@ -86,6 +95,46 @@ func (dnsSvcs *DnsSvcsV1) ListResourceRecords(listResourceRecordsOptions *ListRe
 	return
 }
 
+// defaultPageLimit is the page size used when walking paginated list operations.
+const defaultPageLimit = int64(200)
+
+// ListAllResourceRecords : List all resource records
+// List the resource records of a given DNS zone matching the filters of the options, walking every page from the
+// given Offset. The Limit of the options sets the page size. Unlike ListResourceRecords, the Type, Name, NameMatch and
+// RdataValue filters are applied to every page, including those the service does not support.
+func (dnsSvcs *DnsSvcsV1) ListAllResourceRecords(listResourceRecordsOptions *ListResourceRecordsOptions) (records []ResourceRecord, err error) {
+	err = core.ValidateNotNil(listResourceRecordsOptions, "listResourceRecordsOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	pageOptions := *listResourceRecordsOptions
+	if pageOptions.Offset == nil {
+		pageOptions.Offset = core.Int64Ptr(0)
+	}
+	if pageOptions.Limit == nil {
+		pageOptions.Limit = core.Int64Ptr(defaultPageLimit)
+	}
+	for {
+		result, _, listErr := dnsSvcs.ListResourceRecords(&pageOptions)
+		if listErr != nil {
+			err = listErr
+			return
+		}
+		records = append(records, listResourceRecordsOptions.filter(result.ResourceRecords)...)
+
+		// Advance by the page size the service reports, as the filter above may leave fewer records than were
+		// returned.
+		pageSize := *pageOptions.Limit
+		if result.Limit != nil && *result.Limit > 0 {
+			pageSize = *result.Limit
+		}
+		pageOptions.Offset = core.Int64Ptr(*pageOptions.Offset + pageSize)
+		if result.TotalCount == nil || *pageOptions.Offset >= *result.TotalCount {
+			return
+		}
+	}
+}
+
 // CreateResourceRecord : Create a resource record
 // Create a resource record for a given DNS zone.
 func (dnsSvcs *DnsSvcsV1) CreateResourceRecord(createResourceRecordOptions *CreateResourceRecordOptions) (result *ResourceRecord, response *core.DetailedResponse, err error) {
@@ -271,6 +320,12 @@ func (dnsSvcs *DnsSvcsV1) UpdateResourceRecord(updateResourceRecordOptions *Upda
 	if err != nil {
 		return
 	}
+	if updateResourceRecordOptions.ExpectedModifiedOn != nil || updateResourceRecordOptions.ExpectedContentHash != nil {
+		response, err = dnsSvcs.checkResourceRecordVersion(updateResourceRecordOptions)
+		if err != nil {
+			return
+		}
+	}
 
 	pathSegments := []string{"instances", "dnszones", "resource_records"}
 	pathParameters := []string{*updateResourceRecordOptions.InstanceID, *updateResourceRecordOptions.DnszoneID, *updateResourceRecordOptions.RecordID}
@@ -373,6 +428,7 @@ type CreateResourceRecordOptions struct {
 const (
 	CreateResourceRecordOptions_Type_A     = "A"
 	CreateResourceRecordOptions_Type_Aaaa  = "AAAA"
+	CreateResourceRecordOptions_Type_Caa   = "CAA"
 	CreateResourceRecordOptions_Type_Cname = "CNAME"
 	CreateResourceRecordOptions_Type_Mx    = "MX"
 	CreateResourceRecordOptions_Type_Ptr   = "PTR"
@@ -579,10 +635,47 @@ type ListResourceRecordsOptions struct {
 	// Specify how many resource records are returned, the default value is 200.
 	Limit *int64 `json:"limit,omitempty"`
 
+	// Only return resource records of this type.
+	Type *string `json:"type,omitempty"`
+
+	// Only return resource records whose name matches this value, compared as set by NameMatch. ListResourceRecords
+	// only sends an exact Name to the service.
+	Name *string `json:"name,omitempty"`
+
+	// How Name is compared with the fully qualified resource record name, the default value is exact. Prefix and glob
+	// matches are applied by ListAllResourceRecords.
+	NameMatch *string `json:"name_match,omitempty"`
+
+	// Only return resource records with an rdata property equal to this value, for example the host name a CNAME
+	// points at. Applied by ListAllResourceRecords.
+	RdataValue *string `json:"rdata_value,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
 
+// Constants associated with the ListResourceRecordsOptions.Type property.
+// Only return resource records of this type.
+const (
+	ListResourceRecordsOptions_Type_A     = "A"
+	ListResourceRecordsOptions_Type_Aaaa  = "AAAA"
+	ListResourceRecordsOptions_Type_Caa   = "CAA"
+	ListResourceRecordsOptions_Type_Cname = "CNAME"
+	ListResourceRecordsOptions_Type_Mx    = "MX"
+	ListResourceRecordsOptions_Type_Ptr   = "PTR"
+	ListResourceRecordsOptions_Type_Srv   = "SRV"
+	ListResourceRecordsOptions_Type_Txt   = "TXT"
+)
+
+// Constants associated with the ListResourceRecordsOptions.NameMatch property.
+// How Name is compared with the fully qualified resource record name. Names are compared case-insensitively and
+// without their trailing dot; glob patterns use the syntax of path.Match.
+const (
+	ListResourceRecordsOptions_NameMatch_Exact  = "exact"
+	ListResourceRecordsOptions_NameMatch_Glob   = "glob"
+	ListResourceRecordsOptions_NameMatch_Prefix = "prefix"
+)
+
 // NewListResourceRecordsOptions : Instantiate ListResourceRecordsOptions
 func (*DnsSvcsV1) NewListResourceRecordsOptions(instanceID string, dnszoneID string) *ListResourceRecordsOptions {
 	return &ListResourceRecordsOptions{
@@ -621,12 +714,111 @@ func (options *ListResourceRecordsOptions) SetLimit(limit int64) *ListResourceRe
 	return options
 }
 
+// SetType : Allow user to set Type
+func (options *ListResourceRecordsOptions) SetType(typeVar string) *ListResourceRecordsOptions {
+	options.Type = core.StringPtr(typeVar)
+	return options
+}
+
+// SetName : Allow user to set Name
+func (options *ListResourceRecordsOptions) SetName(name string) *ListResourceRecordsOptions {
+	options.Name = core.StringPtr(name)
+	return options
+}
+
+// SetNameMatch : Allow user to set NameMatch
+func (options *ListResourceRecordsOptions) SetNameMatch(nameMatch string) *ListResourceRecordsOptions {
+	options.NameMatch = core.StringPtr(nameMatch)
+	return options
+}
+
+// SetRdataValue : Allow user to set RdataValue
+func (options *ListResourceRecordsOptions) SetRdataValue(rdataValue string) *ListResourceRecordsOptions {
+	options.RdataValue = core.StringPtr(rdataValue)
+	return options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *ListResourceRecordsOptions) SetHeaders(param map[string]string) *ListResourceRecordsOptions {
 	options.Headers = param
 	return options
 }
 
+func (options *ListResourceRecordsOptions) nameMatch() string {
+	if options.NameMatch == nil {
+		return ListResourceRecordsOptions_NameMatch_Exact
+	}
+	return *options.NameMatch
+}
+
+// filter drops the resource records not matching the Type, Name and RdataValue filters. The service may not apply
+// some or all of these filters itself.
+func (options *ListResourceRecordsOptions) filter(records []ResourceRecord) []ResourceRecord {
+	if options.Type == nil && options.Name == nil && options.RdataValue == nil {
+		return records
+	}
+	filtered := []ResourceRecord{}
+	for _, record := range records {
+		if options.Type != nil && (record.Type == nil || !strings.EqualFold(*record.Type, *options.Type)) {
+			continue
+		}
+		if options.Name != nil && (record.Name == nil || !options.matchesName(*record.Name)) {
+			continue
+		}
+		if options.RdataValue != nil && !rdataContains(record.Rdata, *options.RdataValue) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered
+}
+
+func (options *ListResourceRecordsOptions) matchesName(name string) bool {
+	name = normalizeDomainName(name)
+	pattern := normalizeDomainName(*options.Name)
+	switch options.nameMatch() {
+	case ListResourceRecordsOptions_NameMatch_Prefix:
+		return strings.HasPrefix(name, pattern)
+	case ListResourceRecordsOptions_NameMatch_Glob:
+		matched, _ := path.Match(pattern, name)
+		return matched
+	default:
+		return name == pattern
+	}
+}
+
+// rdataContains reports whether one of the rdata properties equals value. Domain names are compared case-insensitively
+// and without a trailing dot, other strings exactly and numbers by their decimal representation.
+func rdataContains(rdata interface{}, value string) bool {
+	properties, ok := rdata.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for key, property := range properties {
+		var text string
+		switch property := property.(type) {
+		case string:
+			if isRdataDomainName(key) {
+				if normalizeDomainName(property) == normalizeDomainName(value) {
+					return true
+				}
+				continue
+			}
+			text = property
+		case float64:
+			text = strconv.FormatFloat(property, 'f', -1, 64)
+		case int64:
+			text = strconv.FormatInt(property, 10)
+		default:
+			continue
+		}
+		if text == value {
+			return true
+		}
+	}
+	return false
+}
+
 // ResourceRecordInputRdata : Content of the resource record.
 // Models which "extend" this model:
 // - ResourceRecordInputRdataRdataARecord
@@ -636,6 +828,7 @@ func (options *ListResourceRecordsOptions) SetHeaders(param map[string]string) *
 // - ResourceRecordInputRdataRdataSrvRecord
 // - ResourceRecordInputRdataRdataTxtRecord
 // - ResourceRecordInputRdataRdataPtrRecord
+// - ResourceRecordInputRdataRdataCaaRecord
 type ResourceRecordInputRdata struct {
 	// IPv4 address.
 	Ip *string `json:"ip,omitempty"`
@@ -666,6 +859,15 @@ type ResourceRecordInputRdata struct {
 
 	// Hostname of the relevant A or AAAA record.
 	Ptrdname *string `json:"ptrdname,omitempty"`
+
+	// Flags of the CAA record, 0 or 128 (issuer critical).
+	Flags *int64 `json:"flags,omitempty"`
+
+	// Property tag of the CAA record.
+	Tag *string `json:"tag,omitempty"`
+
+	// Value of the CAA record property.
+	Value *string `json:"value,omitempty"`
 }
 
 func (*ResourceRecordInputRdata) isaResourceRecordInputRdata() bool {
@@ -719,6 +921,18 @@ func UnmarshalResourceRecordInputRdata(m map[string]json.RawMessage, result inte
 	if err != nil {
 		return
 	}
+	err = core.UnmarshalPrimitive(m, "flags", &obj.Flags)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "tag", &obj.Tag)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		return
+	}
 	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
 	return
 }
@@ -732,6 +946,7 @@ func UnmarshalResourceRecordInputRdata(m map[string]json.RawMessage, result inte
 // - ResourceRecordUpdateInputRdataRdataSrvRecord
 // - ResourceRecordUpdateInputRdataRdataTxtRecord
 // - ResourceRecordUpdateInputRdataRdataPtrRecord
+// - ResourceRecordUpdateInputRdataRdataCaaRecord
 type ResourceRecordUpdateInputRdata struct {
 	// IPv4 address.
 	Ip *string `json:"ip,omitempty"`
@@ -762,6 +977,15 @@ type ResourceRecordUpdateInputRdata struct {
 
 	// Hostname of the relevant A or AAAA record.
 	Ptrdname *string `json:"ptrdname,omitempty"`
+
+	// Flags of the CAA record, 0 or 128 (issuer critical).
+	Flags *int64 `json:"flags,omitempty"`
+
+	// Property tag of the CAA record.
+	Tag *string `json:"tag,omitempty"`
+
+	// Value of the CAA record property.
+	Value *string `json:"value,omitempty"`
 }
 
 func (*ResourceRecordUpdateInputRdata) isaResourceRecordUpdateInputRdata() bool {
@@ -815,6 +1039,18 @@ func UnmarshalResourceRecordUpdateInputRdata(m map[string]json.RawMessage, resul
 	if err != nil {
 		return
 	}
+	err = core.UnmarshalPrimitive(m, "flags", &obj.Flags)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "tag", &obj.Tag)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		return
+	}
 	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
 	return
 }
@@ -845,6 +1081,14 @@ type UpdateResourceRecordOptions struct {
 	// Only used for SRV record.
 	Protocol *string `json:"protocol,omitempty"`
 
+	// Only update the resource record when its modified_on time is still this value. The resource record is read
+	// before the update; a *ResourceRecordConflictError is returned when it differs.
+	ExpectedModifiedOn *string `json:"-"`
+
+	// Only update the resource record when its content hash, as returned by ResourceRecord.ContentHash, is still this
+	// value. The resource record is read before the update; a *ResourceRecordConflictError is returned when it differs.
+	ExpectedContentHash *string `json:"-"`
+
 	// Uniquely identifying a request.
 	XCorrelationID *string `json:"X-Correlation-ID,omitempty"`
 
@@ -909,6 +1153,18 @@ func (options *UpdateResourceRecordOptions) SetProtocol(protocol string) *Update
 	return options
 }
 
+// SetExpectedModifiedOn : Allow user to set ExpectedModifiedOn
+func (options *UpdateResourceRecordOptions) SetExpectedModifiedOn(expectedModifiedOn string) *UpdateResourceRecordOptions {
+	options.ExpectedModifiedOn = core.StringPtr(expectedModifiedOn)
+	return options
+}
+
+// SetExpectedContentHash : Allow user to set ExpectedContentHash
+func (options *UpdateResourceRecordOptions) SetExpectedContentHash(expectedContentHash string) *UpdateResourceRecordOptions {
+	options.ExpectedContentHash = core.StringPtr(expectedContentHash)
+	return options
+}
+
 // SetXCorrelationID : Allow user to set XCorrelationID
 func (options *UpdateResourceRecordOptions) SetXCorrelationID(xCorrelationID string) *UpdateResourceRecordOptions {
 	options.XCorrelationID = core.StringPtr(xCorrelationID)
@@ -1008,9 +1264,13 @@ type ResourceRecord struct {
 const (
 	ResourceRecord_Type_A     = "A"
 	ResourceRecord_Type_Aaaa  = "AAAA"
+	ResourceRecord_Type_Caa   = "CAA"
 	ResourceRecord_Type_Cname = "CNAME"
+	ResourceRecord_Type_Ds    = "DS"
 	ResourceRecord_Type_Mx    = "MX"
+	ResourceRecord_Type_Ns    = "NS"
 	ResourceRecord_Type_Ptr   = "PTR"
+	ResourceRecord_Type_Soa   = "SOA"
 	ResourceRecord_Type_Srv   = "SRV"
 	ResourceRecord_Type_Txt   = "TXT"
 )
@@ -1058,6 +1318,26 @@ func UnmarshalResourceRecord(m map[string]json.RawMessage, result interface{}) (
 	return
 }
 
+// GetRdataModel unmarshals the Rdata of the resource record into the model built by the specified unmarshaller, for
+// example UnmarshalResourceRecordRdataSoaRecord for a record of type SOA.
+func (resourceRecord *ResourceRecord) GetRdataModel(unmarshaller core.ModelUnmarshaller, result interface{}) (err error) {
+	if resourceRecord.Rdata == nil {
+		err = fmt.Errorf("resource record has no rdata")
+		return
+	}
+	rdataBytes, err := json.Marshal(resourceRecord.Rdata)
+	if err != nil {
+		return
+	}
+	var rawRdata map[string]json.RawMessage
+	err = json.Unmarshal(rdataBytes, &rawRdata)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalModel(rawRdata, "", result, unmarshaller)
+	return
+}
+
 // ResourceRecordInputRdataRdataARecord : The content of type-A resource record.
 // This model "extends" ResourceRecordInputRdata
 type ResourceRecordInputRdataRdataARecord struct {
@@ -1120,6 +1400,61 @@ func UnmarshalResourceRecordInputRdataRdataAaaaRecord(m map[string]json.RawMessa
 	return
 }
 
+// ResourceRecordInputRdataRdataCaaRecord : The content of type-CAA resource record.
+// This model "extends" ResourceRecordInputRdata
+type ResourceRecordInputRdataRdataCaaRecord struct {
+	// Flags of the CAA record, 0 or 128 (issuer critical).
+	Flags *int64 `json:"flags" validate:"required"`
+
+	// Property tag of the CAA record.
+	Tag *string `json:"tag" validate:"required"`
+
+	// Value of the CAA record property.
+	Value *string `json:"value" validate:"required"`
+}
+
+// Constants associated with the ResourceRecordInputRdataRdataCaaRecord.Tag property.
+// Property tag of the CAA record.
+const (
+	ResourceRecordInputRdataRdataCaaRecord_Tag_Iodef     = "iodef"
+	ResourceRecordInputRdataRdataCaaRecord_Tag_Issue     = "issue"
+	ResourceRecordInputRdataRdataCaaRecord_Tag_Issuewild = "issuewild"
+)
+
+// NewResourceRecordInputRdataRdataCaaRecord : Instantiate ResourceRecordInputRdataRdataCaaRecord (Generic Model Constructor)
+func (*DnsSvcsV1) NewResourceRecordInputRdataRdataCaaRecord(flags int64, tag string, value string) (model *ResourceRecordInputRdataRdataCaaRecord, err error) {
+	model = &ResourceRecordInputRdataRdataCaaRecord{
+		Flags: core.Int64Ptr(flags),
+		Tag:   core.StringPtr(tag),
+		Value: core.StringPtr(value),
+	}
+	err = core.ValidateStruct(model, "required parameters")
+	return
+}
+
+func (*ResourceRecordInputRdataRdataCaaRecord) isaResourceRecordInputRdata() bool {
+	return true
+}
+
+// UnmarshalResourceRecordInputRdataRdataCaaRecord unmarshals an instance of ResourceRecordInputRdataRdataCaaRecord from the specified map of raw messages.
+func UnmarshalResourceRecordInputRdataRdataCaaRecord(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ResourceRecordInputRdataRdataCaaRecord)
+	err = core.UnmarshalPrimitive(m, "flags", &obj.Flags)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "tag", &obj.Tag)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
 // ResourceRecordInputRdataRdataCnameRecord : The content of type-CNAME resource record.
 // This model "extends" ResourceRecordInputRdata
 type ResourceRecordInputRdataRdataCnameRecord struct {
@@ -1307,6 +1642,120 @@ func UnmarshalResourceRecordInputRdataRdataTxtRecord(m map[string]json.RawMessag
 	return
 }
 
+// ResourceRecordRdataDsRecord : The content of type-DS resource record. Records of this type are read-only.
+type ResourceRecordRdataDsRecord struct {
+	// Key tag of the DNSKEY record the DS record refers to.
+	KeyTag *int64 `json:"key_tag,omitempty"`
+
+	// Algorithm number of the referenced DNSKEY record.
+	Algorithm *int64 `json:"algorithm,omitempty"`
+
+	// Algorithm used to construct the digest.
+	DigestType *int64 `json:"digest_type,omitempty"`
+
+	// Digest of the referenced DNSKEY record, in hexadecimal.
+	Digest *string `json:"digest,omitempty"`
+}
+
+// UnmarshalResourceRecordRdataDsRecord unmarshals an instance of ResourceRecordRdataDsRecord from the specified map of raw messages.
+func UnmarshalResourceRecordRdataDsRecord(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ResourceRecordRdataDsRecord)
+	err = core.UnmarshalPrimitive(m, "key_tag", &obj.KeyTag)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "algorithm", &obj.Algorithm)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "digest_type", &obj.DigestType)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "digest", &obj.Digest)
+	if err != nil {
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ResourceRecordRdataNsRecord : The content of type-NS resource record. Records of this type are read-only.
+type ResourceRecordRdataNsRecord struct {
+	// Hostname of the authoritative name server.
+	Nsdname *string `json:"nsdname,omitempty"`
+}
+
+// UnmarshalResourceRecordRdataNsRecord unmarshals an instance of ResourceRecordRdataNsRecord from the specified map of raw messages.
+func UnmarshalResourceRecordRdataNsRecord(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ResourceRecordRdataNsRecord)
+	err = core.UnmarshalPrimitive(m, "nsdname", &obj.Nsdname)
+	if err != nil {
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ResourceRecordRdataSoaRecord : The content of type-SOA resource record. Records of this type are read-only.
+type ResourceRecordRdataSoaRecord struct {
+	// Hostname of the primary name server of the zone.
+	Mname *string `json:"mname,omitempty"`
+
+	// Mailbox of the person responsible for the zone, with the "@" replaced by a dot.
+	Rname *string `json:"rname,omitempty"`
+
+	// Serial number of the zone.
+	Serial *int64 `json:"serial,omitempty"`
+
+	// Seconds after which secondary name servers should query the primary for the SOA record.
+	Refresh *int64 `json:"refresh,omitempty"`
+
+	// Seconds after which secondary name servers should retry a failed refresh.
+	Retry *int64 `json:"retry,omitempty"`
+
+	// Seconds after which secondary name servers stop answering for the zone if the primary does not respond.
+	Expire *int64 `json:"expire,omitempty"`
+
+	// Time to live in second of negative responses from the zone.
+	Minimum *int64 `json:"minimum,omitempty"`
+}
+
+// UnmarshalResourceRecordRdataSoaRecord unmarshals an instance of ResourceRecordRdataSoaRecord from the specified map of raw messages.
+func UnmarshalResourceRecordRdataSoaRecord(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ResourceRecordRdataSoaRecord)
+	err = core.UnmarshalPrimitive(m, "mname", &obj.Mname)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "rname", &obj.Rname)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "serial", &obj.Serial)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "refresh", &obj.Refresh)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "retry", &obj.Retry)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "expire", &obj.Expire)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "minimum", &obj.Minimum)
+	if err != nil {
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
 // ResourceRecordUpdateInputRdataRdataARecord : The content of type-A resource record.
 // This model "extends" ResourceRecordUpdateInputRdata
 type ResourceRecordUpdateInputRdataRdataARecord struct {
@@ -1369,6 +1818,61 @@ func UnmarshalResourceRecordUpdateInputRdataRdataAaaaRecord(m map[string]json.Ra
 	return
 }
 
+// ResourceRecordUpdateInputRdataRdataCaaRecord : The content of type-CAA resource record.
+// This model "extends" ResourceRecordUpdateInputRdata
+type ResourceRecordUpdateInputRdataRdataCaaRecord struct {
+	// Flags of the CAA record, 0 or 128 (issuer critical).
+	Flags *int64 `json:"flags" validate:"required"`
+
+	// Property tag of the CAA record.
+	Tag *string `json:"tag" validate:"required"`
+
+	// Value of the CAA record property.
+	Value *string `json:"value" validate:"required"`
+}
+
+// Constants associated with the ResourceRecordUpdateInputRdataRdataCaaRecord.Tag property.
+// Property tag of the CAA record.
+const (
+	ResourceRecordUpdateInputRdataRdataCaaRecord_Tag_Iodef     = "iodef"
+	ResourceRecordUpdateInputRdataRdataCaaRecord_Tag_Issue     = "issue"
+	ResourceRecordUpdateInputRdataRdataCaaRecord_Tag_Issuewild = "issuewild"
+)
+
+// NewResourceRecordUpdateInputRdataRdataCaaRecord : Instantiate ResourceRecordUpdateInputRdataRdataCaaRecord (Generic Model Constructor)
+func (*DnsSvcsV1) NewResourceRecordUpdateInputRdataRdataCaaRecord(flags int64, tag string, value string) (model *ResourceRecordUpdateInputRdataRdataCaaRecord, err error) {
+	model = &ResourceRecordUpdateInputRdataRdataCaaRecord{
+		Flags: core.Int64Ptr(flags),
+		Tag:   core.StringPtr(tag),
+		Value: core.StringPtr(value),
+	}
+	err = core.ValidateStruct(model, "required parameters")
+	return
+}
+
+func (*ResourceRecordUpdateInputRdataRdataCaaRecord) isaResourceRecordUpdateInputRdata() bool {
+	return true
+}
+
+// UnmarshalResourceRecordUpdateInputRdataRdataCaaRecord unmarshals an instance of ResourceRecordUpdateInputRdataRdataCaaRecord from the specified map of raw messages.
+func UnmarshalResourceRecordUpdateInputRdataRdataCaaRecord(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ResourceRecordUpdateInputRdataRdataCaaRecord)
+	err = core.UnmarshalPrimitive(m, "flags", &obj.Flags)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "tag", &obj.Tag)
+	if err != nil {
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
 // ResourceRecordUpdateInputRdataRdataCnameRecord : The content of type-CNAME resource record.
 // This model "extends" ResourceRecordUpdateInputRdata
 type ResourceRecordUpdateInputRdataRdataCnameRecord struct {
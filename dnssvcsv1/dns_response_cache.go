@@ -0,0 +1,369 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResponseCacheCapacity is the number of responses the default in-memory store keeps.
+const DefaultResponseCacheCapacity = 1000
+
+// Constants for the kinds of resources cached, the collection names of their URL paths.
+const (
+	ResponseCacheKind_Dnszones          = "dnszones"
+	ResponseCacheKind_LoadBalancers     = "load_balancers"
+	ResponseCacheKind_Monitors          = "monitors"
+	ResponseCacheKind_PermittedNetworks = "permitted_networks"
+	ResponseCacheKind_Pools             = "pools"
+	ResponseCacheKind_ResourceRecords   = "resource_records"
+)
+
+// ResponseCacheOptions : The EnableResponseCache options.
+type ResponseCacheOptions struct {
+	// How long responses are cached, by kind of resource. Kinds not listed use DefaultTTL; a zero TTL disables caching
+	// of the kind.
+	TTL map[string]time.Duration
+
+	// How long responses of kinds not listed in TTL are cached.
+	DefaultTTL time.Duration
+
+	// Where responses are kept, an in-memory LRU store of DefaultResponseCacheCapacity responses when nil.
+	Store ResponseCacheStore
+}
+
+// NewResponseCacheOptions : Instantiate ResponseCacheOptions
+func (*DnsSvcsV1) NewResponseCacheOptions(defaultTTL time.Duration) *ResponseCacheOptions {
+	return &ResponseCacheOptions{
+		TTL:        make(map[string]time.Duration),
+		DefaultTTL: defaultTTL,
+	}
+}
+
+// SetTTL : Allow user to set the TTL of a kind of resource
+func (options *ResponseCacheOptions) SetTTL(kind string, ttl time.Duration) *ResponseCacheOptions {
+	if options.TTL == nil {
+		options.TTL = make(map[string]time.Duration)
+	}
+	options.TTL[kind] = ttl
+	return options
+}
+
+// SetStore : Allow user to set Store
+func (options *ResponseCacheOptions) SetStore(store ResponseCacheStore) *ResponseCacheOptions {
+	options.Store = store
+	return options
+}
+
+// CachedResponse : A response kept by a ResponseCacheStore.
+type CachedResponse struct {
+	// The status code of the response.
+	StatusCode int
+
+	// The headers of the response.
+	Header http.Header
+
+	// The body of the response.
+	Body []byte
+
+	// When the response stops being served.
+	Expires time.Time
+}
+
+// ResponseCacheStore : Storage of cached responses, keyed by request URL. Implementations must be safe for
+// concurrent use.
+type ResponseCacheStore interface {
+	// Get returns the response stored under a key.
+	Get(key string) (response *CachedResponse, found bool)
+
+	// Set stores a response under a key.
+	Set(key string, response *CachedResponse)
+
+	// Delete removes the response stored under a key.
+	Delete(key string)
+
+	// Keys returns the keys of the stored responses.
+	Keys() []string
+}
+
+// LRUResponseCacheStore : An in-memory ResponseCacheStore evicting the least recently used response when full.
+type LRUResponseCacheStore struct {
+	mutex    sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewLRUResponseCacheStore : Instantiate LRUResponseCacheStore
+func NewLRUResponseCacheStore(capacity int) *LRUResponseCacheStore {
+	return &LRUResponseCacheStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the response stored under a key.
+func (store *LRUResponseCacheStore) Get(key string) (*CachedResponse, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	element, found := store.entries[key]
+	if !found {
+		return nil, false
+	}
+	store.order.MoveToFront(element)
+	return element.Value.(*lruEntry).response, true
+}
+
+// Set stores a response under a key.
+func (store *LRUResponseCacheStore) Set(key string, response *CachedResponse) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if element, found := store.entries[key]; found {
+		element.Value.(*lruEntry).response = response
+		store.order.MoveToFront(element)
+		return
+	}
+	store.entries[key] = store.order.PushFront(&lruEntry{key: key, response: response})
+	for store.capacity > 0 && store.order.Len() > store.capacity {
+		oldest := store.order.Back()
+		store.order.Remove(oldest)
+		delete(store.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the response stored under a key.
+func (store *LRUResponseCacheStore) Delete(key string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if element, found := store.entries[key]; found {
+		store.order.Remove(element)
+		delete(store.entries, key)
+	}
+}
+
+// Keys returns the keys of the stored responses, most recently used first.
+func (store *LRUResponseCacheStore) Keys() []string {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	keys := make([]string, 0, store.order.Len())
+	for element := store.order.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*lruEntry).key)
+	}
+	return keys
+}
+
+// cachingTransport serves GET requests from a ResponseCacheStore and invalidates the responses a write affects.
+type cachingTransport struct {
+	next    http.RoundTripper
+	options ResponseCacheOptions
+	now     func() time.Time
+
+	// The transport of the client before the cache was enabled, nil for the default transport.
+	original http.RoundTripper
+
+	// generation counts the invalidations, so that a response read before one is not cached after it. The mutex
+	// orders invalidations with the caching of responses.
+	mutex      sync.Mutex
+	generation uint64
+}
+
+// EnableResponseCache : Cache the responses of read operations
+// Serve GetX and ListX operations from a read-through cache. Create, update and delete operations sent through this
+// client invalidate the cached responses of the object, of the objects under it and of its collection. Changes made
+// by other clients are seen once the TTL expires, or at once by reads with a "Cache-Control: no-cache" header, set
+// through the Headers of their options; the workflows of this package read that way when they check for concurrent
+// changes or wait for a change to take effect. Call DisableSSLVerification and SetHTTPClient before enabling the
+// cache, as they replace it.
+func (dnsSvcs *DnsSvcsV1) EnableResponseCache(options *ResponseCacheOptions) error {
+	if options == nil {
+		return fmt.Errorf("options cannot be nil")
+	}
+	dnsSvcs.DisableResponseCache()
+	if dnsSvcs.Service.Client == nil {
+		dnsSvcs.Service.Client = &http.Client{}
+	}
+	transport := &cachingTransport{next: dnsSvcs.Service.Client.Transport, options: *options, now: time.Now, original: dnsSvcs.Service.Client.Transport}
+	if transport.next == nil {
+		transport.next = http.DefaultTransport
+	}
+	if transport.options.Store == nil {
+		transport.options.Store = NewLRUResponseCacheStore(DefaultResponseCacheCapacity)
+	}
+	client := *dnsSvcs.Service.Client
+	client.Transport = transport
+	dnsSvcs.Service.Client = &client
+	return nil
+}
+
+// DisableResponseCache stops caching responses and drops the cached ones.
+func (dnsSvcs *DnsSvcsV1) DisableResponseCache() {
+	transport := dnsSvcs.cachingTransport()
+	if transport == nil {
+		return
+	}
+	transport.invalidate("")
+	client := *dnsSvcs.Service.Client
+	client.Transport = transport.original
+	dnsSvcs.Service.Client = &client
+}
+
+// InvalidateCache drops every cached response.
+func (dnsSvcs *DnsSvcsV1) InvalidateCache() {
+	if transport := dnsSvcs.cachingTransport(); transport != nil {
+		transport.invalidate("")
+	}
+}
+
+// InvalidateCachedPath drops the cached responses of an object or collection, of the objects under it and of its
+// collection, as a write to it would. The path is relative to the service URL, for example
+// "/instances/{instance_id}/dnszones/{dnszone_id}".
+func (dnsSvcs *DnsSvcsV1) InvalidateCachedPath(path string) {
+	if transport := dnsSvcs.cachingTransport(); transport != nil {
+		transport.invalidate(strings.TrimSuffix(dnsSvcs.Service.Options.URL, "/") + "/" + strings.Trim(path, "/"))
+	}
+}
+
+func (dnsSvcs *DnsSvcsV1) cachingTransport() *cachingTransport {
+	if dnsSvcs.Service.Client == nil {
+		return nil
+	}
+	transport, _ := dnsSvcs.Service.Client.Transport.(*cachingTransport)
+	return transport
+}
+
+// RoundTrip serves a GET request from the store when a fresh response is cached and the request has no
+// "Cache-Control: no-cache" header, and otherwise sends it and caches a successful response unless an invalidation
+// happened while it was read. Other requests are sent and invalidate the responses they affect.
+func (transport *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.URL.String()
+	if req.Method != http.MethodGet {
+		response, err := transport.next.RoundTrip(req)
+		transport.invalidate(strings.SplitN(key, "?", 2)[0])
+		return response, err
+	}
+	ttl := transport.ttl(req.URL.Path)
+	if ttl <= 0 {
+		return transport.next.RoundTrip(req)
+	}
+	if cached, found := transport.options.Store.Get(key); found && !noCache(req.Header) {
+		if transport.now().Before(cached.Expires) {
+			return cached.response(req), nil
+		}
+		transport.options.Store.Delete(key)
+	}
+	transport.mutex.Lock()
+	generation := transport.generation
+	transport.mutex.Unlock()
+	response, err := transport.next.RoundTrip(req)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	cached := &CachedResponse{StatusCode: response.StatusCode, Header: response.Header, Body: body, Expires: transport.now().Add(ttl)}
+	transport.mutex.Lock()
+	if transport.generation == generation {
+		transport.options.Store.Set(key, cached)
+	}
+	transport.mutex.Unlock()
+	return cached.response(req), nil
+}
+
+// ttl returns the TTL of the kind of resource of a URL path: the last collection name after "instances".
+func (transport *cachingTransport) ttl(path string) time.Duration {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	kind := ""
+	for i, segment := range segments {
+		if segment == "instances" {
+			for j := i; j < len(segments); j += 2 {
+				kind = segments[j]
+			}
+			break
+		}
+	}
+	if ttl, found := transport.options.TTL[kind]; found {
+		return ttl
+	}
+	return transport.options.DefaultTTL
+}
+
+// invalidate drops the cached responses of a URL without query, of the URLs under it and of its collection. An empty
+// URL drops every response. Responses being read meanwhile are not cached.
+func (transport *cachingTransport) invalidate(url string) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.generation++
+	collection := url[:strings.LastIndex(url, "/")+1]
+	for _, key := range transport.options.Store.Keys() {
+		keyURL := strings.SplitN(key, "?", 2)[0]
+		if url == "" || keyURL == url || strings.HasPrefix(keyURL, url+"/") || keyURL+"/" == collection {
+			transport.options.Store.Delete(key)
+		}
+	}
+}
+
+// noCache tells whether request headers ask for a response from the service rather than from the cache.
+func noCache(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutCache returns request headers with "Cache-Control: no-cache" added, for the reads of a workflow that must
+// see the current state of an object even when the response cache is enabled.
+func withoutCache(headers map[string]string) map[string]string {
+	bypass := map[string]string{"Cache-Control": "no-cache"}
+	for name, value := range headers {
+		if !strings.EqualFold(name, "Cache-Control") {
+			bypass[name] = value
+		}
+	}
+	return bypass
+}
+
+func (cached *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
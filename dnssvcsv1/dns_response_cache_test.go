@@ -0,0 +1,175 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Response cache`, func() {
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	var store *dnssvcsv1.LRUResponseCacheStore
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+		store = dnssvcsv1.NewLRUResponseCacheStore(10)
+		fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+		fake.Add("/instances/instance/dnszones/zone/resource_records", map[string]interface{}{"id": "record", "type": "A", "name": "www.example.com", "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		fake.Add("/instances/instance/pools", map[string]interface{}{"id": "pool", "name": "web"})
+		options := testService.NewResponseCacheOptions(time.Minute).SetStore(store).SetTTL(dnssvcsv1.ResponseCacheKind_Monitors, 0)
+		Expect(testService.EnableResponseCache(options)).To(Succeed())
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	countRequests := func(request string) (count int) {
+		for _, received := range fake.Requests {
+			if received == request {
+				count++
+			}
+		}
+		return
+	}
+	It(`Serve read operations from the cache`, func() {
+		for i := 0; i < 3; i++ {
+			pool, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+			Expect(err).To(BeNil())
+			Expect(*pool.Name).To(Equal("web"))
+			_, _, err = testService.ListResourceRecords(testService.NewListResourceRecordsOptions("instance", "zone"))
+			Expect(err).To(BeNil())
+			_, _, err = testService.ListMonitors(testService.NewListMonitorsOptions("instance"))
+			Expect(err).To(BeNil())
+		}
+		Expect(countRequests("GET /instances/instance/pools/pool")).To(Equal(1))
+		Expect(countRequests("GET /instances/instance/dnszones/zone/resource_records")).To(Equal(1))
+		Expect(countRequests("GET /instances/instance/monitors")).To(Equal(3))
+		Expect(store.Keys()).To(HaveLen(2))
+	})
+	It(`Read from the service with Cache-Control no-cache`, func() {
+		_, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		fake.List("/instances/instance/pools")[0]["description"] = "changed elsewhere"
+
+		pool, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		Expect(pool.Description).To(BeNil())
+		pool, _, err = testService.GetPool(testService.NewGetPoolOptions("instance", "pool").SetHeaders(map[string]string{"Cache-Control": "no-cache"}))
+		Expect(err).To(BeNil())
+		Expect(*pool.Description).To(Equal("changed elsewhere"))
+		Expect(countRequests("GET /instances/instance/pools/pool")).To(Equal(2))
+
+		pool, _, err = testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		Expect(*pool.Description).To(Equal("changed elsewhere"))
+		Expect(countRequests("GET /instances/instance/pools/pool")).To(Equal(2))
+	})
+	It(`Invalidate cached responses on writes`, func() {
+		_, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		_, _, err = testService.ListPools(testService.NewListPoolsOptions("instance"))
+		Expect(err).To(BeNil())
+		_, _, err = testService.GetDnszone(testService.NewGetDnszoneOptions("instance", "zone"))
+		Expect(err).To(BeNil())
+		_, _, err = testService.ListResourceRecords(testService.NewListResourceRecordsOptions("instance", "zone"))
+		Expect(err).To(BeNil())
+		Expect(store.Keys()).To(HaveLen(4))
+
+		_, _, err = testService.UpdatePool(testService.NewUpdatePoolOptions("instance", "pool").SetDescription("changed"))
+		Expect(err).To(BeNil())
+		Expect(store.Keys()).To(HaveLen(2))
+		pool, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		Expect(*pool.Description).To(Equal("changed"))
+
+		_, err = testService.DeleteResourceRecord(testService.NewDeleteResourceRecordOptions("instance", "zone", "record"))
+		Expect(err).To(BeNil())
+		result, _, err := testService.ListResourceRecords(testService.NewListResourceRecordsOptions("instance", "zone"))
+		Expect(err).To(BeNil())
+		Expect(result.ResourceRecords).To(BeEmpty())
+		_, _, err = testService.GetDnszone(testService.NewGetDnszoneOptions("instance", "zone"))
+		Expect(err).To(BeNil())
+		Expect(countRequests("GET /instances/instance/dnszones/zone")).To(Equal(1))
+
+		testService.InvalidateCachedPath("/instances/instance/dnszones/zone")
+		_, _, err = testService.GetDnszone(testService.NewGetDnszoneOptions("instance", "zone"))
+		Expect(err).To(BeNil())
+		Expect(countRequests("GET /instances/instance/dnszones/zone")).To(Equal(2))
+
+		testService.InvalidateCache()
+		Expect(store.Keys()).To(BeEmpty())
+	})
+	It(`Do not cache a response read while a write invalidates it`, func() {
+		testService.DisableResponseCache()
+		updated := false
+		testService.Service.Client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			response, err := http.DefaultTransport.RoundTrip(req)
+			if req.Method == http.MethodGet && !updated {
+				updated = true
+				_, _, updateErr := testService.UpdatePool(testService.NewUpdatePoolOptions("instance", "pool").SetDescription("changed"))
+				Expect(updateErr).To(BeNil())
+			}
+			return response, err
+		})
+		Expect(testService.EnableResponseCache(testService.NewResponseCacheOptions(time.Minute).SetStore(store))).To(Succeed())
+
+		pool, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		Expect(pool.Description).To(BeNil())
+		Expect(store.Keys()).To(BeEmpty())
+		pool, _, err = testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		Expect(*pool.Description).To(Equal("changed"))
+		Expect(countRequests("GET /instances/instance/pools/pool")).To(Equal(2))
+	})
+	It(`Expire and disable the cache`, func() {
+		Expect(testService.EnableResponseCache(testService.NewResponseCacheOptions(time.Millisecond))).To(Succeed())
+		_, _, err := testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		time.Sleep(5 * time.Millisecond)
+		_, _, err = testService.GetPool(testService.NewGetPoolOptions("instance", "pool"))
+		Expect(err).To(BeNil())
+		Expect(countRequests("GET /instances/instance/pools/pool")).To(Equal(2))
+
+		testService.DisableResponseCache()
+		Expect(testService.Service.Client.Transport).To(BeNil())
+		Expect(testService.EnableResponseCache(nil)).ToNot(Succeed())
+	})
+	It(`Evict the least recently used responses`, func() {
+		store := dnssvcsv1.NewLRUResponseCacheStore(2)
+		store.Set("a", &dnssvcsv1.CachedResponse{})
+		store.Set("b", &dnssvcsv1.CachedResponse{})
+		_, found := store.Get("a")
+		Expect(found).To(BeTrue())
+		store.Set("c", &dnssvcsv1.CachedResponse{})
+		Expect(store.Keys()).To(Equal([]string{"c", "a"}))
+		store.Delete("a")
+		Expect(store.Keys()).To(Equal([]string{"c"}))
+	})
+})
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (roundTrip roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return roundTrip(req)
+}
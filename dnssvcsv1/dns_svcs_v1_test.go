@@ -26,6 +26,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/IBM/dns-svcs-go-sdk/common"
 	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
 	"github.com/IBM/go-sdk-core/v4/core"
 	"github.com/go-openapi/strfmt"
@@ -1386,6 +1387,130 @@ var _ = Describe(`DnsSvcsV1`, func() {
 			})
 		})
 	})
+	Describe(`ListResourceRecords(listResourceRecordsOptions *ListResourceRecordsOptions) - Filters`, func() {
+		listResourceRecordsPath := "/instances/testString/dnszones/testString/resource_records"
+		Context(`Using mock server endpoint that ignores filters`, func() {
+			BeforeEach(func() {
+				testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+					defer GinkgoRecover()
+
+					// Verify the contents of the request
+					Expect(req.URL.Path).To(Equal(listResourceRecordsPath))
+					Expect(req.Method).To(Equal("GET"))
+					Expect(req.URL.Query()["type"]).To(Equal([]string{"CNAME"}))
+					if req.URL.Query().Get("name") != "" {
+						Expect(req.URL.Query()["name"]).To(Equal([]string{"www.example.com"}))
+					}
+
+					offset := req.URL.Query().Get("offset")
+					res.Header().Set("Content-type", "application/json")
+					res.WriteHeader(200)
+					if offset == "0" {
+						fmt.Fprintf(res, `{"resource_records": [{"id": "CNAME:1", "name": "www.example.com", "type": "CNAME", "ttl": 120, "rdata": {"cname": "host.example.com"}}, {"id": "A:2", "name": "host.example.com", "type": "A", "ttl": 120, "rdata": {"ip": "10.110.201.214"}}], "offset": 0, "limit": 2, "total_count": 3, "first": {"href": "https://api.dns-svcs.cloud.ibm.com/v1/instances/testString/dnszones/testString/resource_records?limit=2"}}`)
+					} else {
+						fmt.Fprintf(res, `{"resource_records": [{"id": "CNAME:3", "name": "api.example.com", "type": "CNAME", "ttl": 120, "rdata": {"cname": "Host.example.com."}}], "offset": 2, "limit": 2, "total_count": 3, "first": {"href": "https://api.dns-svcs.cloud.ibm.com/v1/instances/testString/dnszones/testString/resource_records?limit=2"}}`)
+					}
+				}))
+			})
+			It(`Invoke ListResourceRecords with filters returning the page unchanged`, func() {
+				testService, testServiceErr := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+				})
+				Expect(testServiceErr).To(BeNil())
+				Expect(testService).ToNot(BeNil())
+
+				listResourceRecordsOptionsModel := testService.NewListResourceRecordsOptions("testString", "testString")
+				listResourceRecordsOptionsModel.SetOffset(0).SetType(dnssvcsv1.ListResourceRecordsOptions_Type_Cname).SetName("WWW.example.com.")
+				listResourceRecordsOptionsModel.NameMatch = core.StringPtr(dnssvcsv1.ListResourceRecordsOptions_NameMatch_Exact)
+
+				listResourceRecordsOptionsModel.SetRdataValue("other.example.com")
+
+				result, response, operationErr := testService.ListResourceRecords(listResourceRecordsOptionsModel)
+				Expect(operationErr).To(BeNil())
+				Expect(response).ToNot(BeNil())
+				Expect(result.ResourceRecords).To(HaveLen(2))
+				Expect(*result.ResourceRecords[0].ID).To(Equal("CNAME:1"))
+				Expect(*result.Limit).To(Equal(int64(2)))
+				Expect(*result.TotalCount).To(Equal(int64(3)))
+			})
+			It(`Invoke ListAllResourceRecords with filters successfully`, func() {
+				testService, testServiceErr := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+				})
+				Expect(testServiceErr).To(BeNil())
+				Expect(testService).ToNot(BeNil())
+
+				listResourceRecordsOptionsModel := testService.NewListResourceRecordsOptions("testString", "testString")
+				listResourceRecordsOptionsModel.SetType(dnssvcsv1.ListResourceRecordsOptions_Type_Cname).SetRdataValue("host.example.com")
+
+				records, operationErr := testService.ListAllResourceRecords(listResourceRecordsOptionsModel)
+				Expect(operationErr).To(BeNil())
+				Expect(records).To(HaveLen(2))
+				Expect(*records[1].ID).To(Equal("CNAME:3"))
+
+				listResourceRecordsOptionsModel = testService.NewListResourceRecordsOptions("testString", "testString")
+				listResourceRecordsOptionsModel.SetType(dnssvcsv1.ListResourceRecordsOptions_Type_Cname).SetName("*.example.com").SetNameMatch(dnssvcsv1.ListResourceRecordsOptions_NameMatch_Glob)
+				records, operationErr = testService.ListAllResourceRecords(listResourceRecordsOptionsModel)
+				Expect(operationErr).To(BeNil())
+				Expect(records).To(HaveLen(2))
+
+				listResourceRecordsOptionsModel.SetName("ap").SetNameMatch(dnssvcsv1.ListResourceRecordsOptions_NameMatch_Prefix)
+				records, operationErr = testService.ListAllResourceRecords(listResourceRecordsOptionsModel)
+				Expect(operationErr).To(BeNil())
+				Expect(records).To(HaveLen(1))
+				Expect(*records[0].Name).To(Equal("api.example.com"))
+
+				records, operationErr = testService.ListAllResourceRecords(nil)
+				Expect(operationErr).ToNot(BeNil())
+				Expect(records).To(BeNil())
+			})
+			AfterEach(func() {
+				testServer.Close()
+			})
+		})
+		Context(`Using mock server endpoint returning TXT and SRV records`, func() {
+			BeforeEach(func() {
+				testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+					defer GinkgoRecover()
+
+					// Verify the contents of the request
+					Expect(req.URL.Path).To(Equal(listResourceRecordsPath))
+					Expect(req.Method).To(Equal("GET"))
+					res.Header().Set("Content-type", "application/json")
+					res.WriteHeader(200)
+					fmt.Fprintf(res, `{"resource_records": [{"id": "TXT:1", "name": "txt.example.com", "type": "TXT", "ttl": 120, "rdata": {"text": "Token."}}, {"id": "SRV:2", "name": "_sip._udp.example.com", "type": "SRV", "ttl": 120, "rdata": {"priority": 10, "weight": 2021030401, "port": 5060, "target": "Sip.example.com."}}], "offset": 0, "limit": 200, "total_count": 2}`)
+				}))
+			})
+			It(`Invoke ListAllResourceRecords matching rdata values exactly`, func() {
+				testService, testServiceErr := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+				})
+				Expect(testServiceErr).To(BeNil())
+				Expect(testService).ToNot(BeNil())
+
+				expectIDs := func(rdataValue string, ids ...string) {
+					listResourceRecordsOptionsModel := testService.NewListResourceRecordsOptions("testString", "testString")
+					listResourceRecordsOptionsModel.SetRdataValue(rdataValue)
+					records, operationErr := testService.ListAllResourceRecords(listResourceRecordsOptionsModel)
+					Expect(operationErr).To(BeNil())
+					Expect(records).To(HaveLen(len(ids)))
+					for i, id := range ids {
+						Expect(*records[i].ID).To(Equal(id))
+					}
+				}
+				expectIDs("Token.", "TXT:1")
+				expectIDs("token")
+				expectIDs("2021030401", "SRV:2")
+				expectIDs("sip.example.com", "SRV:2")
+			})
+			AfterEach(func() {
+				testServer.Close()
+			})
+		})
+	})
 	Describe(`CreateResourceRecord(createResourceRecordOptions *CreateResourceRecordOptions) - Operation response error`, func() {
 		createResourceRecordPath := "/instances/testString/dnszones/testString/resource_records"
 		Context(`Using mock server endpoint`, func() {
@@ -3435,7 +3560,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 					Expect(req.Header["X-Correlation-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
-					fmt.Fprintf(res, `{"monitors": [{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}], "offset": 1, "limit": 20, "count": 1, "total_count": 200, "first": {"href": "https://api.dns-svcs.cloud.ibm.com/v1/instances/434f6c3e-6014-4124-a61d-2e910bca19b1/dnszones/example.com:d04d3a7a-7f6d-47d4-b811-08c5478fa1a4/resource_records?limit=20"}, "next": {"href": "https://api.dns-svcs.cloud.ibm.com/v1/instances/434f6c3e-6014-4124-a61d-2e910bca19b1/dnszones/example.com:d04d3a7a-7f6d-47d4-b811-08c5478fa1a4/resource_records?offset=20&limit=20"}}`)
+					fmt.Fprintf(res, `{"monitors": [{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "follow_redirects": false, "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}], "offset": 1, "limit": 20, "count": 1, "total_count": 200, "first": {"href": "https://api.dns-svcs.cloud.ibm.com/v1/instances/434f6c3e-6014-4124-a61d-2e910bca19b1/dnszones/example.com:d04d3a7a-7f6d-47d4-b811-08c5478fa1a4/resource_records?limit=20"}, "next": {"href": "https://api.dns-svcs.cloud.ibm.com/v1/instances/434f6c3e-6014-4124-a61d-2e910bca19b1/dnszones/example.com:d04d3a7a-7f6d-47d4-b811-08c5478fa1a4/resource_records?offset=20&limit=20"}}`)
 				}))
 			})
 			It(`Invoke ListMonitors successfully`, func() {
@@ -3544,6 +3669,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				createMonitorOptionsModel.AllowInsecure = core.BoolPtr(false)
 				createMonitorOptionsModel.ExpectedCodes = core.StringPtr("200")
 				createMonitorOptionsModel.ExpectedBody = core.StringPtr("alive")
+				createMonitorOptionsModel.FollowRedirects = core.BoolPtr(true)
 				createMonitorOptionsModel.XCorrelationID = core.StringPtr("testString")
 				createMonitorOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
@@ -3572,7 +3698,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 					Expect(req.Header["X-Correlation-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
-					fmt.Fprintf(res, `{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}`)
+					fmt.Fprintf(res, `{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "follow_redirects": false, "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}`)
 				}))
 			})
 			It(`Invoke CreateMonitor successfully`, func() {
@@ -3610,6 +3736,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				createMonitorOptionsModel.AllowInsecure = core.BoolPtr(false)
 				createMonitorOptionsModel.ExpectedCodes = core.StringPtr("200")
 				createMonitorOptionsModel.ExpectedBody = core.StringPtr("alive")
+				createMonitorOptionsModel.FollowRedirects = core.BoolPtr(true)
 				createMonitorOptionsModel.XCorrelationID = core.StringPtr("testString")
 				createMonitorOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
@@ -3648,6 +3775,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				createMonitorOptionsModel.AllowInsecure = core.BoolPtr(false)
 				createMonitorOptionsModel.ExpectedCodes = core.StringPtr("200")
 				createMonitorOptionsModel.ExpectedBody = core.StringPtr("alive")
+				createMonitorOptionsModel.FollowRedirects = core.BoolPtr(true)
 				createMonitorOptionsModel.XCorrelationID = core.StringPtr("testString")
 				createMonitorOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
@@ -3802,7 +3930,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 					Expect(req.Header["X-Correlation-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
-					fmt.Fprintf(res, `{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}`)
+					fmt.Fprintf(res, `{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "follow_redirects": false, "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}`)
 				}))
 			})
 			It(`Invoke GetMonitor successfully`, func() {
@@ -3914,6 +4042,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				updateMonitorOptionsModel.AllowInsecure = core.BoolPtr(false)
 				updateMonitorOptionsModel.ExpectedCodes = core.StringPtr("200")
 				updateMonitorOptionsModel.ExpectedBody = core.StringPtr("alive")
+				updateMonitorOptionsModel.FollowRedirects = core.BoolPtr(true)
 				updateMonitorOptionsModel.XCorrelationID = core.StringPtr("testString")
 				updateMonitorOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
@@ -3942,7 +4071,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 					Expect(req.Header["X-Correlation-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
-					fmt.Fprintf(res, `{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}`)
+					fmt.Fprintf(res, `{"id": "5365b73c-ce6f-4d6f-ad9f-d9c131b26370", "name": "healthcheck-monitor", "description": "Load balancer monitor for glb.example.com.", "type": "HTTPS", "port": 8080, "interval": 60, "retries": 2, "timeout": 5, "method": "GET", "path": "/health", "headers": [{"name": "Host", "value": ["origin.example.com"]}], "allow_insecure": false, "expected_codes": "200", "expected_body": "alive", "follow_redirects": false, "created_on": "2019-01-01T12:00:00", "modified_on": "2019-01-01T12:00:00"}`)
 				}))
 			})
 			It(`Invoke UpdateMonitor successfully`, func() {
@@ -3981,6 +4110,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				updateMonitorOptionsModel.AllowInsecure = core.BoolPtr(false)
 				updateMonitorOptionsModel.ExpectedCodes = core.StringPtr("200")
 				updateMonitorOptionsModel.ExpectedBody = core.StringPtr("alive")
+				updateMonitorOptionsModel.FollowRedirects = core.BoolPtr(true)
 				updateMonitorOptionsModel.XCorrelationID = core.StringPtr("testString")
 				updateMonitorOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
@@ -4020,6 +4150,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				updateMonitorOptionsModel.AllowInsecure = core.BoolPtr(false)
 				updateMonitorOptionsModel.ExpectedCodes = core.StringPtr("200")
 				updateMonitorOptionsModel.ExpectedBody = core.StringPtr("alive")
+				updateMonitorOptionsModel.FollowRedirects = core.BoolPtr(true)
 				updateMonitorOptionsModel.XCorrelationID = core.StringPtr("testString")
 				updateMonitorOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
@@ -4220,6 +4351,25 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				Expect(model).ToNot(BeNil())
 				Expect(err).To(BeNil())
 			})
+			It(`Invoke NewPermittedNetworkVpcFromCrn successfully`, func() {
+				vpcCrn := common.NewVpcCrn("eu-de", "bcf1865e99742d38d2d5fc3fb80a5496", "6e6cc326-04d1-4c99-a289-efb3ae4193d6")
+				model, err := testService.NewPermittedNetworkVpcFromCrn(vpcCrn)
+				Expect(err).To(BeNil())
+				Expect(*model.VpcCrn).To(Equal("crn:v1:bluemix:public:is:eu-de:a/bcf1865e99742d38d2d5fc3fb80a5496::vpc:6e6cc326-04d1-4c99-a289-efb3ae4193d6"))
+				parsed, err := model.ParsedVpcCrn()
+				Expect(err).To(BeNil())
+				Expect(parsed).To(Equal(vpcCrn))
+
+				vpcCrn.ResourceType = common.CrnResourceTypeSubnet
+				_, err = testService.NewPermittedNetworkVpcFromCrn(vpcCrn)
+				Expect(err).ToNot(BeNil())
+				model.VpcCrn = core.StringPtr("vpc crn")
+				_, err = model.ParsedVpcCrn()
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("is not a CRN"))
+				_, err = testService.NewPermittedNetworkVpcFromCrn(nil)
+				Expect(err).ToNot(BeNil())
+			})
 			It(`Invoke NewCreateResourceRecordOptions successfully`, func() {
 				// Construct an instance of the ResourceRecordInputRdataRdataARecord model
 				resourceRecordInputRdataModel := new(dnssvcsv1.ResourceRecordInputRdataRdataARecord)
@@ -4354,6 +4504,14 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				Expect(model).ToNot(BeNil())
 				Expect(err).To(BeNil())
 			})
+			It(`Invoke NewResourceRecordInputRdataRdataCaaRecord successfully`, func() {
+				flags := int64(0)
+				tag := "issue"
+				value := "letsencrypt.org"
+				model, err := testService.NewResourceRecordInputRdataRdataCaaRecord(flags, tag, value)
+				Expect(model).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
 			It(`Invoke NewResourceRecordInputRdataRdataCnameRecord successfully`, func() {
 				cname := "www.example.com"
 				model, err := testService.NewResourceRecordInputRdataRdataCnameRecord(cname)
@@ -4400,6 +4558,14 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				Expect(model).ToNot(BeNil())
 				Expect(err).To(BeNil())
 			})
+			It(`Invoke NewResourceRecordUpdateInputRdataRdataCaaRecord successfully`, func() {
+				flags := int64(0)
+				tag := "issue"
+				value := "letsencrypt.org"
+				model, err := testService.NewResourceRecordUpdateInputRdataRdataCaaRecord(flags, tag, value)
+				Expect(model).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
 			It(`Invoke NewResourceRecordUpdateInputRdataRdataCnameRecord successfully`, func() {
 				cname := "www.example.com"
 				model, err := testService.NewResourceRecordUpdateInputRdataRdataCnameRecord(cname)
@@ -4497,6 +4663,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				createMonitorOptionsModel.SetAllowInsecure(false)
 				createMonitorOptionsModel.SetExpectedCodes("200")
 				createMonitorOptionsModel.SetExpectedBody("alive")
+				createMonitorOptionsModel.SetFollowRedirects(false)
 				createMonitorOptionsModel.SetXCorrelationID("testString")
 				createMonitorOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(createMonitorOptionsModel).ToNot(BeNil())
@@ -4514,6 +4681,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				Expect(createMonitorOptionsModel.AllowInsecure).To(Equal(core.BoolPtr(false)))
 				Expect(createMonitorOptionsModel.ExpectedCodes).To(Equal(core.StringPtr("200")))
 				Expect(createMonitorOptionsModel.ExpectedBody).To(Equal(core.StringPtr("alive")))
+				Expect(createMonitorOptionsModel.FollowRedirects).To(Equal(core.BoolPtr(false)))
 				Expect(createMonitorOptionsModel.XCorrelationID).To(Equal(core.StringPtr("testString")))
 				Expect(createMonitorOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
@@ -4762,6 +4930,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				updateMonitorOptionsModel.SetAllowInsecure(false)
 				updateMonitorOptionsModel.SetExpectedCodes("200")
 				updateMonitorOptionsModel.SetExpectedBody("alive")
+				updateMonitorOptionsModel.SetFollowRedirects(false)
 				updateMonitorOptionsModel.SetXCorrelationID("testString")
 				updateMonitorOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(updateMonitorOptionsModel).ToNot(BeNil())
@@ -4780,6 +4949,7 @@ var _ = Describe(`DnsSvcsV1`, func() {
 				Expect(updateMonitorOptionsModel.AllowInsecure).To(Equal(core.BoolPtr(false)))
 				Expect(updateMonitorOptionsModel.ExpectedCodes).To(Equal(core.StringPtr("200")))
 				Expect(updateMonitorOptionsModel.ExpectedBody).To(Equal(core.StringPtr("alive")))
+				Expect(updateMonitorOptionsModel.FollowRedirects).To(Equal(core.BoolPtr(false)))
 				Expect(updateMonitorOptionsModel.XCorrelationID).To(Equal(core.StringPtr("testString")))
 				Expect(updateMonitorOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
@@ -4837,6 +5007,71 @@ var _ = Describe(`DnsSvcsV1`, func() {
 			})
 		})
 	})
+	Describe(`ResourceRecord.GetRdataModel(unmarshaller core.ModelUnmarshaller, result interface{})`, func() {
+		It(`Invoke GetRdataModel for a SOA record successfully`, func() {
+			resourceRecordModel := new(dnssvcsv1.ResourceRecord)
+			resourceRecordModel.Type = core.StringPtr(dnssvcsv1.ResourceRecord_Type_Soa)
+			resourceRecordModel.Rdata = map[string]interface{}{"mname": "ns1.example.com", "rname": "hostmaster.example.com", "serial": 2020010101, "refresh": 3600, "retry": 600, "expire": 604800, "minimum": 300}
+
+			var soa *dnssvcsv1.ResourceRecordRdataSoaRecord
+			err := resourceRecordModel.GetRdataModel(dnssvcsv1.UnmarshalResourceRecordRdataSoaRecord, &soa)
+			Expect(err).To(BeNil())
+			Expect(soa.Mname).To(Equal(core.StringPtr("ns1.example.com")))
+			Expect(soa.Serial).To(Equal(core.Int64Ptr(int64(2020010101))))
+			Expect(soa.Minimum).To(Equal(core.Int64Ptr(int64(300))))
+		})
+		It(`Invoke GetRdataModel for a CAA record successfully`, func() {
+			resourceRecordModel := new(dnssvcsv1.ResourceRecord)
+			resourceRecordModel.Type = core.StringPtr(dnssvcsv1.ResourceRecord_Type_Caa)
+			resourceRecordModel.Rdata = map[string]interface{}{"flags": 128, "tag": "issue", "value": "letsencrypt.org"}
+
+			var caa *dnssvcsv1.ResourceRecordInputRdataRdataCaaRecord
+			err := resourceRecordModel.GetRdataModel(dnssvcsv1.UnmarshalResourceRecordInputRdataRdataCaaRecord, &caa)
+			Expect(err).To(BeNil())
+			Expect(caa.Flags).To(Equal(core.Int64Ptr(int64(128))))
+			Expect(caa.Tag).To(Equal(core.StringPtr(dnssvcsv1.ResourceRecordInputRdataRdataCaaRecord_Tag_Issue)))
+		})
+		It(`Invoke GetRdataModel without rdata`, func() {
+			var ns *dnssvcsv1.ResourceRecordRdataNsRecord
+			err := new(dnssvcsv1.ResourceRecord).GetRdataModel(dnssvcsv1.UnmarshalResourceRecordRdataNsRecord, &ns)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+	Describe(`HealthcheckHeaderBuilder`, func() {
+		testService, _ := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+			URL:           "http://dnssvcsv1modelgenerator.com",
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		It(`Build health check headers successfully`, func() {
+			headers, err := testService.NewHealthcheckHeaders().Add("Host", "example.com").Add("host", "www.example.com").Set("X-App-ID", "abc123").Build()
+			Expect(err).To(BeNil())
+			Expect(headers).To(HaveLen(2))
+			Expect(*headers[0].Name).To(Equal("Host"))
+			Expect(headers[0].Value).To(Equal([]string{"example.com", "www.example.com"}))
+			Expect(*headers[1].Name).To(Equal("X-App-ID"))
+
+			createMonitorOptionsModel := new(dnssvcsv1.CreateMonitorOptions).SetHeader(map[string]string{"X-App-ID": "abc123", "Host": "example.com"}).SetFollowRedirects(true)
+			Expect(createMonitorOptionsModel.HeadersVar).To(HaveLen(2))
+			Expect(*createMonitorOptionsModel.HeadersVar[0].Name).To(Equal("Host"))
+			Expect(*createMonitorOptionsModel.FollowRedirects).To(BeTrue())
+		})
+		It(`Build health check headers with error: User-Agent cannot be overridden`, func() {
+			headers, err := testService.NewHealthcheckHeaders().Set("user-agent", "probe/1.0").Build()
+			Expect(err).ToNot(BeNil())
+			Expect(headers).To(BeNil())
+
+			createMonitorOptionsModel := testService.NewCreateMonitorOptions("testString").SetHeader(map[string]string{"User-Agent": "probe/1.0"})
+			result, response, operationErr := testService.CreateMonitor(createMonitorOptionsModel)
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).To(BeNil())
+			Expect(result).To(BeNil())
+			updateMonitorOptionsModel := testService.NewUpdateMonitorOptions("testString", "testString").SetHeader(map[string]string{"User-Agent": "probe/1.0"})
+			result, response, operationErr = testService.UpdateMonitor(updateMonitorOptionsModel)
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).To(BeNil())
+			Expect(result).To(BeNil())
+		})
+	})
 	Describe(`Utility function tests`, func() {
 		It(`Invoke CreateMockByteArray() successfully`, func() {
 			mockByteArray := CreateMockByteArray("This is a test")
@@ -0,0 +1,98 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"time"
+
+	common "github.com/IBM/dns-svcs-go-sdk/common"
+)
+
+// The service returns the creation and modification times of its objects as strings, in more than one format over
+// time. The accessors below parse them with common.ParseTimestamp.
+
+// CreatedOnTime returns the time the DNS zone was created, or an error if CreatedOn is missing or malformed.
+func (dnszone *Dnszone) CreatedOnTime() (time.Time, error) {
+	return parseTimestampProperty(dnszone.CreatedOn, "created_on")
+}
+
+// ModifiedOnTime returns the time the DNS zone was last modified, or an error if ModifiedOn is missing or malformed.
+func (dnszone *Dnszone) ModifiedOnTime() (time.Time, error) {
+	return parseTimestampProperty(dnszone.ModifiedOn, "modified_on")
+}
+
+// CreatedOnTime returns the time the resource record was created, or an error if CreatedOn is missing or malformed.
+func (resourceRecord *ResourceRecord) CreatedOnTime() (time.Time, error) {
+	return parseTimestampProperty(resourceRecord.CreatedOn, "created_on")
+}
+
+// ModifiedOnTime returns the time the resource record was last modified, or an error if ModifiedOn is missing or malformed.
+func (resourceRecord *ResourceRecord) ModifiedOnTime() (time.Time, error) {
+	return parseTimestampProperty(resourceRecord.ModifiedOn, "modified_on")
+}
+
+// CreatedOnTime returns the time the permitted network was created, or an error if CreatedOn is missing or malformed.
+func (permittedNetwork *PermittedNetwork) CreatedOnTime() (time.Time, error) {
+	return parseTimestampProperty(permittedNetwork.CreatedOn, "created_on")
+}
+
+// ModifiedOnTime returns the time the permitted network was last modified, or an error if ModifiedOn is missing or malformed.
+func (permittedNetwork *PermittedNetwork) ModifiedOnTime() (time.Time, error) {
+	return parseTimestampProperty(permittedNetwork.ModifiedOn, "modified_on")
+}
+
+// CreatedOnTime returns the time the load balancer was created, or an error if CreatedOn is missing or malformed.
+func (loadBalancer *LoadBalancer) CreatedOnTime() (time.Time, error) {
+	return parseTimestampProperty(loadBalancer.CreatedOn, "created_on")
+}
+
+// ModifiedOnTime returns the time the load balancer was last modified, or an error if ModifiedOn is missing or malformed.
+func (loadBalancer *LoadBalancer) ModifiedOnTime() (time.Time, error) {
+	return parseTimestampProperty(loadBalancer.ModifiedOn, "modified_on")
+}
+
+// CreatedOnTime returns the time the pool was created, or an error if CreatedOn is missing or malformed.
+func (pool *Pool) CreatedOnTime() (time.Time, error) {
+	return parseTimestampProperty(pool.CreatedOn, "created_on")
+}
+
+// ModifiedOnTime returns the time the pool was last modified, or an error if ModifiedOn is missing or malformed.
+func (pool *Pool) ModifiedOnTime() (time.Time, error) {
+	return parseTimestampProperty(pool.ModifiedOn, "modified_on")
+}
+
+// CreatedOnTime returns the time the monitor was created, or an error if CreatedOn is missing or malformed.
+func (monitor *Monitor) CreatedOnTime() (time.Time, error) {
+	return parseTimestampProperty(monitor.CreatedOn, "created_on")
+}
+
+// ModifiedOnTime returns the time the monitor was last modified, or an error if ModifiedOn is missing or malformed.
+func (monitor *Monitor) ModifiedOnTime() (time.Time, error) {
+	return parseTimestampProperty(monitor.ModifiedOn, "modified_on")
+}
+
+func parseTimestampProperty(value *string, property string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("%s is missing", property)
+	}
+	parsed, err := common.ParseTimestamp(*value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %s", property, err.Error())
+	}
+	return parsed, nil
+}
@@ -0,0 +1,51 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"time"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Timestamps`, func() {
+	It(`Invoke CreatedOnTime and ModifiedOnTime successfully`, func() {
+		noon := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+		dnszone := &dnssvcsv1.Dnszone{CreatedOn: core.StringPtr("2019-01-01T12:00:00"), ModifiedOn: core.StringPtr("2019-01-01 12:00:00.000 +0000 UTC")}
+		created, err := dnszone.CreatedOnTime()
+		Expect(err).To(BeNil())
+		Expect(created.Equal(noon)).To(BeTrue())
+		modified, err := dnszone.ModifiedOnTime()
+		Expect(err).To(BeNil())
+		Expect(modified.Equal(noon)).To(BeTrue())
+
+		pool := &dnssvcsv1.Pool{CreatedOn: core.StringPtr("2019-01-01T12:00:00Z")}
+		created, err = pool.CreatedOnTime()
+		Expect(err).To(BeNil())
+		Expect(created.Equal(noon)).To(BeTrue())
+	})
+	It(`Invoke CreatedOnTime and ModifiedOnTime with error`, func() {
+		record := &dnssvcsv1.ResourceRecord{CreatedOn: core.StringPtr("yesterday")}
+		_, err := record.CreatedOnTime()
+		Expect(err.Error()).To(Equal(`created_on: "yesterday" is not a timestamp`))
+		_, err = record.ModifiedOnTime()
+		Expect(err.Error()).To(Equal("modified_on is missing"))
+	})
+})
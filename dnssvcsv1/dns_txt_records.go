@@ -0,0 +1,309 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SpfRecord : An SPF policy (RFC 7208) published as the text of a TXT resource record.
+type SpfRecord struct {
+	// Mechanisms and modifiers in evaluation order, for example "ip4:192.0.2.0/24", "include:_spf.example.com" or
+	// "mx". The trailing "all" mechanism is held in All.
+	Terms []string
+
+	// The trailing "all" mechanism with its qualifier as written, so that an unqualified "all" stays so. An empty
+	// value leaves "all" out of the policy.
+	All string
+}
+
+// Constants associated with the SpfRecord.All property.
+// The trailing "all" mechanism with its qualifier as written.
+const (
+	SpfRecord_All_Fail        = "-all"
+	SpfRecord_All_Neutral     = "?all"
+	SpfRecord_All_Pass        = "+all"
+	SpfRecord_All_SoftFail    = "~all"
+	SpfRecord_All_Unqualified = "all"
+)
+
+// String returns the TXT record text of the SPF policy.
+func (spf *SpfRecord) String() string {
+	terms := append([]string{"v=spf1"}, spf.Terms...)
+	if spf.All != "" {
+		terms = append(terms, spf.All)
+	}
+	return strings.Join(terms, " ")
+}
+
+// ParseSpfRecord parses the text of a TXT resource record as an SPF policy.
+func ParseSpfRecord(txtdata string) (spf *SpfRecord, err error) {
+	fields := strings.Fields(txtdata)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "v=spf1") {
+		err = fmt.Errorf("TXT record is not an SPF policy: missing 'v=spf1'")
+		return
+	}
+	spf = &SpfRecord{}
+	for _, field := range fields[1:] {
+		lower := strings.ToLower(field)
+		if lower == SpfRecord_All_Unqualified || (len(lower) == 4 && strings.HasSuffix(lower, "all") && strings.ContainsAny(lower[:1], "+-~?")) {
+			spf.All = lower
+			continue
+		}
+		spf.Terms = append(spf.Terms, field)
+	}
+	return
+}
+
+// DkimRecord : A DKIM public key record (RFC 6376) published as the text of a TXT resource record.
+type DkimRecord struct {
+	// Version of the DKIM key record, "DKIM1" when set.
+	Version string
+
+	// Key type, the default value is "rsa".
+	KeyType string
+
+	// Base64 encoded public key. An empty value means the key has been revoked.
+	PublicKey string
+
+	// Acceptable hash algorithms, for example "sha256".
+	HashAlgorithms []string
+
+	// Service types the key applies to, for example "email".
+	ServiceTypes []string
+
+	// Flags of the key, for example "y" for testing mode.
+	Flags []string
+
+	// Notes for administrators.
+	Notes string
+}
+
+// String returns the TXT record text of the DKIM key record.
+func (dkim *DkimRecord) String() string {
+	tags := []string{}
+	if dkim.Version != "" {
+		tags = append(tags, "v="+dkim.Version)
+	}
+	if len(dkim.HashAlgorithms) > 0 {
+		tags = append(tags, "h="+strings.Join(dkim.HashAlgorithms, ":"))
+	}
+	if dkim.KeyType != "" {
+		tags = append(tags, "k="+dkim.KeyType)
+	}
+	if dkim.Notes != "" {
+		tags = append(tags, "n="+dkim.Notes)
+	}
+	if len(dkim.ServiceTypes) > 0 {
+		tags = append(tags, "s="+strings.Join(dkim.ServiceTypes, ":"))
+	}
+	if len(dkim.Flags) > 0 {
+		tags = append(tags, "t="+strings.Join(dkim.Flags, ":"))
+	}
+	tags = append(tags, "p="+dkim.PublicKey)
+	return strings.Join(tags, "; ")
+}
+
+// ParseDkimRecord parses the text of a TXT resource record as a DKIM key record.
+func ParseDkimRecord(txtdata string) (dkim *DkimRecord, err error) {
+	tags, order, err := parseTagValueList(txtdata)
+	if err != nil {
+		return
+	}
+	if version, ok := tags["v"]; ok && (order[0] != "v" || version != "DKIM1") {
+		err = fmt.Errorf("TXT record is not a DKIM key record: 'v=DKIM1' must be the first tag")
+		return
+	}
+	publicKey, ok := tags["p"]
+	if !ok {
+		err = fmt.Errorf("TXT record is not a DKIM key record: missing 'p' tag")
+		return
+	}
+	dkim = &DkimRecord{
+		Version:        tags["v"],
+		KeyType:        tags["k"],
+		PublicKey:      strings.Join(strings.Fields(publicKey), ""),
+		HashAlgorithms: splitTagList(tags["h"]),
+		ServiceTypes:   splitTagList(tags["s"]),
+		Flags:          splitTagList(tags["t"]),
+		Notes:          tags["n"],
+	}
+	return
+}
+
+// DmarcRecord : A DMARC policy (RFC 7489) published as the text of the TXT resource record of "_dmarc.<domain>".
+type DmarcRecord struct {
+	// Policy for the domain.
+	Policy string
+
+	// Policy for subdomains of the domain. An empty value means subdomains use Policy.
+	SubdomainPolicy string
+
+	// Percentage of messages the policy applies to.
+	Percentage *int64
+
+	// URIs that aggregate reports are sent to, for example "mailto:dmarc@example.com".
+	AggregateReportURIs []string
+
+	// URIs that failure reports are sent to.
+	ForensicReportURIs []string
+
+	// DKIM identifier alignment mode.
+	DkimAlignment string
+
+	// SPF identifier alignment mode.
+	SpfAlignment string
+
+	// Failure reporting options, for example "1" or "d:s".
+	FailureOptions string
+
+	// Interval in second between aggregate reports.
+	ReportInterval *int64
+}
+
+// Constants associated with the DmarcRecord.Policy and DmarcRecord.SubdomainPolicy properties.
+// Policy for the domain.
+const (
+	DmarcRecord_Policy_None       = "none"
+	DmarcRecord_Policy_Quarantine = "quarantine"
+	DmarcRecord_Policy_Reject     = "reject"
+)
+
+// Constants associated with the DmarcRecord.DkimAlignment and DmarcRecord.SpfAlignment properties.
+// Identifier alignment mode.
+const (
+	DmarcRecord_Alignment_Relaxed = "r"
+	DmarcRecord_Alignment_Strict  = "s"
+)
+
+// String returns the TXT record text of the DMARC policy.
+func (dmarc *DmarcRecord) String() string {
+	tags := []string{"v=DMARC1", "p=" + dmarc.Policy}
+	if dmarc.SubdomainPolicy != "" {
+		tags = append(tags, "sp="+dmarc.SubdomainPolicy)
+	}
+	if dmarc.Percentage != nil {
+		tags = append(tags, "pct="+strconv.FormatInt(*dmarc.Percentage, 10))
+	}
+	if len(dmarc.AggregateReportURIs) > 0 {
+		tags = append(tags, "rua="+strings.Join(dmarc.AggregateReportURIs, ","))
+	}
+	if len(dmarc.ForensicReportURIs) > 0 {
+		tags = append(tags, "ruf="+strings.Join(dmarc.ForensicReportURIs, ","))
+	}
+	if dmarc.DkimAlignment != "" {
+		tags = append(tags, "adkim="+dmarc.DkimAlignment)
+	}
+	if dmarc.SpfAlignment != "" {
+		tags = append(tags, "aspf="+dmarc.SpfAlignment)
+	}
+	if dmarc.FailureOptions != "" {
+		tags = append(tags, "fo="+dmarc.FailureOptions)
+	}
+	if dmarc.ReportInterval != nil {
+		tags = append(tags, "ri="+strconv.FormatInt(*dmarc.ReportInterval, 10))
+	}
+	return strings.Join(tags, "; ")
+}
+
+// ParseDmarcRecord parses the text of a TXT resource record as a DMARC policy.
+func ParseDmarcRecord(txtdata string) (dmarc *DmarcRecord, err error) {
+	tags, order, err := parseTagValueList(txtdata)
+	if err != nil {
+		return
+	}
+	if len(order) < 2 || order[0] != "v" || tags["v"] != "DMARC1" || order[1] != "p" {
+		err = fmt.Errorf("TXT record is not a DMARC policy: it must start with 'v=DMARC1; p='")
+		return
+	}
+	dmarc = &DmarcRecord{
+		Policy:              tags["p"],
+		SubdomainPolicy:     tags["sp"],
+		AggregateReportURIs: splitURIList(tags["rua"]),
+		ForensicReportURIs:  splitURIList(tags["ruf"]),
+		DkimAlignment:       tags["adkim"],
+		SpfAlignment:        tags["aspf"],
+		FailureOptions:      tags["fo"],
+	}
+	if pct, ok := tags["pct"]; ok {
+		var value int64
+		value, err = strconv.ParseInt(pct, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid DMARC 'pct' tag '%s'", pct)
+			return nil, err
+		}
+		dmarc.Percentage = &value
+	}
+	if ri, ok := tags["ri"]; ok {
+		var value int64
+		value, err = strconv.ParseInt(ri, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid DMARC 'ri' tag '%s'", ri)
+			return nil, err
+		}
+		dmarc.ReportInterval = &value
+	}
+	return
+}
+
+// parseTagValueList splits a "tag=value; tag=value" list as used by DKIM and DMARC records. It returns the values by
+// tag name and the tag names in the order they appear.
+func parseTagValueList(txtdata string) (tags map[string]string, order []string, err error) {
+	tags = make(map[string]string)
+	for _, spec := range strings.Split(txtdata, ";") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		pos := strings.Index(spec, "=")
+		if pos <= 0 {
+			err = fmt.Errorf("malformed tag '%s'", spec)
+			return
+		}
+		name := strings.TrimSpace(spec[:pos])
+		if _, found := tags[name]; found {
+			err = fmt.Errorf("duplicate tag '%s'", name)
+			return
+		}
+		tags[name] = strings.TrimSpace(spec[pos+1:])
+		order = append(order, name)
+	}
+	if len(order) == 0 {
+		err = fmt.Errorf("TXT record contains no tags")
+	}
+	return
+}
+
+func splitTagList(value string) (list []string) {
+	for _, item := range strings.Split(value, ":") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return
+}
+
+func splitURIList(value string) (list []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return
+}
@@ -0,0 +1,101 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`TXT record helpers`, func() {
+	Describe(`SpfRecord`, func() {
+		It(`Build and parse an SPF policy successfully`, func() {
+			spf := &dnssvcsv1.SpfRecord{
+				Terms: []string{"ip4:192.0.2.0/24", "include:_spf.example.com", "mx"},
+				All:   dnssvcsv1.SpfRecord_All_SoftFail,
+			}
+			Expect(spf.String()).To(Equal("v=spf1 ip4:192.0.2.0/24 include:_spf.example.com mx ~all"))
+
+			parsed, err := dnssvcsv1.ParseSpfRecord(spf.String())
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(spf))
+		})
+		It(`Parse an SPF policy with an unqualified all`, func() {
+			parsed, err := dnssvcsv1.ParseSpfRecord("v=spf1 a all")
+			Expect(err).To(BeNil())
+			Expect(parsed.Terms).To(Equal([]string{"a"}))
+			Expect(parsed.All).To(Equal(dnssvcsv1.SpfRecord_All_Unqualified))
+		})
+		It(`Parse and format SPF policies without changing them`, func() {
+			for _, txtdata := range []string{"v=spf1 a all", "v=spf1 mx +all", "v=spf1 -all", "v=spf1 include:_spf.example.com ?all", "v=spf1 a"} {
+				parsed, err := dnssvcsv1.ParseSpfRecord(txtdata)
+				Expect(err).To(BeNil())
+				Expect(parsed.String()).To(Equal(txtdata))
+			}
+		})
+		It(`Parse a TXT record that is not an SPF policy`, func() {
+			_, err := dnssvcsv1.ParseSpfRecord("google-site-verification=abc")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+	Describe(`DkimRecord`, func() {
+		It(`Build and parse a DKIM key record successfully`, func() {
+			dkim := &dnssvcsv1.DkimRecord{
+				Version:        "DKIM1",
+				KeyType:        "rsa",
+				PublicKey:      "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQDwIRP",
+				HashAlgorithms: []string{"sha256"},
+				Flags:          []string{"y", "s"},
+			}
+			Expect(dkim.String()).To(Equal("v=DKIM1; h=sha256; k=rsa; t=y:s; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQDwIRP"))
+
+			parsed, err := dnssvcsv1.ParseDkimRecord(dkim.String())
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(dkim))
+		})
+		It(`Parse a DKIM key record with errors`, func() {
+			_, err := dnssvcsv1.ParseDkimRecord("v=DKIM1; k=rsa")
+			Expect(err).ToNot(BeNil())
+			_, err = dnssvcsv1.ParseDkimRecord("k=rsa; v=DKIM1; p=abc")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+	Describe(`DmarcRecord`, func() {
+		It(`Build and parse a DMARC policy successfully`, func() {
+			dmarc := &dnssvcsv1.DmarcRecord{
+				Policy:              dnssvcsv1.DmarcRecord_Policy_Reject,
+				SubdomainPolicy:     dnssvcsv1.DmarcRecord_Policy_Quarantine,
+				Percentage:          core.Int64Ptr(int64(50)),
+				AggregateReportURIs: []string{"mailto:dmarc@example.com", "mailto:ops@example.com"},
+				DkimAlignment:       dnssvcsv1.DmarcRecord_Alignment_Strict,
+			}
+			Expect(dmarc.String()).To(Equal("v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@example.com,mailto:ops@example.com; adkim=s"))
+
+			parsed, err := dnssvcsv1.ParseDmarcRecord(dmarc.String())
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(dmarc))
+		})
+		It(`Parse a DMARC policy with errors`, func() {
+			_, err := dnssvcsv1.ParseDmarcRecord("p=reject; v=DMARC1")
+			Expect(err).ToNot(BeNil())
+			_, err = dnssvcsv1.ParseDmarcRecord("v=DMARC1; p=none; pct=all")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
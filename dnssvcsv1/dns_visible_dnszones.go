@@ -0,0 +1,151 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"fmt"
+	"strings"
+
+	common "github.com/IBM/dns-svcs-go-sdk/common"
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// ListVisibleDnszonesOptions : The ListVisibleDnszones options.
+type ListVisibleDnszonesOptions struct {
+	// The unique identifier of a service instance.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The CRN of the VPC. Either VpcCrn or VpcID is required.
+	VpcCrn *string `json:"vpc_crn,omitempty"`
+
+	// The ID of the VPC, the last segment of its CRN. Either VpcCrn or VpcID is required.
+	VpcID *string `json:"vpc_id,omitempty"`
+
+	// Whether to include the resource records of every visible DNS zone.
+	IncludeResourceRecords *bool `json:"include_resource_records,omitempty"`
+}
+
+// NewListVisibleDnszonesOptions : Instantiate ListVisibleDnszonesOptions for a VPC given by CRN, such as
+// "crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-5ab1a3b0", or by ID, such as "r006-5ab1a3b0".
+func (*DnsSvcsV1) NewListVisibleDnszonesOptions(instanceID string, vpc string) *ListVisibleDnszonesOptions {
+	options := &ListVisibleDnszonesOptions{
+		InstanceID: core.StringPtr(instanceID),
+	}
+	if strings.HasPrefix(vpc, "crn:") {
+		options.VpcCrn = core.StringPtr(vpc)
+	} else {
+		options.VpcID = core.StringPtr(vpc)
+	}
+	return options
+}
+
+// SetIncludeResourceRecords : Allow user to set IncludeResourceRecords
+func (options *ListVisibleDnszonesOptions) SetIncludeResourceRecords(includeResourceRecords bool) *ListVisibleDnszonesOptions {
+	options.IncludeResourceRecords = core.BoolPtr(includeResourceRecords)
+	return options
+}
+
+// VisibleDnszone : A DNS zone visible from a VPC.
+type VisibleDnszone struct {
+	// The DNS zone.
+	Dnszone *Dnszone `json:"dnszone"`
+
+	// The permitted network of the DNS zone for the VPC.
+	PermittedNetwork *PermittedNetwork `json:"permitted_network"`
+
+	// Whether names of the DNS zone resolve from the VPC: the DNS zone is active and the permitted network ACTIVE.
+	Resolvable bool `json:"resolvable"`
+
+	// The resource records of the DNS zone, when asked for.
+	ResourceRecords []ResourceRecord `json:"resource_records,omitempty"`
+}
+
+// ListVisibleDnszones : List the DNS zones visible from a VPC
+// List the DNS zones of a given service instance that have the VPC as a permitted network, each with its permitted
+// network entry and, optionally, its resource records. Resolvable tells whether the names of a DNS zone resolve from
+// the VPC, which is not the case while the DNS zone is disabled or the permitted network is being removed.
+func (dnsSvcs *DnsSvcsV1) ListVisibleDnszones(listVisibleDnszonesOptions *ListVisibleDnszonesOptions) (visible []VisibleDnszone, err error) {
+	err = core.ValidateNotNil(listVisibleDnszonesOptions, "listVisibleDnszonesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(listVisibleDnszonesOptions, "listVisibleDnszonesOptions")
+	if err != nil {
+		return
+	}
+	options := listVisibleDnszonesOptions
+	if (options.VpcCrn == nil) == (options.VpcID == nil) {
+		err = fmt.Errorf("exactly one of VpcCrn and VpcID is required")
+		return
+	}
+	instanceID := *options.InstanceID
+	vpcCrn, vpcID := "", stringValue(options.VpcID, "")
+	if options.VpcCrn != nil {
+		var parsed *common.Crn
+		parsed, err = common.ParseVpcCrn(*options.VpcCrn)
+		if err != nil {
+			return
+		}
+		vpcCrn, vpcID = parsed.String(), parsed.ResourceID
+	}
+	if vpcID == "" {
+		err = fmt.Errorf("the VPC ID is empty")
+		return
+	}
+
+	dnszones, err := dnsSvcs.ListAllDnszones(dnsSvcs.NewListDnszonesOptions(instanceID).SetVpcID(vpcID))
+	if err != nil {
+		return
+	}
+	visible = []VisibleDnszone{}
+	for i := range dnszones {
+		dnszone := &dnszones[i]
+		dnszoneID := stringValue(dnszone.ID, "")
+		var networks []PermittedNetwork
+		networks, err = dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(instanceID, dnszoneID))
+		if err != nil {
+			err = fmt.Errorf("cannot list the permitted networks of DNS zone %s: %s", stringValue(dnszone.Name, dnszoneID), err.Error())
+			return
+		}
+		// The service filters DNS zones by VPC ID only, so the permitted network is matched on the whole CRN when known.
+		var network *PermittedNetwork
+		for j := range networks {
+			parsed, parseErr := common.ParseVpcCrn(permittedNetworkVpcCrn(&networks[j]))
+			if parseErr == nil && parsed.ResourceID == vpcID && (vpcCrn == "" || parsed.String() == vpcCrn) {
+				network = &networks[j]
+				break
+			}
+		}
+		if network == nil {
+			continue
+		}
+		entry := VisibleDnszone{
+			Dnszone:          dnszone,
+			PermittedNetwork: network,
+			Resolvable:       stringValue(dnszone.State, "") == Dnszone_State_Active && stringValue(network.State, "") == PermittedNetwork_State_Active,
+		}
+		if options.IncludeResourceRecords != nil && *options.IncludeResourceRecords {
+			entry.ResourceRecords, err = dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(instanceID, dnszoneID))
+			if err != nil {
+				err = fmt.Errorf("cannot list the resource records of DNS zone %s: %s", stringValue(dnszone.Name, dnszoneID), err.Error())
+				return
+			}
+		}
+		visible = append(visible, entry)
+	}
+	return
+}
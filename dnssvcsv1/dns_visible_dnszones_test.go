@@ -0,0 +1,90 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`ListVisibleDnszones`, func() {
+	const (
+		vpcCrn      = "crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-5ab1a3b0"
+		otherVpcCrn = "crn:v1:bluemix:public:is:us-south:a/other::vpc:r006-5ab1a3b0"
+	)
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		testService = fake.Service()
+		for _, zone := range []map[string]interface{}{
+			{"id": "active", "name": "example.com", "state": "active"},
+			{"id": "disabled", "name": "example.org", "state": "disabled"},
+			{"id": "other", "name": "example.net", "state": "active"},
+			{"id": "none", "name": "example.info", "state": "pending_network_add"},
+		} {
+			fake.Add("/instances/i/dnszones", zone)
+		}
+		fake.Add("/instances/i/dnszones/active/permitted_networks", map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcCrn}})
+		fake.Add("/instances/i/dnszones/disabled/permitted_networks", map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcCrn}})
+		fake.Add("/instances/i/dnszones/other/permitted_networks", map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": otherVpcCrn}})
+		fake.Add("/instances/i/dnszones/active/resource_records", map[string]interface{}{"type": "A", "name": "www.example.com", "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke ListVisibleDnszones successfully`, func() {
+		visible, err := testService.ListVisibleDnszones(testService.NewListVisibleDnszonesOptions("i", vpcCrn).SetIncludeResourceRecords(true))
+		Expect(err).To(BeNil())
+		Expect(visible).To(HaveLen(2))
+		Expect(*visible[0].Dnszone.Name).To(Equal("example.com"))
+		Expect(*visible[0].PermittedNetwork.PermittedNetwork.VpcCrn).To(Equal(vpcCrn))
+		Expect(visible[0].Resolvable).To(BeTrue())
+		Expect(visible[0].ResourceRecords).To(HaveLen(1))
+		Expect(*visible[1].Dnszone.Name).To(Equal("example.org"))
+		Expect(visible[1].Resolvable).To(BeFalse())
+		Expect(fake.Requests).To(ContainElement("GET /instances/i/dnszones/active/resource_records"))
+
+		visible, err = testService.ListVisibleDnszones(testService.NewListVisibleDnszonesOptions("i", "r006-5ab1a3b0"))
+		Expect(err).To(BeNil())
+		Expect(visible).To(HaveLen(3))
+		Expect(visible[0].ResourceRecords).To(BeNil())
+
+		visible, err = testService.ListVisibleDnszones(testService.NewListVisibleDnszonesOptions("i", "r006-missing"))
+		Expect(err).To(BeNil())
+		Expect(visible).To(BeEmpty())
+	})
+	It(`Invoke ListVisibleDnszones with error`, func() {
+		_, err := testService.ListVisibleDnszones(testService.NewListVisibleDnszonesOptions("i", "crn:v1:bluemix:public:is:us-south-1:a/account::subnet:0716-a4c0c123"))
+		Expect(err).ToNot(BeNil())
+		_, err = testService.ListVisibleDnszones(testService.NewListVisibleDnszonesOptions("i", ""))
+		Expect(err).ToNot(BeNil())
+		options := testService.NewListVisibleDnszonesOptions("i", vpcCrn)
+		options.VpcID = options.VpcCrn
+		_, err = testService.ListVisibleDnszones(options)
+		Expect(err).ToNot(BeNil())
+
+		fake.Failures["GET /instances/i/dnszones/disabled/permitted_networks"] = 500
+		_, err = testService.ListVisibleDnszones(testService.NewListVisibleDnszonesOptions("i", vpcCrn))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("cannot list the permitted networks of DNS zone example.org"))
+		_, err = testService.ListVisibleDnszones(nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,316 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// CloneDnszoneOptions : The CloneDnszone options.
+type CloneDnszoneOptions struct {
+	// The unique identifier of the service instance holding the source DNS zone.
+	SourceInstanceID *string `json:"source_instance_id" validate:"required"`
+
+	// The unique identifier of the source DNS zone.
+	SourceDnszoneID *string `json:"source_dnszone_id" validate:"required"`
+
+	// The unique identifier of the service instance to create the DNS zone in.
+	TargetInstanceID *string `json:"target_instance_id" validate:"required"`
+
+	// Name of the target DNS zone, the default value is the name of the source DNS zone. Resource record names are
+	// moved under the new name; rdata is copied unchanged.
+	TargetName *string `json:"target_name,omitempty"`
+
+	// Copy the permitted networks of the source DNS zone as well.
+	CopyPermittedNetworks *bool `json:"copy_permitted_networks,omitempty"`
+
+	// Called after every step of the workflow.
+	Progress func(CloneDnszoneProgress) `json:"-"`
+}
+
+// NewCloneDnszoneOptions : Instantiate CloneDnszoneOptions
+func (*DnsSvcsV1) NewCloneDnszoneOptions(sourceInstanceID string, sourceDnszoneID string, targetInstanceID string) *CloneDnszoneOptions {
+	return &CloneDnszoneOptions{
+		SourceInstanceID: core.StringPtr(sourceInstanceID),
+		SourceDnszoneID:  core.StringPtr(sourceDnszoneID),
+		TargetInstanceID: core.StringPtr(targetInstanceID),
+	}
+}
+
+// SetTargetName : Allow user to set TargetName
+func (options *CloneDnszoneOptions) SetTargetName(targetName string) *CloneDnszoneOptions {
+	options.TargetName = core.StringPtr(targetName)
+	return options
+}
+
+// SetCopyPermittedNetworks : Allow user to set CopyPermittedNetworks
+func (options *CloneDnszoneOptions) SetCopyPermittedNetworks(copyPermittedNetworks bool) *CloneDnszoneOptions {
+	options.CopyPermittedNetworks = core.BoolPtr(copyPermittedNetworks)
+	return options
+}
+
+// SetProgress : Allow user to set Progress
+func (options *CloneDnszoneOptions) SetProgress(progress func(CloneDnszoneProgress)) *CloneDnszoneOptions {
+	options.Progress = progress
+	return options
+}
+
+// CloneDnszoneProgress : A step of the CloneDnszone workflow.
+type CloneDnszoneProgress struct {
+	// The kind of object the step copied.
+	Step string
+
+	// Name of the object copied: the DNS zone name, "<type> <name>" of a resource record, or the VPC CRN of a
+	// permitted network.
+	Name string
+
+	// Number of objects of this kind handled so far, including this one.
+	Done int
+
+	// Number of objects of this kind to handle.
+	Total int
+
+	// Whether the object already existed in the target, for example when resuming a clone, or cannot be copied.
+	Skipped bool
+
+	// The error copying the object.
+	Err error
+}
+
+// Constants associated with the CloneDnszoneProgress.Step property.
+// The kind of object the step copied.
+const (
+	CloneDnszoneProgress_Step_Dnszone          = "dnszone"
+	CloneDnszoneProgress_Step_PermittedNetwork = "permitted_network"
+	CloneDnszoneProgress_Step_ResourceRecord   = "resource_record"
+)
+
+// CloneDnszoneResult : Outcome of the CloneDnszone workflow.
+type CloneDnszoneResult struct {
+	// The target DNS zone.
+	Dnszone *Dnszone
+
+	// Number of resource records created.
+	ResourceRecordsCopied int
+
+	// Number of resource records already present in the target, or of a type that cannot be created.
+	ResourceRecordsSkipped int
+
+	// Number of permitted networks created.
+	PermittedNetworksCopied int
+
+	// Number of permitted networks already present in the target.
+	PermittedNetworksSkipped int
+
+	// The objects that failed to copy.
+	Failures []CloneDnszoneProgress
+}
+
+// CloneDnszone : Clone a DNS zone
+// Copy a DNS zone with its description, label and resource records, and optionally its permitted networks, into a
+// service instance. The target DNS zone is looked up by name first and objects already present in it are skipped, so
+// running the workflow again resumes a clone that failed part way. An error is returned when any object failed to
+// copy; the result then lists the failures.
+func (dnsSvcs *DnsSvcsV1) CloneDnszone(cloneDnszoneOptions *CloneDnszoneOptions) (result *CloneDnszoneResult, err error) {
+	err = core.ValidateNotNil(cloneDnszoneOptions, "cloneDnszoneOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(cloneDnszoneOptions, "cloneDnszoneOptions")
+	if err != nil {
+		return
+	}
+	options := cloneDnszoneOptions
+	progress := func(step CloneDnszoneProgress) {
+		if step.Err != nil {
+			result.Failures = append(result.Failures, step)
+		}
+		if options.Progress != nil {
+			options.Progress(step)
+		}
+	}
+
+	source, _, err := dnsSvcs.GetDnszone(dnsSvcs.NewGetDnszoneOptions(*options.SourceInstanceID, *options.SourceDnszoneID))
+	if err != nil {
+		return
+	}
+	targetName := *source.Name
+	if options.TargetName != nil {
+		targetName = *options.TargetName
+	}
+
+	result = &CloneDnszoneResult{}
+	result.Dnszone, err = dnsSvcs.findOrCreateDnszone(*options.TargetInstanceID, targetName, source)
+	progress(CloneDnszoneProgress{Step: CloneDnszoneProgress_Step_Dnszone, Name: targetName, Done: 1, Total: 1, Err: err})
+	if err != nil {
+		result.Dnszone = nil
+		return
+	}
+	targetInstanceID, targetDnszoneID := *options.TargetInstanceID, *result.Dnszone.ID
+
+	sourceRecords, err := dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(*options.SourceInstanceID, *options.SourceDnszoneID))
+	if err != nil {
+		return
+	}
+	targetRecords, err := dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(targetInstanceID, targetDnszoneID))
+	if err != nil {
+		return
+	}
+	existing := make(map[string]bool)
+	for i := range targetRecords {
+		existing[resourceRecordKey(&targetRecords[i], targetName, targetName)] = true
+	}
+	for i := range sourceRecords {
+		record := sourceRecords[i]
+		step := CloneDnszoneProgress{
+			Step:  CloneDnszoneProgress_Step_ResourceRecord,
+			Done:  i + 1,
+			Total: len(sourceRecords),
+		}
+		if record.Type != nil && record.Name != nil {
+			step.Name = *record.Type + " " + *record.Name
+		}
+		if !isCreatableRecordType(record.Type) || existing[resourceRecordKey(&record, *source.Name, targetName)] {
+			step.Skipped = true
+			result.ResourceRecordsSkipped++
+			progress(step)
+			continue
+		}
+		record.Name = core.StringPtr(moveDomainName(*record.Name, *source.Name, targetName))
+		var createOptions *CreateResourceRecordOptions
+		createOptions, step.Err = newCreateResourceRecordOptionsFromRecord(targetInstanceID, targetDnszoneID, &record)
+		if step.Err == nil {
+			_, _, step.Err = dnsSvcs.CreateResourceRecord(createOptions)
+		}
+		if step.Err == nil {
+			result.ResourceRecordsCopied++
+		}
+		progress(step)
+	}
+
+	if options.CopyPermittedNetworks != nil && *options.CopyPermittedNetworks {
+		err = dnsSvcs.clonePermittedNetworks(*options.SourceInstanceID, *options.SourceDnszoneID, targetInstanceID, targetDnszoneID, result, progress)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		err = fmt.Errorf("%d objects failed to copy to DNS zone %s, the first: %s", len(result.Failures), targetDnszoneID, result.Failures[0].Err.Error())
+	}
+	return
+}
+
+func (dnsSvcs *DnsSvcsV1) findOrCreateDnszone(instanceID string, name string, source *Dnszone) (dnszone *Dnszone, err error) {
+	dnszone, err = dnsSvcs.findDnszone(instanceID, name)
+	if err != nil || dnszone != nil {
+		return
+	}
+	createOptions := dnsSvcs.NewCreateDnszoneOptions(instanceID, name)
+	createOptions.Description = source.Description
+	createOptions.Label = source.Label
+	dnszone, _, err = dnsSvcs.CreateDnszone(createOptions)
+	return
+}
+
+func (dnsSvcs *DnsSvcsV1) clonePermittedNetworks(sourceInstanceID string, sourceDnszoneID string, targetInstanceID string, targetDnszoneID string, result *CloneDnszoneResult, progress func(CloneDnszoneProgress)) (err error) {
+	sourceNetworks, err := dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(sourceInstanceID, sourceDnszoneID))
+	if err != nil {
+		return
+	}
+	targetNetworks, err := dnsSvcs.ListAllPermittedNetworks(dnsSvcs.NewListPermittedNetworksOptions(targetInstanceID, targetDnszoneID))
+	if err != nil {
+		return
+	}
+	existing := make(map[string]bool)
+	for _, network := range targetNetworks {
+		if network.PermittedNetwork != nil && network.PermittedNetwork.VpcCrn != nil {
+			existing[*network.PermittedNetwork.VpcCrn] = true
+		}
+	}
+	for i, network := range sourceNetworks {
+		if network.PermittedNetwork == nil || network.PermittedNetwork.VpcCrn == nil {
+			continue
+		}
+		step := CloneDnszoneProgress{
+			Step:  CloneDnszoneProgress_Step_PermittedNetwork,
+			Name:  *network.PermittedNetwork.VpcCrn,
+			Done:  i + 1,
+			Total: len(sourceNetworks),
+		}
+		if existing[step.Name] {
+			step.Skipped = true
+			result.PermittedNetworksSkipped++
+			progress(step)
+			continue
+		}
+		createOptions := dnsSvcs.NewCreatePermittedNetworkOptions(targetInstanceID, targetDnszoneID)
+		createOptions.SetType(CreatePermittedNetworkOptions_Type_Vpc)
+		createOptions.SetPermittedNetwork(&PermittedNetworkVpc{VpcCrn: network.PermittedNetwork.VpcCrn})
+		_, _, step.Err = dnsSvcs.CreatePermittedNetwork(createOptions)
+		if step.Err == nil {
+			result.PermittedNetworksCopied++
+		}
+		progress(step)
+	}
+	return
+}
+
+// isCreatableRecordType reports whether resource records of a type can be created through CreateResourceRecord.
+// Records such as SOA and NS are managed by the service.
+func isCreatableRecordType(recordType *string) bool {
+	if recordType == nil {
+		return false
+	}
+	switch *recordType {
+	case CreateResourceRecordOptions_Type_A, CreateResourceRecordOptions_Type_Aaaa, CreateResourceRecordOptions_Type_Caa,
+		CreateResourceRecordOptions_Type_Cname, CreateResourceRecordOptions_Type_Mx, CreateResourceRecordOptions_Type_Ptr,
+		CreateResourceRecordOptions_Type_Srv, CreateResourceRecordOptions_Type_Txt:
+		return true
+	}
+	return false
+}
+
+// resourceRecordKey identifies a resource record by type, name and rdata, with the name moved from zoneName to
+// targetName.
+func resourceRecordKey(record *ResourceRecord, zoneName string, targetName string) string {
+	key := ""
+	if record.Type != nil {
+		key = *record.Type
+	}
+	if record.Name != nil {
+		key += " " + moveDomainName(*record.Name, zoneName, targetName)
+	}
+	rdata, _ := json.Marshal(record.Rdata)
+	return key + " " + strings.ToLower(string(rdata))
+}
+
+// moveDomainName replaces the zoneName suffix of a domain name with targetName.
+func moveDomainName(name string, zoneName string, targetName string) string {
+	name, zoneName, targetName = normalizeDomainName(name), normalizeDomainName(zoneName), normalizeDomainName(targetName)
+	if name == zoneName {
+		return targetName
+	}
+	if strings.HasSuffix(name, "."+zoneName) {
+		return strings.TrimSuffix(name, zoneName) + targetName
+	}
+	return name
+}
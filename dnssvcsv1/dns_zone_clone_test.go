@@ -0,0 +1,93 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"net/http"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CloneDnszone(cloneDnszoneOptions *CloneDnszoneOptions)`, func() {
+	var fake *fakednssvcs.Server
+	var testService *dnssvcsv1.DnsSvcsV1
+	sourceRecords := "/instances/staging/dnszones/source/resource_records"
+	vpcCrn := "crn:v1:bluemix:public:is:us-south:a/bcf1865e99742d38d2d5fc3fb80a5496::vpc:r006-5ab1a3b0"
+	BeforeEach(func() {
+		fake = fakednssvcs.NewServer()
+		fake.Add("/instances/staging/dnszones", map[string]interface{}{"id": "source", "name": "example.com", "description": "staging zone", "label": "us-south"})
+		fake.Add(sourceRecords, map[string]interface{}{"name": "example.com", "type": "SOA", "rdata": map[string]interface{}{"mname": "ns1.example.com"}})
+		fake.Add(sourceRecords, map[string]interface{}{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+		fake.Add(sourceRecords, map[string]interface{}{"name": "api.example.com", "type": "CNAME", "ttl": 300, "rdata": map[string]interface{}{"cname": "www.example.com"}})
+		fake.Add(sourceRecords, map[string]interface{}{"name": "_sip._udp.voip.example.com", "type": "SRV", "service": "_sip", "protocol": "udp", "rdata": map[string]interface{}{"port": 80, "priority": 10, "target": "www.example.com", "weight": 10}})
+		fake.Add("/instances/staging/dnszones/source/permitted_networks", map[string]interface{}{"type": "vpc", "state": "ACTIVE", "permitted_network": map[string]interface{}{"vpc_crn": vpcCrn}})
+		testService = fake.Service()
+	})
+	AfterEach(func() {
+		fake.Close()
+	})
+	It(`Invoke CloneDnszone successfully and resume after a failure`, func() {
+		fake.Failures["POST /instances/prod/dnszones/*/permitted_networks"] = http.StatusInternalServerError
+
+		steps := []dnssvcsv1.CloneDnszoneProgress{}
+		cloneOptions := testService.NewCloneDnszoneOptions("staging", "source", "prod").SetCopyPermittedNetworks(true)
+		cloneOptions.SetProgress(func(step dnssvcsv1.CloneDnszoneProgress) {
+			steps = append(steps, step)
+		})
+		result, err := testService.CloneDnszone(cloneOptions)
+		Expect(err).ToNot(BeNil())
+		Expect(*result.Dnszone.Description).To(Equal("staging zone"))
+		Expect(*result.Dnszone.Label).To(Equal("us-south"))
+		Expect(result.ResourceRecordsCopied).To(Equal(3))
+		Expect(result.ResourceRecordsSkipped).To(Equal(1))
+		Expect(result.Failures).To(HaveLen(1))
+		Expect(result.Failures[0].Step).To(Equal(dnssvcsv1.CloneDnszoneProgress_Step_PermittedNetwork))
+		Expect(steps).To(HaveLen(6))
+		Expect(steps[0].Step).To(Equal(dnssvcsv1.CloneDnszoneProgress_Step_Dnszone))
+
+		targetRecords := "/instances/prod/dnszones/" + *result.Dnszone.ID + "/resource_records"
+		Expect(fake.SortedNames(targetRecords)).To(Equal([]string{"_sip._udp.voip.example.com", "api.example.com", "www.example.com"}))
+
+		delete(fake.Failures, "POST /instances/prod/dnszones/*/permitted_networks")
+		result, err = testService.CloneDnszone(cloneOptions.SetProgress(nil))
+		Expect(err).To(BeNil())
+		Expect(result.ResourceRecordsCopied).To(Equal(0))
+		Expect(result.ResourceRecordsSkipped).To(Equal(4))
+		Expect(result.PermittedNetworksCopied).To(Equal(1))
+		Expect(fake.List("/instances/prod/dnszones")).To(HaveLen(1))
+		Expect(fake.List(targetRecords)).To(HaveLen(3))
+	})
+	It(`Invoke CloneDnszone under a new name`, func() {
+		result, err := testService.CloneDnszone(testService.NewCloneDnszoneOptions("staging", "source", "prod").SetTargetName("example.org"))
+		Expect(err).To(BeNil())
+		Expect(*result.Dnszone.Name).To(Equal("example.org"))
+		Expect(result.PermittedNetworksCopied).To(Equal(0))
+		targetRecords := "/instances/prod/dnszones/" + *result.Dnszone.ID + "/resource_records"
+		Expect(fake.SortedNames(targetRecords)).To(Equal([]string{"_sip._udp.voip.example.org", "api.example.org", "www.example.org"}))
+	})
+	It(`Invoke CloneDnszone with error: Operation validation`, func() {
+		result, err := testService.CloneDnszone(nil)
+		Expect(err).ToNot(BeNil())
+		Expect(result).To(BeNil())
+		result, err = testService.CloneDnszone(new(dnssvcsv1.CloneDnszoneOptions))
+		Expect(err).ToNot(BeNil())
+		Expect(result).To(BeNil())
+	})
+})
@@ -0,0 +1,817 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"gopkg.in/yaml.v2"
+)
+
+// Constants for the formats of zone files.
+const (
+	ZoneFileFormat_Bind = "bind"
+	ZoneFileFormat_Json = "json"
+	ZoneFileFormat_Yaml = "yaml"
+)
+
+// ZoneFile : The resource records of a DNS zone kept outside the service, for example in Git. Only the types of
+// resource records that can be created through CreateResourceRecord are kept; SOA and NS records are managed by the
+// service.
+type ZoneFile struct {
+	// Name of the DNS zone.
+	Origin string `json:"origin" yaml:"origin"`
+
+	// TTL of the resource records that do not set one, 0 for the default of the service.
+	TTL int64 `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// The resource records.
+	Records []ZoneFileRecord `json:"records" yaml:"records"`
+}
+
+// ZoneFileRecord : A resource record of a zone file.
+type ZoneFileRecord struct {
+	// Identifier of the resource record in the service, for records read from a live DNS zone. It is not written to
+	// zone files.
+	ID string `json:"-" yaml:"-"`
+
+	// Fully qualified name of the resource record, without the trailing dot. SRV names start with the service and
+	// protocol.
+	Name string `json:"name" yaml:"name"`
+
+	// Type of the resource record.
+	Type string `json:"type" yaml:"type"`
+
+	// Time to live in second, 0 for the TTL of the zone file.
+	TTL int64 `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// Content of the resource record, with the properties of ResourceRecordInputRdata.
+	Rdata map[string]interface{} `json:"rdata" yaml:"rdata"`
+
+	// Only used for SRV records.
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+
+	// Only used for SRV records.
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+}
+
+// ZoneDiff : The differences between the resource records a zone file wants and those of a live DNS zone.
+type ZoneDiff struct {
+	// Resource records of the zone file missing from the DNS zone.
+	Missing []ZoneFileRecord `json:"missing"`
+
+	// Resource records of the DNS zone the zone file does not list.
+	Extra []ZoneFileRecord `json:"extra"`
+
+	// Resource records present in both with a different TTL or rdata.
+	Changed []ZoneRecordChange `json:"changed"`
+}
+
+// ZoneRecordChange : A resource record whose TTL or rdata differs between a zone file and a live DNS zone.
+type ZoneRecordChange struct {
+	// The resource record as the zone file wants it.
+	Desired ZoneFileRecord `json:"desired"`
+
+	// The resource record as it is in the DNS zone.
+	Live ZoneFileRecord `json:"live"`
+
+	// The properties that differ, "ttl" and "rdata".
+	Properties []string `json:"properties"`
+}
+
+// ImportZoneFileOptions : The ImportZoneFile options.
+type ImportZoneFileOptions struct {
+	// The unique identifier of a service instance.
+	InstanceID *string `json:"instance_id" validate:"required"`
+
+	// The unique identifier of a DNS zone.
+	DnszoneID *string `json:"dnszone_id" validate:"required"`
+
+	// The resource records wanted. Its origin must be the name of the DNS zone, or empty.
+	ZoneFile *ZoneFile `json:"zone_file" validate:"required"`
+
+	// Delete the resource records of the DNS zone the zone file does not list.
+	Prune *bool `json:"prune,omitempty"`
+
+	// Compute the differences without changing the DNS zone.
+	DryRun *bool `json:"dry_run,omitempty"`
+}
+
+// NewImportZoneFileOptions : Instantiate ImportZoneFileOptions
+func (*DnsSvcsV1) NewImportZoneFileOptions(instanceID string, dnszoneID string, zoneFile *ZoneFile) *ImportZoneFileOptions {
+	return &ImportZoneFileOptions{
+		InstanceID: core.StringPtr(instanceID),
+		DnszoneID:  core.StringPtr(dnszoneID),
+		ZoneFile:   zoneFile,
+	}
+}
+
+// SetPrune : Allow user to set Prune
+func (options *ImportZoneFileOptions) SetPrune(prune bool) *ImportZoneFileOptions {
+	options.Prune = core.BoolPtr(prune)
+	return options
+}
+
+// SetDryRun : Allow user to set DryRun
+func (options *ImportZoneFileOptions) SetDryRun(dryRun bool) *ImportZoneFileOptions {
+	options.DryRun = core.BoolPtr(dryRun)
+	return options
+}
+
+// ImportZoneFileResult : The result of ImportZoneFile.
+type ImportZoneFileResult struct {
+	// The differences found before the import. Extra resource records are only deleted when pruning.
+	Diff *ZoneDiff `json:"diff"`
+
+	// Resource records created.
+	Created []ResourceRecord `json:"created"`
+
+	// Resource records updated.
+	Updated []ResourceRecord `json:"updated"`
+
+	// Resource records deleted, as they were before.
+	Deleted []ZoneFileRecord `json:"deleted"`
+}
+
+// ExportZoneFile : Export the resource records of a DNS zone
+// Read a DNS zone and its resource records into a zone file. The records are sorted by name, type and rdata so that
+// exports of the same content are identical.
+func (dnsSvcs *DnsSvcsV1) ExportZoneFile(instanceID string, dnszoneID string) (zoneFile *ZoneFile, err error) {
+	dnszone, _, err := dnsSvcs.GetDnszone(dnsSvcs.NewGetDnszoneOptions(instanceID, dnszoneID))
+	if err != nil {
+		return
+	}
+	records, err := dnsSvcs.ListAllResourceRecords(dnsSvcs.NewListResourceRecordsOptions(instanceID, dnszoneID))
+	if err != nil {
+		return
+	}
+	return NewZoneFile(stringValue(dnszone.Name, ""), records)
+}
+
+// NewZoneFile returns the zone file of resource records of a DNS zone, skipping the types managed by the service.
+func NewZoneFile(origin string, records []ResourceRecord) (zoneFile *ZoneFile, err error) {
+	zoneFile = &ZoneFile{Origin: normalizeDomainName(origin), Records: []ZoneFileRecord{}}
+	for i := range records {
+		if !isCreatableRecordType(records[i].Type) {
+			continue
+		}
+		record := ZoneFileRecord{
+			ID:       stringValue(records[i].ID, ""),
+			Name:     normalizeDomainName(stringValue(records[i].Name, "")),
+			Type:     strings.ToUpper(stringValue(records[i].Type, "")),
+			TTL:      int64Value(records[i].TTL, 0),
+			Service:  stringValue(records[i].Service, ""),
+			Protocol: stringValue(records[i].Protocol, ""),
+		}
+		err = convertOptions(records[i].Rdata, &record.Rdata)
+		if err != nil {
+			return nil, err
+		}
+		zoneFile.Records = append(zoneFile.Records, record)
+	}
+	zoneFile.sort()
+	return
+}
+
+// ImportZoneFile : Import a zone file into a DNS zone
+// Create the resource records of a zone file missing from a DNS zone and update those whose TTL or rdata differ.
+// With Prune, resource records the zone file does not list are deleted. The import stops at the first failure; the
+// result then holds the changes made so far.
+func (dnsSvcs *DnsSvcsV1) ImportZoneFile(importZoneFileOptions *ImportZoneFileOptions) (result *ImportZoneFileResult, err error) {
+	err = core.ValidateNotNil(importZoneFileOptions, "importZoneFileOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(importZoneFileOptions, "importZoneFileOptions")
+	if err != nil {
+		return
+	}
+	instanceID, dnszoneID := *importZoneFileOptions.InstanceID, *importZoneFileOptions.DnszoneID
+	live, err := dnsSvcs.ExportZoneFile(instanceID, dnszoneID)
+	if err != nil {
+		return
+	}
+	desired := importZoneFileOptions.ZoneFile
+	if desired.Origin != "" && normalizeDomainName(desired.Origin) != live.Origin {
+		err = fmt.Errorf("zone file is for %s, not DNS zone %s", desired.Origin, live.Origin)
+		return
+	}
+
+	result = &ImportZoneFileResult{Diff: DiffZoneFiles(desired, live)}
+	if importZoneFileOptions.DryRun != nil && *importZoneFileOptions.DryRun {
+		return
+	}
+	for _, record := range result.Diff.Missing {
+		var createOptions *CreateResourceRecordOptions
+		createOptions, err = newCreateResourceRecordOptionsFromRecord(instanceID, dnszoneID, record.resourceRecord(desired.TTL))
+		if err != nil {
+			return
+		}
+		var created *ResourceRecord
+		created, _, err = dnsSvcs.CreateResourceRecord(createOptions)
+		if err != nil {
+			return
+		}
+		result.Created = append(result.Created, *created)
+	}
+	for _, change := range result.Diff.Changed {
+		record := change.Desired.resourceRecord(desired.TTL)
+		record.ID = core.StringPtr(change.Live.ID)
+		var updateOptions *UpdateResourceRecordOptions
+		updateOptions, err = newUpdateResourceRecordOptionsFromRecord(instanceID, dnszoneID, record)
+		if err != nil {
+			return
+		}
+		var updated *ResourceRecord
+		updated, _, err = dnsSvcs.UpdateResourceRecord(updateOptions)
+		if err != nil {
+			return
+		}
+		result.Updated = append(result.Updated, *updated)
+	}
+	if importZoneFileOptions.Prune == nil || !*importZoneFileOptions.Prune {
+		return
+	}
+	for _, record := range result.Diff.Extra {
+		_, err = dnsSvcs.DeleteResourceRecord(dnsSvcs.NewDeleteResourceRecordOptions(instanceID, dnszoneID, record.ID))
+		if err != nil {
+			return
+		}
+		result.Deleted = append(result.Deleted, record)
+	}
+	return
+}
+
+// DiffZoneFiles compares the resource records a zone file wants with those of a live DNS zone. Records match by
+// type, name and rdata; a desired record without a TTL, in a zone file without one either, matches any TTL. Records
+// left over with the same type and name on both sides are paired as changed, in order.
+func DiffZoneFiles(desired *ZoneFile, live *ZoneFile) *ZoneDiff {
+	diff := &ZoneDiff{Missing: []ZoneFileRecord{}, Extra: []ZoneFileRecord{}, Changed: []ZoneRecordChange{}}
+	used := make([]bool, len(live.Records))
+	ttlChanged := func(record ZoneFileRecord, liveRecord ZoneFileRecord) bool {
+		if liveRecord.TTL == 0 {
+			liveRecord.TTL = live.TTL
+		}
+		return record.TTL != 0 && record.TTL != liveRecord.TTL
+	}
+	var unmatched []ZoneFileRecord
+	for _, record := range desired.Records {
+		record = record.qualified(desired.Origin, live.Origin)
+		if record.TTL == 0 {
+			record.TTL = desired.TTL
+		}
+		matched := false
+		for i, liveRecord := range live.Records {
+			if !used[i] && liveRecord.key() == record.key() {
+				used[i], matched = true, true
+				if ttlChanged(record, liveRecord) {
+					diff.Changed = append(diff.Changed, ZoneRecordChange{Desired: record, Live: liveRecord, Properties: []string{"ttl"}})
+				}
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, record)
+		}
+	}
+	for _, record := range unmatched {
+		matched := false
+		for i, liveRecord := range live.Records {
+			if !used[i] && liveRecord.Type == record.Type && liveRecord.Name == record.Name {
+				used[i], matched = true, true
+				change := ZoneRecordChange{Desired: record, Live: liveRecord, Properties: []string{"rdata"}}
+				if ttlChanged(record, liveRecord) {
+					change.Properties = []string{"ttl", "rdata"}
+				}
+				diff.Changed = append(diff.Changed, change)
+				break
+			}
+		}
+		if !matched {
+			diff.Missing = append(diff.Missing, record)
+		}
+	}
+	for i, liveRecord := range live.Records {
+		if !used[i] {
+			diff.Extra = append(diff.Extra, liveRecord)
+		}
+	}
+	return diff
+}
+
+// HasDrift reports whether the DNS zone differs from the zone file.
+func (diff *ZoneDiff) HasDrift() bool {
+	return len(diff.Missing) > 0 || len(diff.Extra) > 0 || len(diff.Changed) > 0
+}
+
+// String returns the differences one per line: "+" for missing resource records, "-" for extra ones and "~" for
+// changed ones followed by their live version.
+func (diff *ZoneDiff) String() string {
+	var lines []string
+	for _, record := range diff.Missing {
+		lines = append(lines, "+ "+record.String())
+	}
+	for _, record := range diff.Extra {
+		lines = append(lines, "- "+record.String())
+	}
+	for _, change := range diff.Changed {
+		lines = append(lines, "~ "+change.Desired.String()+" (was "+change.Live.String()+")")
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// String returns the resource record as a line of a BIND zone file with a fully qualified name.
+func (record ZoneFileRecord) String() string {
+	line := record.Name + "."
+	if record.TTL != 0 {
+		line += " " + strconv.FormatInt(record.TTL, 10)
+	}
+	return line + " IN " + record.Type + " " + formatBindRdata(record.Type, record.Rdata)
+}
+
+// ParseZoneFile parses a zone file in a format. BIND zone files are read with origin as the initial $ORIGIN; JSON and
+// YAML ones take it as their origin when they set none. Names not ending in the origin are relative to it.
+func ParseZoneFile(data []byte, format string, origin string) (zoneFile *ZoneFile, err error) {
+	switch format {
+	case ZoneFileFormat_Bind:
+		zoneFile, err = parseBindZoneFile(data, origin)
+	case ZoneFileFormat_Json:
+		zoneFile = new(ZoneFile)
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(zoneFile)
+	case ZoneFileFormat_Yaml:
+		zoneFile = new(ZoneFile)
+		err = yaml.UnmarshalStrict(data, zoneFile)
+	default:
+		err = fmt.Errorf("unknown zone file format %q", format)
+		return
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone file: %s", err.Error())
+	}
+	if zoneFile.Origin == "" {
+		zoneFile.Origin = origin
+	}
+	zoneFile.Origin = normalizeDomainName(zoneFile.Origin)
+	for i, record := range zoneFile.Records {
+		record.Type = strings.ToUpper(record.Type)
+		if !isCreatableRecordType(&record.Type) {
+			return nil, fmt.Errorf("invalid zone file: record %s has unsupported type %q", record.Name, record.Type)
+		}
+		zoneFile.Records[i] = record.qualified(zoneFile.Origin, zoneFile.Origin)
+	}
+	return
+}
+
+// Format returns the zone file in a format. BIND zone files name the resource records relative to the origin.
+func (zoneFile *ZoneFile) Format(format string) ([]byte, error) {
+	switch format {
+	case ZoneFileFormat_Bind:
+		return zoneFile.formatBind(), nil
+	case ZoneFileFormat_Json:
+		data, err := json.MarshalIndent(zoneFile, "", "  ")
+		return append(data, '\n'), err
+	case ZoneFileFormat_Yaml:
+		return yaml.Marshal(zoneFile)
+	}
+	return nil, fmt.Errorf("unknown zone file format %q", format)
+}
+
+func (zoneFile *ZoneFile) sort() {
+	sort.SliceStable(zoneFile.Records, func(i, j int) bool {
+		a, b := zoneFile.Records[i], zoneFile.Records[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.key() < b.key()
+	})
+}
+
+func (zoneFile *ZoneFile) formatBind() []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "$ORIGIN %s.\n", zoneFile.Origin)
+	if zoneFile.TTL != 0 {
+		fmt.Fprintf(&buffer, "$TTL %d\n", zoneFile.TTL)
+	}
+	for _, record := range zoneFile.Records {
+		owner := record.Name + "."
+		if record.Name == zoneFile.Origin {
+			owner = "@"
+		} else if strings.HasSuffix(record.Name, "."+zoneFile.Origin) {
+			owner = strings.TrimSuffix(record.Name, "."+zoneFile.Origin)
+		}
+		ttl := ""
+		if record.TTL != 0 {
+			ttl = strconv.FormatInt(record.TTL, 10)
+		}
+		fmt.Fprintf(&buffer, "%s\t%s\tIN\t%s\t%s\n", owner, ttl, record.Type, formatBindRdata(record.Type, record.Rdata))
+	}
+	return buffer.Bytes()
+}
+
+// qualified returns the record with its name and the domain names of its rdata made fully qualified, relative
+// names being taken as relative to origin, and moved from origin to target.
+func (record ZoneFileRecord) qualified(origin string, target string) ZoneFileRecord {
+	if origin == "" {
+		origin = target
+	}
+	record.Type = strings.ToUpper(record.Type)
+	record.Name = moveDomainName(qualifyDomainName(record.Name, origin), origin, target)
+	if record.Name == "@" || record.Name == "@."+normalizeDomainName(target) {
+		record.Name = normalizeDomainName(target)
+	}
+	rdata := make(map[string]interface{}, len(record.Rdata))
+	for key, value := range record.Rdata {
+		rdata[key] = value
+	}
+	for _, key := range rdataDomainNames {
+		if name, ok := rdata[key].(string); ok {
+			rdata[key] = normalizeDomainName(name)
+		}
+	}
+	record.Rdata = rdata
+	if record.Type == ResourceRecord_Type_Srv && record.Service == "" {
+		labels := strings.SplitN(record.Name, ".", 3)
+		if len(labels) == 3 && strings.HasPrefix(labels[0], "_") && strings.HasPrefix(labels[1], "_") {
+			record.Service, record.Protocol = labels[0], strings.TrimPrefix(labels[1], "_")
+		}
+	}
+	return record
+}
+
+// key identifies a resource record by type, name and rdata. Domain names are compared case-insensitively and numbers
+// by value.
+func (record ZoneFileRecord) key() string {
+	rdata := make(map[string]interface{}, len(record.Rdata))
+	for key, value := range record.Rdata {
+		switch value := value.(type) {
+		case int:
+			rdata[key] = float64(value)
+		case int64:
+			rdata[key] = float64(value)
+		default:
+			rdata[key] = value
+		}
+	}
+	for _, key := range rdataDomainNames {
+		if name, ok := rdata[key].(string); ok {
+			rdata[key] = normalizeDomainName(name)
+		}
+	}
+	data, _ := json.Marshal(rdata)
+	return strings.ToUpper(record.Type) + " " + normalizeDomainName(record.Name) + " " + string(data)
+}
+
+// resourceRecord returns the resource record to create or update, with the default TTL applied.
+func (record ZoneFileRecord) resourceRecord(defaultTTL int64) *ResourceRecord {
+	resourceRecord := &ResourceRecord{
+		Name:  core.StringPtr(record.Name),
+		Type:  core.StringPtr(record.Type),
+		Rdata: record.Rdata,
+	}
+	if record.TTL != 0 {
+		resourceRecord.TTL = core.Int64Ptr(record.TTL)
+	} else if defaultTTL != 0 {
+		resourceRecord.TTL = core.Int64Ptr(defaultTTL)
+	}
+	if record.Service != "" {
+		resourceRecord.Service = core.StringPtr(record.Service)
+		resourceRecord.Protocol = core.StringPtr(record.Protocol)
+	}
+	return resourceRecord
+}
+
+// rdataDomainNames are the rdata properties holding domain names.
+var rdataDomainNames = []string{"cname", "exchange", "target", "ptrdname"}
+
+// bindRdataFields are the rdata properties of each type in the order of a BIND zone file.
+var bindRdataFields = map[string][]string{
+	ResourceRecord_Type_A:     {"ip"},
+	ResourceRecord_Type_Aaaa:  {"ip"},
+	ResourceRecord_Type_Cname: {"cname"},
+	ResourceRecord_Type_Ptr:   {"ptrdname"},
+	ResourceRecord_Type_Mx:    {"preference", "exchange"},
+	ResourceRecord_Type_Srv:   {"priority", "weight", "port", "target"},
+	ResourceRecord_Type_Caa:   {"flags", "tag", "value"},
+	ResourceRecord_Type_Txt:   {"text"},
+}
+
+func formatBindRdata(recordType string, rdata map[string]interface{}) string {
+	var fields []string
+	for _, key := range bindRdataFields[recordType] {
+		value := rdata[key]
+		if value == nil {
+			value = 0
+		}
+		text := fmt.Sprint(value)
+		if number, ok := value.(float64); ok {
+			text = strconv.FormatFloat(number, 'f', -1, 64)
+		}
+		switch {
+		case key == "text":
+			fields = append(fields, quoteTxtData(text))
+		case key == "value":
+			fields = append(fields, strconv.Quote(text))
+		case recordType != ResourceRecord_Type_Txt && isRdataDomainName(key):
+			fields = append(fields, text+".")
+		default:
+			fields = append(fields, text)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+func isRdataDomainName(key string) bool {
+	for _, name := range rdataDomainNames {
+		if key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteTxtData quotes TXT data as BIND character strings, splitting it every 255 characters.
+func quoteTxtData(text string) string {
+	var strs []string
+	for len(text) > 255 {
+		strs = append(strs, text[:255])
+		text = text[255:]
+	}
+	strs = append(strs, text)
+	for i, str := range strs {
+		str = strings.Replace(str, `\`, `\\`, -1)
+		strs[i] = `"` + strings.Replace(str, `"`, `\"`, -1) + `"`
+	}
+	return strings.Join(strs, " ")
+}
+
+// bindToken is a word or character string of a BIND zone file.
+type bindToken struct {
+	text   string
+	quoted bool
+}
+
+// bindEntry is a logical line of a BIND zone file, joined across parentheses.
+type bindEntry struct {
+	line         int
+	tokens       []bindToken
+	inheritOwner bool
+}
+
+func parseBindZoneFile(data []byte, origin string) (zoneFile *ZoneFile, err error) {
+	entries, err := splitBindEntries(string(data))
+	if err != nil {
+		return
+	}
+	origin = normalizeDomainName(origin)
+	zoneFile = &ZoneFile{Origin: origin, Records: []ZoneFileRecord{}}
+	owner := ""
+	for _, entry := range entries {
+		tokens := entry.tokens
+		switch strings.ToUpper(tokens[0].text) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN takes one domain name", entry.line)
+			}
+			origin = absoluteBindName(tokens[1].text, origin)
+			if zoneFile.Origin == "" {
+				zoneFile.Origin = origin
+			}
+			continue
+		case "$TTL":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL takes one TTL", entry.line)
+			}
+			zoneFile.TTL, err = parseBindTTL(tokens[1].text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", entry.line, err.Error())
+			}
+			continue
+		}
+		if strings.HasPrefix(tokens[0].text, "$") && !tokens[0].quoted {
+			return nil, fmt.Errorf("line %d: unsupported directive %s", entry.line, tokens[0].text)
+		}
+		if !entry.inheritOwner {
+			owner = absoluteBindName(tokens[0].text, origin)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: the record has no owner name", entry.line)
+		}
+		record := ZoneFileRecord{Name: owner}
+		for len(tokens) > 0 {
+			if ttl, ttlErr := parseBindTTL(tokens[0].text); ttlErr == nil && record.TTL == 0 {
+				record.TTL = ttl
+			} else if class := strings.ToUpper(tokens[0].text); class != "IN" {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: the record has no type", entry.line)
+		}
+		record.Type = strings.ToUpper(tokens[0].text)
+		if record.Type == ResourceRecord_Type_Soa || record.Type == ResourceRecord_Type_Ns {
+			continue
+		}
+		record.Rdata, err = parseBindRdata(record.Type, tokens[1:], origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err.Error())
+		}
+		zoneFile.Records = append(zoneFile.Records, record)
+	}
+	return
+}
+
+func parseBindRdata(recordType string, tokens []bindToken, origin string) (rdata map[string]interface{}, err error) {
+	fields, supported := bindRdataFields[recordType]
+	if !supported {
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+	rdata = make(map[string]interface{})
+	if recordType == ResourceRecord_Type_Txt {
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("TXT record has no data")
+		}
+		var text strings.Builder
+		for _, token := range tokens {
+			text.WriteString(token.text)
+		}
+		rdata["text"] = text.String()
+		return
+	}
+	if len(tokens) != len(fields) {
+		return nil, fmt.Errorf("%s record takes %s", recordType, strings.Join(fields, ", "))
+	}
+	for i, key := range fields {
+		text := tokens[i].text
+		switch key {
+		case "ip":
+			ip := net.ParseIP(text)
+			if ip == nil || (ip.To4() != nil) != (recordType == ResourceRecord_Type_A) {
+				return nil, fmt.Errorf("invalid %s address %q", recordType, text)
+			}
+			rdata[key] = text
+		case "preference", "priority", "weight", "port", "flags":
+			number, parseErr := strconv.ParseInt(text, 10, 64)
+			if parseErr != nil || number < 0 {
+				return nil, fmt.Errorf("invalid %s %q", key, text)
+			}
+			rdata[key] = number
+		case "tag", "value":
+			rdata[key] = text
+		default:
+			rdata[key] = absoluteBindName(text, origin)
+		}
+	}
+	return
+}
+
+// absoluteBindName returns a domain name of a BIND zone file fully qualified, without the trailing dot.
+func absoluteBindName(name string, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return normalizeDomainName(name)
+	}
+	if origin == "" {
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name) + "." + origin
+}
+
+// parseBindTTL parses a TTL in seconds, or with the BIND units s, m, h, d and w such as "1h30m".
+func parseBindTTL(text string) (ttl int64, err error) {
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	lower := strings.ToLower(text)
+	if lower == "" || lower[0] < '0' || lower[0] > '9' {
+		return 0, fmt.Errorf("invalid TTL %q", text)
+	}
+	number := int64(0)
+	for i := 0; i < len(lower); i++ {
+		c := lower[i]
+		switch {
+		case c >= '0' && c <= '9':
+			number = number*10 + int64(c-'0')
+		case units[c] != 0:
+			ttl += number * units[c]
+			number = 0
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", text)
+		}
+	}
+	return ttl + number, nil
+}
+
+// splitBindEntries splits a BIND zone file into entries, dropping comments and joining lines within parentheses.
+func splitBindEntries(text string) (entries []bindEntry, err error) {
+	line, depth, openLine := 1, 0, 0
+	var entry *bindEntry
+	var word strings.Builder
+	inWord := false
+	endWord := func(quoted bool) {
+		if inWord || quoted {
+			if entry == nil {
+				entry = &bindEntry{line: line}
+			}
+			entry.tokens = append(entry.tokens, bindToken{text: word.String(), quoted: quoted})
+		}
+		word.Reset()
+		inWord = false
+	}
+	startOfLine := true
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if startOfLine && depth == 0 && (c == ' ' || c == '\t') && entry == nil {
+			entry = &bindEntry{line: line, inheritOwner: true}
+		}
+		startOfLine = false
+		switch c {
+		case '"':
+			endWord(false)
+			quoteLine := line
+			for i++; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' && i+1 < len(text) {
+					i++
+				}
+				if text[i] == '\n' {
+					line++
+				}
+				word.WriteByte(text[i])
+			}
+			if i >= len(text) {
+				return nil, fmt.Errorf("line %d: unterminated character string", quoteLine)
+			}
+			endWord(true)
+		case ';':
+			endWord(false)
+			for i+1 < len(text) && text[i+1] != '\n' {
+				i++
+			}
+		case '(':
+			endWord(false)
+			if depth == 0 {
+				openLine = line
+			}
+			depth++
+		case ')':
+			endWord(false)
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parenthesis", line)
+			}
+			depth--
+		case ' ', '\t', '\r':
+			endWord(false)
+		case '\n':
+			endWord(false)
+			if depth == 0 {
+				if entry != nil && len(entry.tokens) > 0 {
+					entries = append(entries, *entry)
+				}
+				entry = nil
+				startOfLine = true
+			}
+			line++
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	endWord(false)
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parenthesis", openLine)
+	}
+	if entry != nil && len(entry.tokens) > 0 {
+		entries = append(entries, *entry)
+	}
+	return
+}
@@ -0,0 +1,181 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dnssvcsv1_test
+
+import (
+	"strings"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/dns-svcs-go-sdk/internal/fakednssvcs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Zone files`, func() {
+	const bindZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1 hostmaster ( 1 7200 3600 1209600 3600 ) ; managed by the service
+	IN	NS	ns1.example.net.
+www	300	IN	A	10.0.0.1
+	IN	A	10.0.0.2
+api	IN 60	AAAA	2001:db8::1
+alias		CNAME	www
+@		MX	10 mail.example.net.
+_sip._udp.voip	SRV	( 1 5 5060
+			  sip.example.com. )
+@		TXT	"v=spf1 include:_spf.example.net ~all"
+long		TXT	"part one " "part \"two\""
+@		CAA	0 issue "ca.example.net"
+`
+	It(`Invoke ParseZoneFile with a BIND zone file`, func() {
+		zoneFile, err := dnssvcsv1.ParseZoneFile([]byte(bindZoneFile), dnssvcsv1.ZoneFileFormat_Bind, "")
+		Expect(err).To(BeNil())
+		Expect(zoneFile.Origin).To(Equal("example.com"))
+		Expect(zoneFile.TTL).To(Equal(int64(3600)))
+		Expect(zoneFile.Records).To(HaveLen(9))
+		Expect(zoneFile.Records[0].String()).To(Equal("www.example.com. 300 IN A 10.0.0.1"))
+		Expect(zoneFile.Records[1].Name).To(Equal("www.example.com"))
+		Expect(zoneFile.Records[2].TTL).To(Equal(int64(60)))
+		Expect(zoneFile.Records[3].Rdata).To(Equal(map[string]interface{}{"cname": "www.example.com"}))
+		Expect(zoneFile.Records[4].String()).To(Equal("example.com. IN MX 10 mail.example.net."))
+		srv := zoneFile.Records[5]
+		Expect(srv.Name).To(Equal("_sip._udp.voip.example.com"))
+		Expect(srv.Service).To(Equal("_sip"))
+		Expect(srv.Protocol).To(Equal("udp"))
+		Expect(srv.Rdata).To(Equal(map[string]interface{}{"priority": int64(1), "weight": int64(5), "port": int64(5060), "target": "sip.example.com"}))
+		Expect(zoneFile.Records[7].Rdata["text"]).To(Equal(`part one part "two"`))
+		Expect(zoneFile.Records[8].String()).To(Equal(`example.com. IN CAA 0 issue "ca.example.net"`))
+
+		_, err = dnssvcsv1.ParseZoneFile([]byte("www A 10.0.0.1\n"), dnssvcsv1.ZoneFileFormat_Bind, "example.org")
+		Expect(err).To(BeNil())
+		for _, invalid := range []string{"www A 10.0.0.256\n", "www AAAA 10.0.0.1\n", "www MX mail\n", "www HINFO a b\n", "www TXT \"open\n", "www ( A 10.0.0.1\n", "$INCLUDE other.zone\n"} {
+			_, err = dnssvcsv1.ParseZoneFile([]byte(invalid), dnssvcsv1.ZoneFileFormat_Bind, "example.org")
+			Expect(err).ToNot(BeNil(), invalid)
+			Expect(err.Error()).To(ContainSubstring("line 1"))
+		}
+	})
+	It(`Invoke Format and ParseZoneFile round trips`, func() {
+		zoneFile, err := dnssvcsv1.ParseZoneFile([]byte(bindZoneFile), dnssvcsv1.ZoneFileFormat_Bind, "")
+		Expect(err).To(BeNil())
+		for _, format := range []string{dnssvcsv1.ZoneFileFormat_Bind, dnssvcsv1.ZoneFileFormat_Json, dnssvcsv1.ZoneFileFormat_Yaml} {
+			data, err := zoneFile.Format(format)
+			Expect(err).To(BeNil())
+			parsed, err := dnssvcsv1.ParseZoneFile(data, format, "")
+			Expect(err).To(BeNil(), format)
+			Expect(dnssvcsv1.DiffZoneFiles(parsed, zoneFile).HasDrift()).To(BeFalse(), format)
+			Expect(dnssvcsv1.DiffZoneFiles(zoneFile, parsed).HasDrift()).To(BeFalse(), format)
+		}
+		data, _ := zoneFile.Format(dnssvcsv1.ZoneFileFormat_Bind)
+		Expect(strings.Split(string(data), "\n")[2]).To(Equal("www\t300\tIN\tA\t10.0.0.1"))
+
+		_, err = dnssvcsv1.ParseZoneFile([]byte("origin: example.com\nrecords:\n- name: www\n  type: ns\n  rdata: {}\n"), dnssvcsv1.ZoneFileFormat_Yaml, "")
+		Expect(err).ToNot(BeNil())
+		_, err = dnssvcsv1.ParseZoneFile([]byte(`{"origin": "example.com", "record": []}`), dnssvcsv1.ZoneFileFormat_Json, "")
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke DiffZoneFiles successfully`, func() {
+		live := &dnssvcsv1.ZoneFile{Origin: "example.com", Records: []dnssvcsv1.ZoneFileRecord{
+			{ID: "1", Name: "www.example.com", Type: "A", TTL: 900, Rdata: map[string]interface{}{"ip": "10.0.0.1"}},
+			{ID: "2", Name: "www.example.com", Type: "A", TTL: 900, Rdata: map[string]interface{}{"ip": "10.0.0.2"}},
+			{ID: "3", Name: "mail.example.com", Type: "MX", TTL: 900, Rdata: map[string]interface{}{"preference": float64(10), "exchange": "MX.example.net"}},
+			{ID: "4", Name: "old.example.com", Type: "CNAME", TTL: 900, Rdata: map[string]interface{}{"cname": "www.example.com"}},
+		}}
+		desired, err := dnssvcsv1.ParseZoneFile([]byte(`
+www	A	10.0.0.1
+www	A	10.0.0.3
+mail	60	MX	10 mx.example.net.
+new	CNAME	www
+`), dnssvcsv1.ZoneFileFormat_Bind, "example.com")
+		Expect(err).To(BeNil())
+		diff := dnssvcsv1.DiffZoneFiles(desired, live)
+		Expect(diff.HasDrift()).To(BeTrue())
+		Expect(diff.Missing).To(HaveLen(1))
+		Expect(diff.Missing[0].Name).To(Equal("new.example.com"))
+		Expect(diff.Extra).To(HaveLen(1))
+		Expect(diff.Extra[0].ID).To(Equal("4"))
+		Expect(diff.Changed).To(HaveLen(2))
+		Expect(diff.Changed[0].Live.ID).To(Equal("3"))
+		Expect(diff.Changed[0].Desired.TTL).To(Equal(int64(60)))
+		Expect(diff.Changed[0].Properties).To(Equal([]string{"ttl"}))
+		Expect(diff.Changed[1].Properties).To(Equal([]string{"rdata"}))
+		Expect(diff.Changed[1].Live.ID).To(Equal("2"))
+		Expect(diff.String()).To(Equal(`+ new.example.com. IN CNAME www.example.com.
+- old.example.com. 900 IN CNAME www.example.com.
+~ mail.example.com. 60 IN MX 10 mx.example.net. (was mail.example.com. 900 IN MX 10 MX.example.net.)
+~ www.example.com. IN A 10.0.0.3 (was www.example.com. 900 IN A 10.0.0.2)
+`))
+	})
+	Describe(`With a DNS zone`, func() {
+		var fake *fakednssvcs.Server
+		var testService *dnssvcsv1.DnsSvcsV1
+		const recordsPath = "/instances/instance/dnszones/zone/resource_records"
+		BeforeEach(func() {
+			fake = fakednssvcs.NewServer()
+			testService = fake.Service()
+			fake.Add("/instances/instance/dnszones", map[string]interface{}{"id": "zone", "name": "example.com"})
+			fake.Add(recordsPath, map[string]interface{}{"id": "soa", "type": "SOA", "name": "example.com", "ttl": 3600, "rdata": map[string]interface{}{"mname": "ns1.example.com"}})
+			fake.Add(recordsPath, map[string]interface{}{"id": "www", "type": "A", "name": "www.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.1"}})
+			fake.Add(recordsPath, map[string]interface{}{"id": "old", "type": "A", "name": "old.example.com", "ttl": 900, "rdata": map[string]interface{}{"ip": "10.0.0.9"}})
+		})
+		AfterEach(func() {
+			fake.Close()
+		})
+		It(`Invoke ExportZoneFile successfully`, func() {
+			zoneFile, err := testService.ExportZoneFile("instance", "zone")
+			Expect(err).To(BeNil())
+			data, err := zoneFile.Format(dnssvcsv1.ZoneFileFormat_Bind)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal("$ORIGIN example.com.\nold\t900\tIN\tA\t10.0.0.9\nwww\t900\tIN\tA\t10.0.0.1\n"))
+			Expect(zoneFile.Records[1].ID).To(Equal("www"))
+		})
+		It(`Invoke ImportZoneFile successfully`, func() {
+			zoneFile, err := dnssvcsv1.ParseZoneFile([]byte("$TTL 300\nwww A 10.0.0.2\n_sip._tcp SRV 1 5 5060 sip\n"), dnssvcsv1.ZoneFileFormat_Bind, "example.com")
+			Expect(err).To(BeNil())
+
+			result, err := testService.ImportZoneFile(testService.NewImportZoneFileOptions("instance", "zone", zoneFile).SetDryRun(true))
+			Expect(err).To(BeNil())
+			Expect(result.Diff.Missing).To(HaveLen(1))
+			Expect(result.Diff.Changed).To(HaveLen(1))
+			Expect(result.Diff.Extra).To(HaveLen(1))
+			Expect(result.Created).To(BeEmpty())
+			Expect(fake.List(recordsPath)).To(HaveLen(3))
+
+			result, err = testService.ImportZoneFile(testService.NewImportZoneFileOptions("instance", "zone", zoneFile).SetPrune(true))
+			Expect(err).To(BeNil())
+			Expect(result.Created).To(HaveLen(1))
+			Expect(result.Updated).To(HaveLen(1))
+			Expect(result.Deleted).To(HaveLen(1))
+			Expect(fake.Bodies["POST "+recordsPath]).To(Equal(map[string]interface{}{
+				"name": "example.com", "type": "SRV", "ttl": float64(300), "service": "_sip", "protocol": "tcp",
+				"rdata": map[string]interface{}{"priority": float64(1), "weight": float64(5), "port": float64(5060), "target": "sip.example.com"},
+			}))
+			Expect(fake.Bodies["PUT "+recordsPath+"/www"]["rdata"]).To(Equal(map[string]interface{}{"ip": "10.0.0.2"}))
+			Expect(fake.SortedNames(recordsPath)).To(Equal([]string{"_sip._tcp.example.com", "example.com", "www.example.com"}))
+
+			result, err = testService.ImportZoneFile(testService.NewImportZoneFileOptions("instance", "zone", zoneFile))
+			Expect(err).To(BeNil())
+			Expect(result.Diff.HasDrift()).To(BeFalse())
+		})
+		It(`Invoke ImportZoneFile with error`, func() {
+			_, err := testService.ImportZoneFile(testService.NewImportZoneFileOptions("instance", "zone", &dnssvcsv1.ZoneFile{Origin: "example.org"}))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("zone file is for example.org"))
+			_, err = testService.ImportZoneFile(nil)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
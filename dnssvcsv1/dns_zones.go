@@ -89,6 +89,38 @@ func (dnsSvcs *DnsSvcsV1) ListDnszones(listDnszonesOptions *ListDnszonesOptions)
 	return
 }
 
+// ListAllDnszones : List all DNS zones
+// List the DNS zones of a given service instance, walking every page from the given Offset. The Limit of the options
+// sets the page size.
+func (dnsSvcs *DnsSvcsV1) ListAllDnszones(listDnszonesOptions *ListDnszonesOptions) (dnszones []Dnszone, err error) {
+	err = core.ValidateNotNil(listDnszonesOptions, "listDnszonesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	pageOptions := *listDnszonesOptions
+	if pageOptions.Offset == nil {
+		pageOptions.Offset = core.Int64Ptr(0)
+	}
+	if pageOptions.Limit == nil {
+		pageOptions.Limit = core.Int64Ptr(defaultPageLimit)
+	}
+	for {
+		result, _, listErr := dnsSvcs.ListDnszones(&pageOptions)
+		if listErr != nil {
+			err = listErr
+			return
+		}
+		dnszones = append(dnszones, result.Dnszones...)
+		if len(result.Dnszones) == 0 || result.TotalCount == nil {
+			return
+		}
+		pageOptions.Offset = core.Int64Ptr(*pageOptions.Offset + int64(len(result.Dnszones)))
+		if *pageOptions.Offset >= *result.TotalCount {
+			return
+		}
+	}
+}
+
 // CreateDnszone : Create a DNS zone
 // Create a DNS zone for a given service instance.
 func (dnsSvcs *DnsSvcsV1) CreateDnszone(createDnszoneOptions *CreateDnszoneOptions) (result *Dnszone, response *core.DetailedResponse, err error) {
@@ -3,6 +3,7 @@ module github.com/IBM/dns-svcs-go-sdk
 go 1.13
 
 require (
+	github.com/IBM/go-sdk-core/v3 v3.3.1
 	github.com/IBM/go-sdk-core/v4 v4.0.4
 	github.com/go-openapi/strfmt v0.19.5
 	github.com/joho/godotenv v1.3.0
@@ -10,4 +11,6 @@ require (
 	github.com/onsi/gomega v1.9.0
 	github.com/stretchr/testify v1.5.1
 	github.com/watson-developer-cloud/go-sdk v1.5.0
+	golang.org/x/net v0.0.0-20180906233101-161cd47e91fd
+	gopkg.in/yaml.v2 v2.2.4
 )
@@ -0,0 +1,323 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakednssvcs provides an in-memory stand-in for the DNS Services API, for the tests of the dnssvcsv1 helpers
+// and of the dnssvcs command line.
+package fakednssvcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/IBM/dns-svcs-go-sdk/dnssvcsv1"
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// Server is an in-memory stand-in for the DNS Services API. Objects are kept as JSON maps by collection path, for
+// example "/instances/i/dnszones" or "/instances/i/dnszones/z/resource_records".
+type Server struct {
+	// The URL of the server, for DnsSvcsV1Options.
+	URL string
+
+	server *httptest.Server
+
+	mutex       sync.Mutex
+	collections map[string][]map[string]interface{}
+	nextID      int
+
+	// Requests received, as "METHOD path".
+	Requests []string
+
+	// The body of the last request received for each "METHOD path".
+	Bodies map[string]map[string]interface{}
+
+	// Failures maps "METHOD path" patterns, as matched by path.Match, to the status code returned instead of serving
+	// the request.
+	Failures map[string]int
+
+	// OriginHealth maps pool origin addresses to the health the service reports for them, healthy when absent.
+	OriginHealth map[string]bool
+
+	// SettleLists is the number of times permitted networks are listed before created ones become ACTIVE and deleted
+	// ones disappear; zero settles them at once. settling counts down the lists left by permitted network ID.
+	SettleLists int
+	settling    map[string]int
+
+	// PageLimit is the number of objects listed when a list request sets no limit; zero lists them all.
+	PageLimit int
+}
+
+// NewServer starts a Server with no objects.
+func NewServer() *Server {
+	fake := &Server{
+		collections:  make(map[string][]map[string]interface{}),
+		Failures:     make(map[string]int),
+		OriginHealth: make(map[string]bool),
+		Bodies:       make(map[string]map[string]interface{}),
+		settling:     make(map[string]int),
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	fake.URL = fake.server.URL
+	return fake
+}
+
+// Close shuts the server down.
+func (fake *Server) Close() {
+	fake.server.Close()
+}
+
+// Service returns a DnsSvcsV1 client of the server, without authentication.
+func (fake *Server) Service() *dnssvcsv1.DnsSvcsV1 {
+	testService, err := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+		URL:           fake.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return testService
+}
+
+// Add stores an object in a collection, assigning an id when it has none, and returns the id.
+func (fake *Server) Add(collection string, object map[string]interface{}) string {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return fake.insert(collection, object)
+}
+
+// List returns a copy of the objects of a collection.
+func (fake *Server) List(collection string) []map[string]interface{} {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]map[string]interface{}{}, fake.collections[collection]...)
+}
+
+func (fake *Server) insert(collection string, object map[string]interface{}) string {
+	if _, ok := object["id"]; !ok {
+		fake.nextID++
+		object["id"] = fmt.Sprintf("%08d-0000-4000-8000-000000000000", fake.nextID)
+	}
+	if _, ok := object["created_on"]; !ok {
+		object["created_on"] = "2019-01-01T12:00:00Z"
+		object["modified_on"] = "2019-01-01T12:00:00Z"
+	}
+	fake.collections[collection] = append(fake.collections[collection], object)
+	return object["id"].(string)
+}
+
+func (fake *Server) serveHTTP(res http.ResponseWriter, req *http.Request) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	request := req.Method + " " + req.URL.Path
+	fake.Requests = append(fake.Requests, request)
+	res.Header().Set("Content-type", "application/json")
+	for pattern, status := range fake.Failures {
+		if matched, _ := path.Match(pattern, request); matched {
+			res.WriteHeader(status)
+			fmt.Fprintf(res, `{"code": %d, "message": "injected failure"}`, status)
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	collection, id := req.URL.Path, ""
+	if len(segments)%2 == 0 {
+		collection = "/" + strings.Join(segments[:len(segments)-1], "/")
+		id = segments[len(segments)-1]
+	}
+
+	var body, recorded map[string]interface{}
+	if req.Body != nil {
+		data, _ := ioutil.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &body)
+		_ = json.Unmarshal(data, &recorded)
+	}
+	fake.Bodies[request] = recorded
+
+	switch {
+	case id == "" && req.Method == http.MethodGet:
+		fake.serveList(res, req, collection)
+	case id == "" && req.Method == http.MethodPost:
+		if body == nil {
+			body = make(map[string]interface{})
+		}
+		fake.complete(collection, body)
+		id := fake.insert(collection, body)
+		if strings.HasSuffix(collection, "/permitted_networks") {
+			fake.settle(collection, id, fake.SettleLists)
+		}
+		res.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(res).Encode(body)
+	default:
+		index := -1
+		for i, object := range fake.collections[collection] {
+			if object["id"] == id {
+				index = i
+			}
+		}
+		if index < 0 {
+			res.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(res, `{"code": 404, "message": "%s not found"}`, id)
+			return
+		}
+		object := fake.collections[collection][index]
+		switch req.Method {
+		case http.MethodGet:
+			fake.complete(collection, object)
+			_ = json.NewEncoder(res).Encode(object)
+		case http.MethodPut, http.MethodPatch:
+			for key, value := range body {
+				object[key] = value
+			}
+			fake.complete(collection, object)
+			object["modified_on"] = fmt.Sprintf("2019-01-01T12:%02d:00Z", len(fake.Requests)%60)
+			_ = json.NewEncoder(res).Encode(object)
+		case http.MethodDelete:
+			if strings.HasSuffix(collection, "/permitted_networks") {
+				object["state"] = dnssvcsv1.PermittedNetwork_State_RemovalInProgress
+				res.WriteHeader(http.StatusAccepted)
+				_ = json.NewEncoder(res).Encode(object)
+				fake.settle(collection, id, fake.SettleLists)
+				return
+			}
+			objects := fake.collections[collection]
+			fake.collections[collection] = append(objects[:index:index], objects[index+1:]...)
+			res.WriteHeader(http.StatusNoContent)
+		default:
+			res.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (fake *Server) serveList(res http.ResponseWriter, req *http.Request, collection string) {
+	if strings.HasSuffix(collection, "/permitted_networks") {
+		for _, object := range append([]map[string]interface{}{}, fake.collections[collection]...) {
+			id := object["id"].(string)
+			if lists, found := fake.settling[id]; found {
+				fake.settle(collection, id, lists-1)
+			}
+		}
+	}
+	objects := fake.collections[collection]
+	offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(req.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = fake.PageLimit
+	}
+	if limit <= 0 {
+		limit = len(objects)
+	}
+	page := []map[string]interface{}{}
+	if offset < len(objects) {
+		end := offset + limit
+		if end > len(objects) {
+			end = len(objects)
+		}
+		page = objects[offset:end]
+	}
+	segments := strings.Split(collection, "/")
+	result := map[string]interface{}{
+		segments[len(segments)-1]: page,
+		"offset":                  offset,
+		"limit":                   limit,
+		"total_count":             len(objects),
+		"first":                   map[string]interface{}{"href": fake.server.URL + collection},
+	}
+	_ = json.NewEncoder(res).Encode(result)
+}
+
+// settle makes a permitted network ACTIVE, or removes it when it is being removed, once no lists are left, and
+// activates a DNS zone waiting for its first permitted network.
+func (fake *Server) settle(collection string, id string, lists int) {
+	if lists > 0 {
+		fake.settling[id] = lists
+		return
+	}
+	delete(fake.settling, id)
+	objects := fake.collections[collection]
+	for i, object := range objects {
+		if object["id"] != id {
+			continue
+		}
+		if object["state"] == dnssvcsv1.PermittedNetwork_State_RemovalInProgress {
+			fake.collections[collection] = append(objects[:i:i], objects[i+1:]...)
+			return
+		}
+		object["state"] = dnssvcsv1.PermittedNetwork_State_Active
+	}
+	zonePath := strings.TrimSuffix(collection, "/permitted_networks")
+	for _, zone := range fake.collections[zonePath[:strings.LastIndex(zonePath, "/")]] {
+		if zone["id"] == zonePath[strings.LastIndex(zonePath, "/")+1:] && zone["state"] == dnssvcsv1.Dnszone_State_PendingNetworkAdd {
+			zone["state"] = dnssvcsv1.Dnszone_State_Active
+		}
+	}
+}
+
+// complete fills in the properties the service derives itself, such as the fully qualified name of a resource record.
+func (fake *Server) complete(collection string, object map[string]interface{}) {
+	if strings.HasSuffix(collection, "/pools") {
+		origins, _ := object["origins"].([]interface{})
+		for _, origin := range origins {
+			if origin, ok := origin.(map[string]interface{}); ok {
+				healthy, ok := fake.OriginHealth[fmt.Sprint(origin["address"])]
+				origin["health"] = healthy || !ok
+			}
+		}
+		return
+	}
+	if !strings.HasSuffix(collection, "/resource_records") {
+		return
+	}
+	zonePath := strings.TrimSuffix(collection, "/resource_records")
+	zoneID := zonePath[strings.LastIndex(zonePath, "/")+1:]
+	zones := fake.collections[zonePath[:strings.LastIndex(zonePath, "/")]]
+	for _, zone := range zones {
+		if zone["id"] != zoneID {
+			continue
+		}
+		zoneName := zone["name"].(string)
+		if name, ok := object["name"].(string); ok && name != zoneName && !strings.HasSuffix(name, "."+zoneName) {
+			object["name"] = name + "." + zoneName
+		}
+		if object["type"] == "SRV" {
+			prefix := fmt.Sprintf("%v._%v.", object["service"], object["protocol"])
+			if name := object["name"].(string); !strings.HasPrefix(name, prefix) {
+				object["name"] = prefix + name
+			}
+		}
+	}
+	if _, ok := object["ttl"]; !ok {
+		object["ttl"] = 900
+	}
+}
+
+// SortedNames returns the sorted "name" properties of the objects of a collection.
+func (fake *Server) SortedNames(collection string) (names []string) {
+	for _, object := range fake.List(collection) {
+		names = append(names, fmt.Sprint(object["name"]))
+	}
+	sort.Strings(names)
+	return
+}